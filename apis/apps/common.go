@@ -1,5 +1,6 @@
 package apps
 
 const (
-	APIcastKind = "APIcast"
+	APIcastKind      = "APIcast"
+	APIcastFleetKind = "APIcastFleet"
 )
@@ -17,16 +17,26 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"reflect"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	appscommon "github.com/3scale/apicast-operator/apis/apps"
+	"github.com/3scale/apicast-operator/pkg/helper"
 	"github.com/3scale/apicast-operator/pkg/k8sutils"
 	"github.com/3scale/apicast-operator/version"
 )
@@ -36,6 +46,8 @@ const (
 	APIcastThreescaleVersionAnnotation        = "apicast.apps.3scale.net/apicast-threescale-version"
 	ReadyConditionType                 string = "Ready"
 	WarningConditionType               string = "Warning"
+	ConfigurationValidConditionType    string = "ConfigurationValid"
+	AdminPortalReachableConditionType  string = "AdminPortalReachable"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -46,6 +58,23 @@ type CustomEnvironmentSpec struct {
 	SecretRef *v1.LocalObjectReference `json:"secretRef"`
 }
 
+// SecretSourceSpec declares a managed copy of a secret living in another namespace
+type SecretSourceSpec struct {
+	// Name of the managed copy created in the APIcast namespace. Any secret
+	// reference of the APIcast spec can point to it.
+	Name string `json:"name"`
+	// SourceRef references the secret to be copied.
+	SourceRef SecretSourceRef `json:"sourceRef"`
+}
+
+// SecretSourceRef references a secret in another namespace
+type SecretSourceRef struct {
+	// Namespace of the source secret
+	Namespace string `json:"namespace"`
+	// Name of the source secret
+	Name string `json:"name"`
+}
+
 // CustomPolicySpec contains or has reference to an APIcast custom policy
 type CustomPolicySpec struct {
 	// Name specifies the name of the custom policy
@@ -65,6 +94,415 @@ type PodDisruptionBudgetSpec struct {
 	Enabled bool `json:"enabled,omitempty"`
 }
 
+// AutoscalingSpec contains the HorizontalPodAutoscaler configuration of the
+// APIcast deployment
+type AutoscalingSpec struct {
+	// MinReplicas is the lower limit for the number of replicas the autoscaler
+	// can scale down to. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit for the number of replicas the autoscaler
+	// can scale up to. Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// CPUTargetUtilization is the target average CPU utilization, represented
+	// as a percentage of the requested CPU. Defaults to 85. Set to 0 to disable
+	// the CPU based target.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CPUTargetUtilization *int32 `json:"cpuTargetUtilization,omitempty"`
+	// MemoryTargetUtilization is the target average memory utilization,
+	// represented as a percentage of the requested memory. Defaults to 85. Set
+	// to 0 to disable the memory based target.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MemoryTargetUtilization *int32 `json:"memoryTargetUtilization,omitempty"`
+	// Metrics contains additional metrics used to calculate the desired
+	// replica count, like custom or external metrics (i.e. the nginx request
+	// rate exposed by APIcast). They are added to the CPU and memory targets.
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+	// Behavior configures the scaling behavior of the autoscaler in both
+	// scale up and scale down directions.
+	// +optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// MonitoringDashboardSpec contains the configuration of the APIcast Grafana
+// dashboard
+type MonitoringDashboardSpec struct {
+	// Enabled controls whether a Grafana dashboard for the APIcast metrics
+	// is created. The dashboard is created as a GrafanaDashboard resource
+	// when the Grafana Operator API is available, otherwise as a ConfigMap
+	// for the Grafana dashboards sidecar. Dashboards are only created when
+	// monitoring is enabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Labels are added to the dashboard resource. Defaults to the
+	// grafana_dashboard: "1" label watched by the Grafana dashboards sidecar.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// InstanceSelector selects the Grafana instances the GrafanaDashboard
+	// resource is imported into. Defaults to all the Grafana instances.
+	// +optional
+	InstanceSelector *metav1.LabelSelector `json:"instanceSelector,omitempty"`
+}
+
+// MonitoringAlertsSpec contains the configuration of the default APIcast
+// alerts
+type MonitoringAlertsSpec struct {
+	// Enabled controls whether a PrometheusRule with the default APIcast
+	// alerts is created. Alerts are only created when monitoring is enabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// PodsNotReadyFor is the time the number of ready pods must stay below
+	// the desired replicas before alerting. Defaults to 5m.
+	// +optional
+	PodsNotReadyFor *monitoringv1.Duration `json:"podsNotReadyFor,omitempty"`
+	// HTTP5xxRatioThreshold is the percentage of 5xx responses over the
+	// last 5 minutes above which an alert is raised. Defaults to 5.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	HTTP5xxRatioThreshold *int32 `json:"http5xxRatioThreshold,omitempty"`
+	// WorkerRestartsThreshold is the number of nginx worker restarts over
+	// the last 15 minutes above which an alert is raised. Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WorkerRestartsThreshold *int32 `json:"workerRestartsThreshold,omitempty"`
+	// ErrorLogThreshold is the number of error log entries, like failures
+	// loading the configuration, over the last 5 minutes above which an
+	// alert is raised. Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ErrorLogThreshold *int32 `json:"errorLogThreshold,omitempty"`
+	// Severity label of the alerts. Defaults to warning.
+	// +kubebuilder:validation:Enum=critical;warning;info
+	// +optional
+	Severity *string `json:"severity,omitempty"`
+}
+
+// ProbeSpec overrides the timing settings of an APIcast container probe
+type ProbeSpec struct {
+	// Number of seconds after the container has started before the probe is initiated.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// Number of seconds after which the probe times out.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// How often (in seconds) to perform the probe.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// Minimum consecutive successes for the probe to be considered successful after having failed.
+	// Must be 1 for liveness and startup probes.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+	// Minimum consecutive failures for the probe to be considered failed after having succeeded.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// ProbesSpec contains the APIcast container probes configuration
+type ProbesSpec struct {
+	// Liveness overrides the liveness probe settings.
+	// +optional
+	Liveness *ProbeSpec `json:"liveness,omitempty"`
+	// Readiness overrides the readiness probe settings.
+	// +optional
+	Readiness *ProbeSpec `json:"readiness,omitempty"`
+	// Startup enables a startup probe holding off the liveness and readiness
+	// probes until APIcast has booted, with the given settings. Enable it when
+	// APIcast needs long to boot, for instance, with large embedded
+	// configurations.
+	// +optional
+	Startup *ProbeSpec `json:"startup,omitempty"`
+}
+
+// DeploymentStrategyType describes how the APIcast pods are replaced on updates
+// +kubebuilder:validation:Enum=RollingUpdate;Recreate
+type DeploymentStrategyType string
+
+const (
+	// RollingUpdateDeploymentStrategyType replaces the old pods gradually.
+	RollingUpdateDeploymentStrategyType DeploymentStrategyType = "RollingUpdate"
+	// RecreateDeploymentStrategyType kills all the old pods before creating new ones.
+	RecreateDeploymentStrategyType DeploymentStrategyType = "Recreate"
+)
+
+// DeploymentStrategySpec describes how the APIcast deployment replaces
+// existing pods with new ones
+type DeploymentStrategySpec struct {
+	// Type of the deployment strategy. RollingUpdate or Recreate. Defaults to RollingUpdate.
+	// +optional
+	Type *DeploymentStrategyType `json:"type,omitempty"`
+	// MaxSurge is the maximum number of pods that can be scheduled above the
+	// desired number of pods during a rolling update. Value can be an absolute
+	// number or a percentage of desired pods. Defaults to 25%.
+	// Only valid with the RollingUpdate strategy.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+	// MaxUnavailable is the maximum number of pods that can be unavailable
+	// during a rolling update. Value can be an absolute number or a
+	// percentage of desired pods. Defaults to 25%.
+	// Only valid with the RollingUpdate strategy.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// GracefulShutdownSpec configures how APIcast pods drain in-flight requests
+// before they are stopped
+type GracefulShutdownSpec struct {
+	// PreStopDelaySeconds is the time the APIcast container keeps serving
+	// after the pod is marked for termination, so the pod is removed from the
+	// service endpoints before APIcast stops accepting connections. APIcast
+	// then finishes the in-flight requests and exits. Defaults to 15.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PreStopDelaySeconds *int32 `json:"preStopDelaySeconds,omitempty"`
+	// TerminationGracePeriodSeconds is the total time given to the APIcast
+	// pod to stop, including the preStop delay, before it is killed. Must be
+	// greater than preStopDelaySeconds. Defaults to 60.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+}
+
+// CanarySpec configures canary releases of new APIcast images
+type CanarySpec struct {
+	// ReplicasPercentage is the number of canary pods, as a percentage of the
+	// stable deployment replicas, rounded up. There is always at least one canary
+	// pod. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ReplicasPercentage *int32 `json:"replicasPercentage,omitempty"`
+	// AnalysisSeconds is the time all the canary pods must stay available before
+	// the new image is promoted. Defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AnalysisSeconds *int32 `json:"analysisSeconds,omitempty"`
+	// ErrorRate aborts the canary release when the ratio of 5xx responses
+	// returned by the canary pods is too high.
+	// +optional
+	ErrorRate *CanaryErrorRateSpec `json:"errorRate,omitempty"`
+}
+
+// CanaryErrorRateSpec configures the error rate check of the canary pods
+type CanaryErrorRateSpec struct {
+	// PrometheusURL is the base URL of the Prometheus HTTP API scraping the
+	// APIcast metrics. For instance, http://prometheus-operated.monitoring.svc:9090
+	PrometheusURL string `json:"prometheusURL"`
+	// MaxPercentage is the maximum percentage of 5xx responses of the canary
+	// pods over the analysis period. Defaults to 5.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxPercentage *int32 `json:"maxPercentage,omitempty"`
+}
+
+// CanaryPhase is the state of a canary release
+type CanaryPhase string
+
+const (
+	// CanaryPhaseProgressing means the canary pods are running the new image
+	CanaryPhaseProgressing CanaryPhase = "Progressing"
+	// CanaryPhasePromoted means the new image was rolled out to the stable deployment
+	CanaryPhasePromoted CanaryPhase = "Promoted"
+	// CanaryPhaseAborted means the new image was rejected and the stable deployment kept the previous image
+	CanaryPhaseAborted CanaryPhase = "Aborted"
+)
+
+// CanaryStatus reports the progress of the last canary release
+type CanaryStatus struct {
+	// Image is the image being released.
+	Image string `json:"image"`
+	// Phase of the canary release. Progressing, Promoted or Aborted.
+	Phase CanaryPhase `json:"phase"`
+	// Replicas is the desired number of canary pods.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// AvailableReplicas is the number of available canary pods.
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// AvailableSince is the time all the canary pods became available.
+	// +optional
+	AvailableSince *metav1.Time `json:"availableSince,omitempty"`
+	// Message is a human readable description of the canary state.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// UpgradePolicyType is the policy of the upgrades of the default image
+// +kubebuilder:validation:Enum=Automatic;Manual;MaintenanceWindow
+type UpgradePolicyType string
+
+const (
+	// UpgradePolicyAutomatic upgrades the image as soon as the operator is upgraded
+	UpgradePolicyAutomatic UpgradePolicyType = "Automatic"
+	// UpgradePolicyManual upgrades the image once the version is approved
+	UpgradePolicyManual UpgradePolicyType = "Manual"
+	// UpgradePolicyMaintenanceWindow upgrades the image during the maintenance window
+	UpgradePolicyMaintenanceWindow UpgradePolicyType = "MaintenanceWindow"
+)
+
+// UpgradePolicySpec controls the upgrades of the default image. Upgrades are
+// blocked while the APIcast is not ready.
+type UpgradePolicySpec struct {
+	// Type of the upgrade policy. Automatic, Manual or MaintenanceWindow.
+	// Defaults to Automatic.
+	// +optional
+	Type *UpgradePolicyType `json:"type,omitempty"`
+	// ApprovedVersion approves the upgrade to the 3scale version reported in
+	// status.upgrade.pendingVersion, when the policy is Manual.
+	// +optional
+	ApprovedVersion *string `json:"approvedVersion,omitempty"`
+	// MaintenanceWindow is the window upgrades are allowed in, when the
+	// policy is MaintenanceWindow.
+	// +optional
+	MaintenanceWindow *MaintenanceWindowSpec `json:"maintenanceWindow,omitempty"`
+}
+
+// MaintenanceWindowSpec is a recurring window upgrades are allowed in
+type MaintenanceWindowSpec struct {
+	// Schedule is the cron schedule of the start of the window, in UTC. For
+	// example "0 2 * * 6" starts the window every Saturday at 02:00.
+	Schedule string `json:"schedule"`
+	// DurationMinutes is the duration of the window. Defaults to 60.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1440
+	// +optional
+	DurationMinutes *int32 `json:"durationMinutes,omitempty"`
+}
+
+// UpgradeStatus reports the upgrades of the default image
+type UpgradeStatus struct {
+	// Image is the default image run by the APIcast pods.
+	Image string `json:"image"`
+	// Version is the 3scale version of the image, empty when the image was
+	// deployed by an operator version not tracking upgrades.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// PendingImage is the default image of the operator not rolled out yet.
+	// +optional
+	PendingImage string `json:"pendingImage,omitempty"`
+	// PendingVersion is the 3scale version of the pending image.
+	// +optional
+	PendingVersion string `json:"pendingVersion,omitempty"`
+	// Message is a human readable description of why the upgrade is pending.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// History of the upgrades, most recent first.
+	// +optional
+	History []UpgradeRecord `json:"history,omitempty"`
+}
+
+// UpgradeRecord is an upgrade of the default image
+type UpgradeRecord struct {
+	// FromImage is the image before the upgrade.
+	FromImage string `json:"fromImage"`
+	// ToImage is the image after the upgrade.
+	ToImage string `json:"toImage"`
+	// Version is the 3scale version of the image after the upgrade.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Time of the upgrade.
+	Time metav1.Time `json:"time"`
+}
+
+// ResolvedImageStatus is the digest an image tag was resolved to
+type ResolvedImageStatus struct {
+	// Image is the image reference that was resolved.
+	Image string `json:"image"`
+	// Digest is the image reference pinned to the digest of the image.
+	Digest string `json:"digest"`
+}
+
+// AdminPortalCheckSpec configures the connectivity check of the admin portal
+type AdminPortalCheckSpec struct {
+	// IntervalSeconds is the time between two checks. Defaults to 300.
+	// +kubebuilder:validation:Minimum=30
+	// +optional
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+}
+
+// BlueGreenColor identifies one of the two blue/green APIcast deployments
+// +kubebuilder:validation:Enum=blue;green
+type BlueGreenColor string
+
+const (
+	BlueGreenColorBlue  BlueGreenColor = "blue"
+	BlueGreenColorGreen BlueGreenColor = "green"
+)
+
+// BlueGreenSpec configures blue/green rollouts of embedded configuration changes
+type BlueGreenSpec struct {
+	// ActiveColor pins the deployment receiving the traffic. When unset, the
+	// traffic is switched to the deployment running the latest configuration
+	// as soon as all its pods are ready. Setting it to the color of the
+	// previous deployment rolls back to the previous configuration.
+	// +optional
+	ActiveColor *BlueGreenColor `json:"activeColor,omitempty"`
+}
+
+// BlueGreenStatus reports the state of the blue/green deployments
+type BlueGreenStatus struct {
+	// ActiveColor is the color of the deployment receiving the traffic. Empty
+	// until the first blue/green deployment is ready.
+	// +optional
+	ActiveColor BlueGreenColor `json:"activeColor,omitempty"`
+	// ActiveConfigurationHash is the hash of the embedded configuration
+	// served by the active deployment.
+	// +optional
+	ActiveConfigurationHash string `json:"activeConfigurationHash,omitempty"`
+	// Message is a human readable description of the blue/green state.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// MonitoringSpec contains the Prometheus Operator monitoring configuration
+// of the APIcast metrics
+type MonitoringSpec struct {
+	// Enabled controls whether a ServiceMonitor or PodMonitor scraping the
+	// APIcast metrics is created. By default it is not enabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Kind of the monitor resource scraping the APIcast metrics. Defaults to
+	// ServiceMonitor.
+	// +kubebuilder:validation:Enum=ServiceMonitor;PodMonitor
+	// +optional
+	Kind *string `json:"kind,omitempty"`
+	// Interval at which the metrics are scraped. Defaults to the Prometheus
+	// global scrape interval.
+	// +optional
+	Interval *monitoringv1.Duration `json:"interval,omitempty"`
+	// ScrapeTimeout is the timeout after which the scrape is ended. Defaults
+	// to the Prometheus global scrape timeout.
+	// +optional
+	ScrapeTimeout *monitoringv1.Duration `json:"scrapeTimeout,omitempty"`
+	// Relabelings are applied to the scraped targets before ingestion.
+	// +optional
+	Relabelings []monitoringv1.RelabelConfig `json:"relabelings,omitempty"`
+	// Labels are added to the monitor resource, so it can be selected by the
+	// Prometheus instance.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Alerts contains the configuration of the PrometheusRule with the
+	// default APIcast alerts. The monitoring labels are also added to the
+	// PrometheusRule.
+	// +optional
+	Alerts *MonitoringAlertsSpec `json:"alerts,omitempty"`
+	// Dashboard contains the configuration of the APIcast Grafana dashboard.
+	// +optional
+	Dashboard *MonitoringDashboardSpec `json:"dashboard,omitempty"`
+}
+
 // APIcastSpec defines the desired state of APIcast.
 type APIcastSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -80,6 +518,71 @@ type APIcastSpec struct {
 	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 	// +optional
 	PriorityClassName *string `json:"priorityClassName,omitempty"`
+	// Sidecars specifies additional containers added to the APIcast pod
+	// next to the APIcast container.
+	// +optional
+	Sidecars []v1.Container `json:"sidecars,omitempty"`
+	// InitContainers specifies init containers added to the APIcast pod.
+	// +optional
+	InitContainers []v1.Container `json:"initContainers,omitempty"`
+	// ExtraVolumes specifies additional volumes added to the APIcast pod.
+	// They can be mounted by the APIcast container, the sidecars and the init containers.
+	// +optional
+	ExtraVolumes []v1.Volume `json:"extraVolumes,omitempty"`
+	// ExtraVolumeMounts specifies additional volume mounts of the APIcast container.
+	// +optional
+	ExtraVolumeMounts []v1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+	// PodTemplateOverride is a strategic merge patch applied on top of the pod
+	// template generated by the operator. It allows setting pod fields not
+	// modelled by the APIcast spec, like securityContext or hostAliases. The
+	// APIcast container and the pod selector labels cannot be overridden.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	PodTemplateOverride *runtime.RawExtension `json:"podTemplateOverride,omitempty"`
+	// Probes overrides the settings of the APIcast container liveness,
+	// readiness and startup probes.
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
+	// DeploymentStrategy describes how the APIcast pods are replaced on
+	// updates.
+	// +optional
+	DeploymentStrategy *DeploymentStrategySpec `json:"deploymentStrategy,omitempty"`
+	// MinReadySeconds is the minimum number of seconds for which a newly
+	// created APIcast pod should be ready without any of its containers
+	// crashing to be considered available. Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+	// ProgressDeadlineSeconds is the maximum time in seconds for the APIcast
+	// deployment to make progress before it is reported as failed with the
+	// ProgressDeadlineExceeded reason. Must be greater than minReadySeconds.
+	// Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+	// RevisionHistoryLimit is the number of old ReplicaSets kept to allow
+	// rollbacks. Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+	// GracefulShutdown enables draining the in-flight requests of APIcast
+	// pods before they are stopped, for instance, on rollouts.
+	// +optional
+	GracefulShutdown *GracefulShutdownSpec `json:"gracefulShutdown,omitempty"`
+	// Canary enables canary releases: when the APIcast image changes, the new
+	// image first runs in a canary deployment receiving part of the traffic,
+	// and it is promoted to all the pods only when the canary is healthy.
+	// +optional
+	Canary *CanarySpec `json:"canary,omitempty"`
+	// BlueGreen enables blue/green rollouts of embedded configuration
+	// changes: the new configuration is deployed next to the current one,
+	// the traffic is switched once all the new pods are ready, and the
+	// previous deployment is kept running for quick rollbacks. Requires
+	// embeddedConfigurationSecretRef.
+	// +optional
+	BlueGreen *BlueGreenSpec `json:"blueGreen,omitempty"`
 
 	// Number of replicas of the APIcast Deployment.
 	// +optional
@@ -87,10 +590,19 @@ type APIcastSpec struct {
 	// Enables/disables HPA
 	//+optional
 	Hpa bool `json:"hpa,omitempty"`
+	// Autoscaling contains the HorizontalPodAutoscaler configuration. When
+	// set, HPA is enabled regardless of the hpa field.
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
 	// Secret reference to a Kubernetes Secret containing the admin portal
 	// endpoint URL. The Secret must be located in the same namespace.
 	// +optional
 	AdminPortalCredentialsRef *v1.LocalObjectReference `json:"adminPortalCredentialsRef,omitempty"`
+	// AdminPortalCheck enables probing the admin portal proxy configs
+	// endpoint with the access token of adminPortalCredentialsRef. The result
+	// is reported in the AdminPortalReachable condition.
+	// +optional
+	AdminPortalCheck *AdminPortalCheckSpec `json:"adminPortalCheck,omitempty"`
 	// Secret reference to a Kubernetes secret containing the gateway
 	// configuration. The Secret must be located in the same namespace.
 	// +optional
@@ -104,6 +616,24 @@ type APIcastSpec struct {
 	// this disables automated upgrades of the image.
 	// +optional
 	Image *string `json:"image,omitempty"`
+	// ImagePullPolicy of the APIcast container. Defaults to Always.
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +optional
+	ImagePullPolicy *v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// ImagePullSecrets are the secrets used to pull the APIcast image. The
+	// Secrets must be located in the same namespace.
+	// +optional
+	ImagePullSecrets []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// ImageDigestPinning enables resolving the image tag to its digest in
+	// the registry. The APIcast pods run the digest, so moving the tag in
+	// the registry does not roll them out. The tag is resolved again when
+	// the image changes.
+	// +optional
+	ImageDigestPinning *bool `json:"imageDigestPinning,omitempty"`
+	// UpgradePolicy controls when the APIcast pods are upgraded to the
+	// default image of a new operator version. Ignored when image is set.
+	// +optional
+	UpgradePolicy *UpgradePolicySpec `json:"upgradePolicy,omitempty"`
 	// ExposedHost is the domain name used for external access. By default no
 	// external access is configured.
 	// +optional
@@ -215,6 +745,13 @@ type APIcastSpec struct {
 	// +optional
 	CustomEnvironments []CustomEnvironmentSpec `json:"customEnvironments,omitempty"` // APICAST_ENVIRONMENT
 
+	// SecretSources specifies secrets from other namespaces that the operator
+	// copies into the APIcast namespace and keeps in sync. The source secret
+	// must list the APIcast namespace in its
+	// apicast.apps.3scale.net/allowed-namespaces annotation.
+	// +optional
+	SecretSources []SecretSourceSpec `json:"secretSources,omitempty"`
+
 	// OpenTracingSpec contains the OpenTracing integration configuration
 	// with APIcast.
 	// Deprecated
@@ -247,6 +784,11 @@ type APIcastSpec struct {
 	// with APIcast.
 	// +optional
 	OpenTelemetry *OpenTelemetrySpec `json:"openTelemetry,omitempty"`
+
+	// Monitoring contains the Prometheus Operator monitoring configuration
+	// of the APIcast metrics.
+	// +optional
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
 }
 
 func (a *APIcast) OpenTracingIsEnabled() bool {
@@ -257,11 +799,66 @@ func (a *APIcast) OpenTelemetryEnabled() bool {
 	return a.Spec.OpenTelemetry != nil && a.Spec.OpenTelemetry.Enabled != nil && *a.Spec.OpenTelemetry.Enabled
 }
 
+func (a *APIcast) IsMonitoringEnabled() bool {
+	return a.Spec.Monitoring != nil && a.Spec.Monitoring.Enabled
+}
+
+func (a *APIcast) IsMonitoringAlertsEnabled() bool {
+	return a.IsMonitoringEnabled() && a.Spec.Monitoring.Alerts != nil && a.Spec.Monitoring.Alerts.Enabled
+}
+
+func (a *APIcast) IsMonitoringDashboardEnabled() bool {
+	return a.IsMonitoringEnabled() && a.Spec.Monitoring.Dashboard != nil && a.Spec.Monitoring.Dashboard.Enabled
+}
+
+// MonitoringKind returns the kind of the monitor resource scraping the
+// APIcast metrics
+func (a *APIcast) MonitoringKind() string {
+	if a.Spec.Monitoring != nil && a.Spec.Monitoring.Kind != nil {
+		return *a.Spec.Monitoring.Kind
+	}
+	return MonitoringKindServiceMonitor
+}
+
 type DeploymentEnvironmentType string
 
 const (
-	DefaultHTTPPort  int32 = 8080
-	DefaultHTTPSPort int32 = 8443
+	DefaultHTTPPort       int32  = 8080
+	DefaultHTTPSPort      int32  = 8443
+	DefaultReplicas       int64  = 1
+	DefaultServiceAccount string = "default"
+)
+
+const (
+	DefaultPreStopDelaySeconds           int32 = 15
+	DefaultTerminationGracePeriodSeconds int64 = 60
+)
+
+const (
+	DefaultAdminPortalCheckIntervalSeconds int32 = 300
+)
+
+const (
+	DefaultMaintenanceWindowDurationMinutes int32 = 60
+	// MaxUpgradeHistory is the number of upgrades kept in the status
+	MaxUpgradeHistory = 10
+)
+
+const (
+	DefaultCanaryReplicasPercentage     int32 = 10
+	DefaultCanaryAnalysisSeconds        int32 = 300
+	DefaultCanaryErrorRateMaxPercentage int32 = 5
+)
+
+const (
+	MonitoringKindServiceMonitor = "ServiceMonitor"
+	MonitoringKindPodMonitor     = "PodMonitor"
+)
+
+const (
+	RouteTerminationEdge        = "edge"
+	RouteTerminationReencrypt   = "reencrypt"
+	RouteTerminationPassthrough = "passthrough"
 )
 
 type APIcastExposedHost struct {
@@ -270,6 +867,52 @@ type APIcastExposedHost struct {
 	IngressClassName *string `json:"ingressClassName,omitempty"`
 	// +optional
 	TLS []networkingv1.IngressTLS `json:"tls,omitempty"`
+	// Route exposes the host with an OpenShift Route instead of an Ingress.
+	// The ingressClassName and tls fields are ignored when set.
+	// +optional
+	Route *APIcastExposedHostRoute `json:"route,omitempty"`
+	// Gateway exposes the host attaching the APIcast service to existing
+	// Gateway API gateways instead of an Ingress. The ingressClassName and tls
+	// fields are ignored when set.
+	// +optional
+	Gateway *APIcastExposedHostGateway `json:"gateway,omitempty"`
+}
+
+type APIcastExposedHostGateway struct {
+	// ParentRefs references the gateways the HTTPRoute is attached to.
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []gatewayapiv1.ParentReference `json:"parentRefs"`
+	// Hostnames of the routes. Defaults to the exposed host.
+	// +optional
+	Hostnames []gatewayapiv1.Hostname `json:"hostnames,omitempty"`
+	// PathMatches of the HTTPRoute. Defaults to the "/" path prefix.
+	// +optional
+	PathMatches []gatewayapiv1.HTTPPathMatch `json:"pathMatches,omitempty"`
+	// TLSRouteParentRefs references the gateways a TLSRoute, targeting the
+	// APIcast HTTPS port, is attached to. The TLSRoute is only created when
+	// set, and requires httpsPort or httpsCertificateSecretRef to be set.
+	// +optional
+	TLSRouteParentRefs []gatewayapiv1.ParentReference `json:"tlsRouteParentRefs,omitempty"`
+}
+
+type APIcastExposedHostRoute struct {
+	// Termination indicates the TLS termination type. When not set, the
+	// route is not secured. reencrypt and passthrough terminations target the
+	// APIcast HTTPS port, which requires httpsPort or
+	// httpsCertificateSecretRef to be set.
+	// +kubebuilder:validation:Enum=edge;reencrypt;passthrough
+	// +optional
+	Termination *string `json:"termination,omitempty"`
+	// InsecureEdgeTerminationPolicy indicates the desired behavior for
+	// insecure connections to a secured route.
+	// +kubebuilder:validation:Enum=None;Allow;Redirect
+	// +optional
+	InsecureEdgeTerminationPolicy *string `json:"insecureEdgeTerminationPolicy,omitempty"`
+	// WildcardPolicy indicates whether the route admits a wildcard host.
+	// Defaults to None.
+	// +kubebuilder:validation:Enum=None;Subdomain
+	// +optional
+	WildcardPolicy *string `json:"wildcardPolicy,omitempty"`
 }
 
 type OpenTelemetrySpec struct {
@@ -323,6 +966,58 @@ type APIcastStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// Canary reports the progress of the last canary release.
+	// +optional
+	Canary *CanaryStatus `json:"canary,omitempty"`
+
+	// BlueGreen reports the state of the blue/green deployments.
+	// +optional
+	BlueGreen *BlueGreenStatus `json:"blueGreen,omitempty"`
+
+	// ResolvedImage is the digest the image was resolved to, when image
+	// digest pinning is enabled.
+	// +optional
+	ResolvedImage *ResolvedImageStatus `json:"resolvedImage,omitempty"`
+
+	// Upgrade reports the upgrades of the default image.
+	// +optional
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+
+	// Replicas is the desired number of APIcast pods.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// ReadyReplicas is the number of APIcast pods ready to serve traffic.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// UpdatedReplicas is the number of APIcast pods running the latest pod template.
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// URLs are the URLs APIcast is exposed at by the Ingress or the Route.
+	// +optional
+	URLs []string `json:"urls,omitempty"`
+
+	// ConfigurationLoadMode is the configuration load mode used by APIcast,
+	// either set in the spec or the default of the deployment environment.
+	// +optional
+	ConfigurationLoadMode string `json:"configurationLoadMode,omitempty"`
+
+	// SecretHashes are the hashes of the data of the watched secrets, by secret name.
+	// +optional
+	SecretHashes map[string]string `json:"secretHashes,omitempty"`
+
+	// LastConfigurationRolloutTime is the last time the APIcast pods were
+	// rolled out because of a configuration change.
+	// +optional
+	LastConfigurationRolloutTime *metav1.Time `json:"lastConfigurationRolloutTime,omitempty"`
+
+	// LastAdminPortalCheckTime is the last time the admin portal connectivity
+	// was checked.
+	// +optional
+	LastAdminPortalCheckTime *metav1.Time `json:"lastAdminPortalCheckTime,omitempty"`
 }
 
 func (r *APIcastStatus) IsReady() bool {
@@ -348,6 +1043,68 @@ func (r *APIcastStatus) Equals(other *APIcastStatus, logger logr.Logger) bool {
 		return false
 	}
 
+	if !reflect.DeepEqual(r.Canary, other.Canary) {
+		diff := cmp.Diff(r.Canary, other.Canary)
+		logger.V(1).Info("Canary not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(r.BlueGreen, other.BlueGreen) {
+		diff := cmp.Diff(r.BlueGreen, other.BlueGreen)
+		logger.V(1).Info("BlueGreen not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(r.Upgrade, other.Upgrade) {
+		diff := cmp.Diff(r.Upgrade, other.Upgrade)
+		logger.V(1).Info("Upgrade not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(r.ResolvedImage, other.ResolvedImage) {
+		diff := cmp.Diff(r.ResolvedImage, other.ResolvedImage)
+		logger.V(1).Info("ResolvedImage not equal", "difference", diff)
+		return false
+	}
+
+	if r.Replicas != other.Replicas || r.ReadyReplicas != other.ReadyReplicas || r.UpdatedReplicas != other.UpdatedReplicas {
+		logger.V(1).Info("Replicas not equal",
+			"replicas", cmp.Diff(r.Replicas, other.Replicas),
+			"readyReplicas", cmp.Diff(r.ReadyReplicas, other.ReadyReplicas),
+			"updatedReplicas", cmp.Diff(r.UpdatedReplicas, other.UpdatedReplicas))
+		return false
+	}
+
+	if !reflect.DeepEqual(r.URLs, other.URLs) {
+		diff := cmp.Diff(r.URLs, other.URLs)
+		logger.V(1).Info("URLs not equal", "difference", diff)
+		return false
+	}
+
+	if r.ConfigurationLoadMode != other.ConfigurationLoadMode {
+		diff := cmp.Diff(r.ConfigurationLoadMode, other.ConfigurationLoadMode)
+		logger.V(1).Info("ConfigurationLoadMode not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(r.SecretHashes, other.SecretHashes) {
+		diff := cmp.Diff(r.SecretHashes, other.SecretHashes)
+		logger.V(1).Info("SecretHashes not equal", "difference", diff)
+		return false
+	}
+
+	if !r.LastConfigurationRolloutTime.Equal(other.LastConfigurationRolloutTime) {
+		diff := cmp.Diff(r.LastConfigurationRolloutTime, other.LastConfigurationRolloutTime)
+		logger.V(1).Info("LastConfigurationRolloutTime not equal", "difference", diff)
+		return false
+	}
+
+	if !r.LastAdminPortalCheckTime.Equal(other.LastAdminPortalCheckTime) {
+		diff := cmp.Diff(r.LastAdminPortalCheckTime, other.LastAdminPortalCheckTime)
+		logger.V(1).Info("LastAdminPortalCheckTime not equal", "difference", diff)
+		return false
+	}
+
 	// Marshalling sorts by condition type
 	currentMarshaledJSON, _ := k8sutils.ConditionMarshal(r.Conditions)
 	otherMarshaledJSON, _ := k8sutils.ConditionMarshal(other.Conditions)
@@ -362,9 +1119,19 @@ func (r *APIcastStatus) Equals(other *APIcastStatus, logger logr.Logger) bool {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 
 // APIcast is the Schema for the apicasts API.
 // +kubebuilder:resource:path=apicasts,scope=Namespaced
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.status.replicas`
+// +kubebuilder:printcolumn:name="Ready Replicas",type=integer,JSONPath=`.status.readyReplicas`
+// +kubebuilder:printcolumn:name="Up-to-date",type=integer,JSONPath=`.status.updatedReplicas`
+// +kubebuilder:printcolumn:name="URLs",type=string,JSONPath=`.status.urls`
+// +kubebuilder:printcolumn:name="Load Mode",type=string,JSONPath=`.status.configurationLoadMode`,priority=1
+// +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.status.image`,priority=1
+// +kubebuilder:printcolumn:name="Last Config Rollout",type=date,JSONPath=`.status.lastConfigurationRolloutTime`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +operator-sdk:csv:customresourcedefinitions:displayName="APIcast"
 type APIcast struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -428,6 +1195,46 @@ func (a *APIcast) Validate() field.ErrorList {
 		errors = append(errors, field.Invalid(httpsPortFldPath, a.Spec.HTTPSPort, "HTTPS port conflicts with HTTP port"))
 	}
 
+	httpsEnabled := a.Spec.HTTPSPort != nil || a.Spec.HTTPSCertificateSecretRef != nil
+
+	// check only one exposure mode is set
+	if a.IsRouteEnabled() && a.IsGatewayEnabled() {
+		errors = append(errors, field.Invalid(specFldPath.Child("exposedHost"), a.Spec.ExposedHost.Host, "route and gateway cannot be set at the same time"))
+	}
+
+	// check the TLSRoute targets an enabled HTTPS port
+	if a.IsGatewayEnabled() && len(a.Spec.ExposedHost.Gateway.TLSRouteParentRefs) > 0 && !httpsEnabled {
+		tlsRouteParentRefsFldPath := specFldPath.Child("exposedHost", "gateway", "tlsRouteParentRefs")
+		errors = append(errors, field.Invalid(tlsRouteParentRefsFldPath, a.Spec.ExposedHost.Gateway.TLSRouteParentRefs, "TLSRoute requires httpsPort or httpsCertificateSecretRef to be set"))
+	}
+
+	// check route terminations targeting the HTTPS port have it enabled
+	if a.IsRouteEnabled() {
+		termination := a.Spec.ExposedHost.Route.Termination
+		if termination != nil && *termination != RouteTerminationEdge && !httpsEnabled {
+			terminationFldPath := specFldPath.Child("exposedHost", "route", "termination")
+			errors = append(errors, field.Invalid(terminationFldPath, *termination, "termination requires httpsPort or httpsCertificateSecretRef to be set"))
+		}
+	}
+
+	if a.Spec.Autoscaling != nil {
+		autoscalingFldPath := specFldPath.Child("autoscaling")
+		autoscaling := a.Spec.Autoscaling
+
+		// check min replicas does not exceed max replicas
+		if autoscaling.MinReplicas != nil && autoscaling.MaxReplicas != nil && *autoscaling.MinReplicas > *autoscaling.MaxReplicas {
+			errors = append(errors, field.Invalid(autoscalingFldPath.Child("minReplicas"), *autoscaling.MinReplicas, "minReplicas cannot be greater than maxReplicas"))
+		}
+
+		// check at least one metric is left, otherwise the HPA controller
+		// would default to its own CPU target
+		cpuDisabled := autoscaling.CPUTargetUtilization != nil && *autoscaling.CPUTargetUtilization == 0
+		memoryDisabled := autoscaling.MemoryTargetUtilization != nil && *autoscaling.MemoryTargetUtilization == 0
+		if cpuDisabled && memoryDisabled && len(autoscaling.Metrics) == 0 {
+			errors = append(errors, field.Invalid(autoscalingFldPath, autoscaling, "at least one metric must be configured"))
+		}
+	}
+
 	customPoliciesFldPath := specFldPath.Child("customPolicies")
 	// check custom policy secret is set
 	for idx, customPolicySpec := range a.Spec.CustomPolicies {
@@ -463,6 +1270,135 @@ func (a *APIcast) Validate() field.ErrorList {
 		}
 	}
 
+	// check additional container names are unique and do not clash with the APIcast container
+	containerNames := map[string]int{fmt.Sprintf("apicast-%s", a.Name): 0}
+	for idx, container := range a.Spec.Sidecars {
+		if _, ok := containerNames[container.Name]; ok {
+			errors = append(errors, field.Duplicate(specFldPath.Child("sidecars").Index(idx).Child("name"), container.Name))
+		}
+		containerNames[container.Name] = 0
+	}
+	for idx, container := range a.Spec.InitContainers {
+		if _, ok := containerNames[container.Name]; ok {
+			errors = append(errors, field.Duplicate(specFldPath.Child("initContainers").Index(idx).Child("name"), container.Name))
+		}
+		containerNames[container.Name] = 0
+	}
+
+	// check extra volume names are unique
+	extraVolumeNames := map[string]int{}
+	for idx, volume := range a.Spec.ExtraVolumes {
+		if _, ok := extraVolumeNames[volume.Name]; ok {
+			errors = append(errors, field.Duplicate(specFldPath.Child("extraVolumes").Index(idx).Child("name"), volume.Name))
+		}
+		extraVolumeNames[volume.Name] = 0
+	}
+
+	if a.Spec.PodTemplateOverride != nil {
+		podTemplateOverrideFldPath := specFldPath.Child("podTemplateOverride")
+		if err := a.validatePodTemplateOverride(); err != nil {
+			errors = append(errors, field.Invalid(podTemplateOverrideFldPath, string(a.Spec.PodTemplateOverride.Raw), err.Error()))
+		}
+	}
+
+	// liveness and startup probes must have a success threshold of 1
+	if a.Spec.Probes != nil {
+		probesFldPath := specFldPath.Child("probes")
+		if a.Spec.Probes.Liveness != nil && a.Spec.Probes.Liveness.SuccessThreshold != nil && *a.Spec.Probes.Liveness.SuccessThreshold != 1 {
+			errors = append(errors, field.Invalid(probesFldPath.Child("liveness", "successThreshold"), *a.Spec.Probes.Liveness.SuccessThreshold, "must be 1"))
+		}
+		if a.Spec.Probes.Startup != nil && a.Spec.Probes.Startup.SuccessThreshold != nil && *a.Spec.Probes.Startup.SuccessThreshold != 1 {
+			errors = append(errors, field.Invalid(probesFldPath.Child("startup", "successThreshold"), *a.Spec.Probes.Startup.SuccessThreshold, "must be 1"))
+		}
+	}
+
+	// rolling update parameters are only allowed with the RollingUpdate strategy
+	if a.Spec.DeploymentStrategy != nil {
+		deploymentStrategyFldPath := specFldPath.Child("deploymentStrategy")
+		strategy := a.Spec.DeploymentStrategy
+		if strategy.Type != nil && *strategy.Type == RecreateDeploymentStrategyType {
+			if strategy.MaxSurge != nil {
+				errors = append(errors, field.Invalid(deploymentStrategyFldPath.Child("maxSurge"), strategy.MaxSurge.String(), "may not be set when type is Recreate"))
+			}
+			if strategy.MaxUnavailable != nil {
+				errors = append(errors, field.Invalid(deploymentStrategyFldPath.Child("maxUnavailable"), strategy.MaxUnavailable.String(), "may not be set when type is Recreate"))
+			}
+		} else if isZeroIntOrString(strategy.MaxSurge) && isZeroIntOrString(strategy.MaxUnavailable) {
+			errors = append(errors, field.Invalid(deploymentStrategyFldPath.Child("maxUnavailable"), strategy.MaxUnavailable.String(), "may not be 0 when maxSurge is 0"))
+		}
+	}
+
+	// the progress deadline must be longer than the pod ready period
+	if a.Spec.ProgressDeadlineSeconds != nil {
+		minReadySeconds := int32(0)
+		if a.Spec.MinReadySeconds != nil {
+			minReadySeconds = *a.Spec.MinReadySeconds
+		}
+		if *a.Spec.ProgressDeadlineSeconds <= minReadySeconds {
+			errors = append(errors, field.Invalid(specFldPath.Child("progressDeadlineSeconds"), *a.Spec.ProgressDeadlineSeconds, "must be greater than minReadySeconds"))
+		}
+	}
+
+	// the canary error rate is queried from an absolute Prometheus URL
+	if a.Spec.Canary != nil && a.Spec.Canary.ErrorRate != nil {
+		prometheusURLFldPath := specFldPath.Child("canary", "errorRate", "prometheusURL")
+		prometheusURL, err := url.Parse(a.Spec.Canary.ErrorRate.PrometheusURL)
+		if err != nil || (prometheusURL.Scheme != "http" && prometheusURL.Scheme != "https") || prometheusURL.Host == "" {
+			errors = append(errors, field.Invalid(prometheusURLFldPath, a.Spec.Canary.ErrorRate.PrometheusURL, "must be an absolute http(s) URL"))
+		}
+	}
+
+	if a.Spec.UpgradePolicy != nil {
+		errors = append(errors, a.Spec.UpgradePolicy.validate(specFldPath.Child("upgradePolicy"))...)
+	}
+
+	// the connectivity check probes the admin portal endpoint
+	if a.Spec.AdminPortalCheck != nil && a.Spec.AdminPortalCredentialsRef == nil {
+		errors = append(errors, field.Invalid(specFldPath.Child("adminPortalCheck"), a.Spec.AdminPortalCheck, "requires adminPortalCredentialsRef"))
+	}
+
+	// blue/green rollouts switch between embedded configurations, the
+	// replicas of both deployments are managed by the operator
+	if a.Spec.BlueGreen != nil {
+		blueGreenFldPath := specFldPath.Child("blueGreen")
+		if a.Spec.EmbeddedConfigurationSecretRef == nil {
+			errors = append(errors, field.Invalid(blueGreenFldPath, a.Spec.BlueGreen, "requires embeddedConfigurationSecretRef"))
+		}
+		if a.IsHPAEnabled() {
+			errors = append(errors, field.Invalid(blueGreenFldPath, a.Spec.BlueGreen, "may not be set when HPA is enabled"))
+		}
+		if a.Spec.Canary != nil {
+			errors = append(errors, field.Invalid(blueGreenFldPath, a.Spec.BlueGreen, "may not be set when canary is set"))
+		}
+	}
+
+	// the pod must be given time to drain after the preStop delay
+	if a.Spec.GracefulShutdown != nil && a.Spec.GracefulShutdown.TerminationGracePeriodSeconds != nil {
+		preStopDelaySeconds := int64(DefaultPreStopDelaySeconds)
+		if a.Spec.GracefulShutdown.PreStopDelaySeconds != nil {
+			preStopDelaySeconds = int64(*a.Spec.GracefulShutdown.PreStopDelaySeconds)
+		}
+		if *a.Spec.GracefulShutdown.TerminationGracePeriodSeconds <= preStopDelaySeconds {
+			errors = append(errors, field.Invalid(specFldPath.Child("gracefulShutdown", "terminationGracePeriodSeconds"), *a.Spec.GracefulShutdown.TerminationGracePeriodSeconds, "must be greater than preStopDelaySeconds"))
+		}
+	}
+
+	secretSourcesFldPath := specFldPath.Child("secretSources")
+	// check secret source copies are named and not duplicated
+	secretSourceNames := make(map[string]int)
+	for idx, secretSource := range a.Spec.SecretSources {
+		secretSourcesIdxFldPath := secretSourcesFldPath.Index(idx)
+		if secretSource.Name == "" {
+			errors = append(errors, field.Invalid(secretSourcesIdxFldPath, secretSource, "secret source name is empty"))
+		} else if _, ok := secretSourceNames[secretSource.Name]; ok {
+			errors = append(errors, field.Invalid(secretSourcesIdxFldPath, secretSource, "secret source name is duplicated"))
+		}
+		if secretSource.SourceRef.Namespace == "" || secretSource.SourceRef.Name == "" {
+			errors = append(errors, field.Invalid(secretSourcesIdxFldPath.Child("sourceRef"), secretSource.SourceRef, "secret source reference requires namespace and name"))
+		}
+		secretSourceNames[secretSource.Name] = 0
+	}
+
 	// check tracing config secret has a name specified when tracing config is
 	// enabled and a custom configuration secret reference has been set
 	if a.OpenTracingIsEnabled() {
@@ -489,6 +1425,76 @@ func (a *APIcast) Validate() field.ErrorList {
 	return errors
 }
 
+// podTemplateProtectedLabels are the labels selecting the stable, the canary
+// and the blue/green pods, they are set by the operator
+var podTemplateProtectedLabels = []string{"deployment", "track", "color"}
+
+// validatePodTemplateOverride applies the pod template override to the pod
+// templates of the stable, the canary and the blue/green deployments, holding
+// only the protected fields, and checks they are kept
+func (a *APIcast) validatePodTemplateOverride() error {
+	deploymentName := fmt.Sprintf("apicast-%s", a.Name)
+	selectors := []map[string]string{
+		{"deployment": deploymentName, "track": "stable"},
+		{"deployment": deploymentName, "track": "canary"},
+		{"deployment": deploymentName, "color": "blue"},
+	}
+
+	for _, selector := range selectors {
+		if err := validatePodTemplatePatch(deploymentName, selector, a.Spec.PodTemplateOverride.Raw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validatePodTemplatePatch applies the patch to a pod template with the given
+// selector labels and checks the selector labels and the APIcast container are kept
+func validatePodTemplatePatch(deploymentName string, selector map[string]string, patch []byte) error {
+	protected := v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: selector,
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: deploymentName}},
+		},
+	}
+
+	original, err := json.Marshal(protected)
+	if err != nil {
+		return err
+	}
+
+	patched, err := strategicpatch.StrategicMergePatch(original, patch, v1.PodTemplateSpec{})
+	if err != nil {
+		return fmt.Errorf("invalid strategic merge patch: %w", err)
+	}
+
+	result := v1.PodTemplateSpec{}
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return err
+	}
+
+	for _, label := range podTemplateProtectedLabels {
+		expected, expectedOk := selector[label]
+		value, ok := result.Labels[label]
+		if ok != expectedOk || value != expected {
+			return fmt.Errorf("the %s label cannot be overridden or removed", label)
+		}
+	}
+
+	if len(result.Spec.Containers) == 0 || result.Spec.Containers[0].Name != deploymentName {
+		return fmt.Errorf("the %s container cannot be removed or renamed", deploymentName)
+	}
+
+	if result.Spec.Containers[0].Image != "" {
+		return fmt.Errorf("the image of the %s container cannot be overridden, use the image field", deploymentName)
+	}
+
+	return nil
+}
+
 func init() {
 	SchemeBuilder.Register(&APIcast{}, &APIcastList{})
 }
@@ -581,6 +1587,70 @@ func (a *APIcast) GetApicastSecretRefs() []*v1.LocalObjectReference {
 	return secretRefs
 }
 
+func (u *UpgradePolicySpec) validate(fldPath *field.Path) field.ErrorList {
+	errors := field.ErrorList{}
+
+	switch u.PolicyType() {
+	case UpgradePolicyManual:
+		if u.MaintenanceWindow != nil {
+			errors = append(errors, field.Invalid(fldPath.Child("maintenanceWindow"), u.MaintenanceWindow, "may only be set when type is MaintenanceWindow"))
+		}
+	case UpgradePolicyMaintenanceWindow:
+		if u.MaintenanceWindow == nil {
+			errors = append(errors, field.Required(fldPath.Child("maintenanceWindow"), "required when type is MaintenanceWindow"))
+		} else if _, err := helper.ParseCronSchedule(u.MaintenanceWindow.Schedule); err != nil {
+			errors = append(errors, field.Invalid(fldPath.Child("maintenanceWindow", "schedule"), u.MaintenanceWindow.Schedule, err.Error()))
+		}
+		if u.ApprovedVersion != nil {
+			errors = append(errors, field.Invalid(fldPath.Child("approvedVersion"), *u.ApprovedVersion, "may only be set when type is Manual"))
+		}
+	default:
+		if u.MaintenanceWindow != nil {
+			errors = append(errors, field.Invalid(fldPath.Child("maintenanceWindow"), u.MaintenanceWindow, "may only be set when type is MaintenanceWindow"))
+		}
+		if u.ApprovedVersion != nil {
+			errors = append(errors, field.Invalid(fldPath.Child("approvedVersion"), *u.ApprovedVersion, "may only be set when type is Manual"))
+		}
+	}
+
+	return errors
+}
+
+// PolicyType returns the upgrade policy type, Automatic when not set
+func (u *UpgradePolicySpec) PolicyType() UpgradePolicyType {
+	if u == nil || u.Type == nil {
+		return UpgradePolicyAutomatic
+	}
+	return *u.Type
+}
+
+// IsHPAEnabled returns true when either the hpa shorthand or the autoscaling
+// configuration is set
+func (a *APIcast) IsHPAEnabled() bool {
+	return a.Spec.Hpa || a.Spec.Autoscaling != nil
+}
+
+// IsRouteEnabled returns true when the exposed host is exposed with an OpenShift Route
+func (a *APIcast) IsRouteEnabled() bool {
+	return a.Spec.ExposedHost != nil && a.Spec.ExposedHost.Route != nil
+}
+
+// IsGatewayEnabled returns true when the exposed host is exposed with Gateway API routes
+func (a *APIcast) IsGatewayEnabled() bool {
+	return a.Spec.ExposedHost != nil && a.Spec.ExposedHost.Gateway != nil
+}
+
 func (a *APIcast) IsPDBEnabled() bool {
 	return a.Spec.PodDisruptionBudget != nil && a.Spec.PodDisruptionBudget.Enabled
 }
+
+// isZeroIntOrString reports whether the value is explicitly set to 0 or 0%
+func isZeroIntOrString(value *intstr.IntOrString) bool {
+	if value == nil {
+		return false
+	}
+	if value.Type == intstr.Int {
+		return value.IntVal == 0
+	}
+	return value.StrVal == "0%" || value.StrVal == "0"
+}
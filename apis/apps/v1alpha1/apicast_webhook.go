@@ -0,0 +1,110 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appscommon "github.com/3scale/apicast-operator/apis/apps"
+)
+
+// SetupWebhookWithManager registers the APIcast webhooks in the manager's
+// webhook server. The conversion webhook is served at /convert.
+func (a *APIcast) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(a).
+		WithDefaulter(&apicastWebhook{}).
+		WithValidator(&apicastWebhook{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-apps-3scale-net-v1alpha1-apicast,mutating=true,failurePolicy=fail,sideEffects=None,groups=apps.3scale.net,resources=apicasts,verbs=create;update,versions=v1alpha1,name=mapicast.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-apps-3scale-net-v1alpha1-apicast,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.3scale.net,resources=apicasts,verbs=create;update,versions=v1alpha1,name=vapicast.kb.io,admissionReviewVersions=v1
+
+// apicastWebhook implements the APIcast defaulting and validating admission
+// webhooks. Resources of other served versions are converted to v1alpha1
+// by the API server before being sent to the webhooks.
+type apicastWebhook struct{}
+
+var _ webhook.CustomDefaulter = &apicastWebhook{}
+var _ webhook.CustomValidator = &apicastWebhook{}
+
+// Default sets the effective values of the fields with a default.
+// The image is not defaulted, as setting it disables automated upgrades of
+// the image. The image in use is reported in the status.
+func (w *apicastWebhook) Default(_ context.Context, obj runtime.Object) error {
+	a, ok := obj.(*APIcast)
+	if !ok {
+		return fmt.Errorf("%T is not a *v1alpha1.APIcast", obj)
+	}
+
+	a.Default()
+
+	return nil
+}
+
+func (w *apicastWebhook) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	a, ok := obj.(*APIcast)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a *v1alpha1.APIcast", obj)
+	}
+
+	return nil, a.validationError()
+}
+
+func (w *apicastWebhook) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	a, ok := newObj.(*APIcast)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a *v1alpha1.APIcast", newObj)
+	}
+
+	return nil, a.validationError()
+}
+
+func (w *apicastWebhook) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// Default sets the defaults of the replicas and service account fields.
+// Replicas are not defaulted when HPA is enabled, as they are ignored.
+func (a *APIcast) Default() {
+	if a.Spec.Replicas == nil && !a.IsHPAEnabled() {
+		replicas := DefaultReplicas
+		a.Spec.Replicas = &replicas
+	}
+
+	if a.Spec.ServiceAccount == nil {
+		serviceAccount := DefaultServiceAccount
+		a.Spec.ServiceAccount = &serviceAccount
+	}
+}
+
+func (a *APIcast) validationError() error {
+	fieldErrors := a.Validate()
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+
+	return errors.NewInvalid(GroupVersion.WithKind(appscommon.APIcastKind).GroupKind(), a.Name, fieldErrors)
+}
@@ -0,0 +1,309 @@
+//go:build unit
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestAPIcastWebhookDefault(t *testing.T) {
+	defaultReplicas := DefaultReplicas
+	replicas := int64(3)
+	serviceAccount := "my-sa"
+
+	tests := []struct {
+		name                   string
+		spec                   APIcastSpec
+		expectedReplicas       *int64
+		expectedServiceAccount string
+	}{
+		{"defaults are set", APIcastSpec{}, &defaultReplicas, DefaultServiceAccount},
+		{"values are kept", APIcastSpec{Replicas: &replicas, ServiceAccount: &serviceAccount}, &replicas, serviceAccount},
+		{"replicas not set when hpa enabled", APIcastSpec{Hpa: true}, nil, DefaultServiceAccount},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(subT *testing.T) {
+			a := &APIcast{ObjectMeta: metav1.ObjectMeta{Name: "example"}, Spec: tc.spec}
+			if err := (&apicastWebhook{}).Default(context.TODO(), a); err != nil {
+				subT.Fatal(err)
+			}
+
+			if tc.expectedReplicas == nil {
+				if a.Spec.Replicas != nil {
+					subT.Errorf("expected nil replicas, got %d", *a.Spec.Replicas)
+				}
+			} else if a.Spec.Replicas == nil || *a.Spec.Replicas != *tc.expectedReplicas {
+				subT.Errorf("expected replicas %d, got %v", *tc.expectedReplicas, a.Spec.Replicas)
+			}
+
+			if a.Spec.ServiceAccount == nil || *a.Spec.ServiceAccount != tc.expectedServiceAccount {
+				subT.Errorf("expected service account %s, got %v", tc.expectedServiceAccount, a.Spec.ServiceAccount)
+			}
+		})
+	}
+}
+
+func TestAPIcastWebhookValidate(t *testing.T) {
+	httpPort := DefaultHTTPPort
+
+	valid := &APIcast{ObjectMeta: metav1.ObjectMeta{Name: "example"}}
+	invalid := &APIcast{
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Spec:       APIcastSpec{HTTPSPort: &httpPort},
+	}
+
+	w := &apicastWebhook{}
+
+	if _, err := w.ValidateCreate(context.TODO(), valid); err != nil {
+		t.Errorf("unexpected error on create: %v", err)
+	}
+
+	_, err := w.ValidateCreate(context.TODO(), invalid)
+	if !errors.IsInvalid(err) {
+		t.Errorf("expected invalid error on create, got: %v", err)
+	}
+
+	_, err = w.ValidateUpdate(context.TODO(), valid, invalid)
+	if !errors.IsInvalid(err) {
+		t.Errorf("expected invalid error on update, got: %v", err)
+	}
+
+	if _, err := w.ValidateDelete(context.TODO(), invalid); err != nil {
+		t.Errorf("unexpected error on delete: %v", err)
+	}
+}
+
+func TestAPIcastValidatePodTemplateOverride(t *testing.T) {
+	cases := []struct {
+		testName string
+		override string
+		valid    bool
+	}{
+		{"SecurityContext", `{"spec":{"securityContext":{"runAsNonRoot":true}}}`, true},
+		{"APIcastContainerPatched", `{"spec":{"containers":[{"name":"apicast-example","securityContext":{"readOnlyRootFilesystem":true}}]}}`, true},
+		{"ExtraLabel", `{"metadata":{"labels":{"team":"gateway"}}}`, true},
+		{"SelectorLabelOverridden", `{"metadata":{"labels":{"deployment":"other"}}}`, false},
+		{"CanaryTrackLabelOverridden", `{"metadata":{"labels":{"track":"canary"}}}`, false},
+		{"BlueGreenColorLabelOverridden", `{"metadata":{"labels":{"color":"blue"}}}`, false},
+		{"SelectorLabelRemoved", `{"metadata":{"labels":{"deployment":null}}}`, false},
+		{"TrackLabelRemoved", `{"metadata":{"labels":{"track":null}}}`, false},
+		{"ColorLabelRemoved", `{"metadata":{"labels":{"color":null}}}`, false},
+		{"LabelsReplaced", `{"metadata":{"labels":{"$patch":"replace","team":"gateway"}}}`, false},
+		{"APIcastImageOverridden", `{"spec":{"containers":[{"name":"apicast-example","image":"quay.io/other/apicast:latest"}]}}`, false},
+		{"APIcastContainerDeleted", `{"spec":{"containers":[{"name":"apicast-example","$patch":"delete"}]}}`, false},
+		{"ContainersReplaced", `{"spec":{"containers":[{"name":"other","$patch":"replace"}]}}`, false},
+		{"NotAnObject", `["spec"]`, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apicast := &APIcast{
+				ObjectMeta: metav1.ObjectMeta{Name: "example"},
+				Spec: APIcastSpec{
+					PodTemplateOverride: &runtime.RawExtension{Raw: []byte(tc.override)},
+				},
+			}
+			errs := apicast.Validate()
+			if tc.valid && len(errs) > 0 {
+				subT.Fatalf("unexpected validation errors: %v", errs)
+			}
+			if !tc.valid && len(errs) == 0 {
+				subT.Fatal("expected validation errors")
+			}
+		})
+	}
+}
+
+func TestAPIcastValidateRollout(t *testing.T) {
+	recreate := RecreateDeploymentStrategyType
+	rollingUpdate := RollingUpdateDeploymentStrategyType
+	zero := intstr.FromInt32(0)
+	zeroPercent := intstr.FromString("0%")
+	one := intstr.FromInt32(1)
+	int32Ptr := func(i int32) *int32 { return &i }
+
+	cases := []struct {
+		testName string
+		spec     APIcastSpec
+		valid    bool
+	}{
+		{"RollingUpdate", APIcastSpec{DeploymentStrategy: &DeploymentStrategySpec{Type: &rollingUpdate, MaxSurge: &one, MaxUnavailable: &zero}}, true},
+		{"Recreate", APIcastSpec{DeploymentStrategy: &DeploymentStrategySpec{Type: &recreate}}, true},
+		{"RecreateWithMaxSurge", APIcastSpec{DeploymentStrategy: &DeploymentStrategySpec{Type: &recreate, MaxSurge: &one}}, false},
+		{"RecreateWithMaxUnavailable", APIcastSpec{DeploymentStrategy: &DeploymentStrategySpec{Type: &recreate, MaxUnavailable: &zero}}, false},
+		{"NoSurgeNoUnavailable", APIcastSpec{DeploymentStrategy: &DeploymentStrategySpec{MaxSurge: &zero, MaxUnavailable: &zeroPercent}}, false},
+		{"ProgressDeadline", APIcastSpec{MinReadySeconds: int32Ptr(10), ProgressDeadlineSeconds: int32Ptr(300)}, true},
+		{"ProgressDeadlineNotAboveMinReady", APIcastSpec{MinReadySeconds: int32Ptr(300), ProgressDeadlineSeconds: int32Ptr(300)}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apicast := &APIcast{ObjectMeta: metav1.ObjectMeta{Name: "example"}, Spec: tc.spec}
+			errs := apicast.Validate()
+			if tc.valid && len(errs) > 0 {
+				subT.Fatalf("unexpected validation errors: %v", errs)
+			}
+			if !tc.valid && len(errs) == 0 {
+				subT.Fatal("expected validation errors")
+			}
+		})
+	}
+}
+
+func TestAPIcastValidateGracefulShutdown(t *testing.T) {
+	int32Ptr := func(i int32) *int32 { return &i }
+	int64Ptr := func(i int64) *int64 { return &i }
+
+	cases := []struct {
+		testName string
+		spec     *GracefulShutdownSpec
+		valid    bool
+	}{
+		{"Defaults", &GracefulShutdownSpec{}, true},
+		{"LongerGracePeriod", &GracefulShutdownSpec{PreStopDelaySeconds: int32Ptr(20), TerminationGracePeriodSeconds: int64Ptr(90)}, true},
+		{"GracePeriodBelowDefaultDelay", &GracefulShutdownSpec{TerminationGracePeriodSeconds: int64Ptr(10)}, false},
+		{"GracePeriodEqualToDelay", &GracefulShutdownSpec{PreStopDelaySeconds: int32Ptr(30), TerminationGracePeriodSeconds: int64Ptr(30)}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apicast := &APIcast{
+				ObjectMeta: metav1.ObjectMeta{Name: "example"},
+				Spec:       APIcastSpec{GracefulShutdown: tc.spec},
+			}
+			errs := apicast.Validate()
+			if tc.valid && len(errs) > 0 {
+				subT.Fatalf("unexpected validation errors: %v", errs)
+			}
+			if !tc.valid && len(errs) == 0 {
+				subT.Fatal("expected validation errors")
+			}
+		})
+	}
+}
+
+func TestAPIcastValidateCanary(t *testing.T) {
+	cases := []struct {
+		testName      string
+		prometheusURL string
+		valid         bool
+	}{
+		{"ServiceURL", "http://prometheus-operated.monitoring.svc:9090", true},
+		{"HTTPS", "https://prometheus.example.com", true},
+		{"Empty", "", false},
+		{"NoScheme", "prometheus:9090/api", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apicast := &APIcast{
+				ObjectMeta: metav1.ObjectMeta{Name: "example"},
+				Spec: APIcastSpec{
+					Canary: &CanarySpec{ErrorRate: &CanaryErrorRateSpec{PrometheusURL: tc.prometheusURL}},
+				},
+			}
+			errs := apicast.Validate()
+			if tc.valid && len(errs) > 0 {
+				subT.Fatalf("unexpected validation errors: %v", errs)
+			}
+			if !tc.valid && len(errs) == 0 {
+				subT.Fatal("expected validation errors")
+			}
+		})
+	}
+}
+
+func TestAPIcastValidateBlueGreen(t *testing.T) {
+	embeddedConfig := &corev1.LocalObjectReference{Name: "config"}
+
+	cases := []struct {
+		testName string
+		spec     APIcastSpec
+		valid    bool
+	}{
+		{"EmbeddedConfiguration", APIcastSpec{BlueGreen: &BlueGreenSpec{}, EmbeddedConfigurationSecretRef: embeddedConfig}, true},
+		{"AdminPortal", APIcastSpec{BlueGreen: &BlueGreenSpec{}, AdminPortalCredentialsRef: &corev1.LocalObjectReference{Name: "portal"}}, false},
+		{"HPA", APIcastSpec{BlueGreen: &BlueGreenSpec{}, EmbeddedConfigurationSecretRef: embeddedConfig, Hpa: true}, false},
+		{"Canary", APIcastSpec{BlueGreen: &BlueGreenSpec{}, EmbeddedConfigurationSecretRef: embeddedConfig, Canary: &CanarySpec{}}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apicast := &APIcast{ObjectMeta: metav1.ObjectMeta{Name: "example"}, Spec: tc.spec}
+			errs := apicast.Validate()
+			if tc.valid && len(errs) > 0 {
+				subT.Fatalf("unexpected validation errors: %v", errs)
+			}
+			if !tc.valid && len(errs) == 0 {
+				subT.Fatal("expected validation errors")
+			}
+		})
+	}
+}
+
+func TestAPIcastValidateAdminPortalCheck(t *testing.T) {
+	cases := []struct {
+		testName string
+		spec     APIcastSpec
+		valid    bool
+	}{
+		{"AdminPortal", APIcastSpec{AdminPortalCheck: &AdminPortalCheckSpec{}, AdminPortalCredentialsRef: &corev1.LocalObjectReference{Name: "portal"}}, true},
+		{"EmbeddedConfiguration", APIcastSpec{AdminPortalCheck: &AdminPortalCheckSpec{}, EmbeddedConfigurationSecretRef: &corev1.LocalObjectReference{Name: "config"}}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apicast := &APIcast{ObjectMeta: metav1.ObjectMeta{Name: "example"}, Spec: tc.spec}
+			errs := apicast.Validate()
+			if tc.valid && len(errs) > 0 {
+				subT.Fatalf("unexpected validation errors: %v", errs)
+			}
+			if !tc.valid && len(errs) == 0 {
+				subT.Fatal("expected validation errors")
+			}
+		})
+	}
+}
+
+func TestAPIcastValidateUpgradePolicy(t *testing.T) {
+	policyType := func(t UpgradePolicyType) *UpgradePolicyType { return &t }
+	version := "2.16.0"
+
+	cases := []struct {
+		testName string
+		spec     *UpgradePolicySpec
+		valid    bool
+	}{
+		{"Defaults", &UpgradePolicySpec{}, true},
+		{"Manual", &UpgradePolicySpec{Type: policyType(UpgradePolicyManual), ApprovedVersion: &version}, true},
+		{"MaintenanceWindow", &UpgradePolicySpec{Type: policyType(UpgradePolicyMaintenanceWindow), MaintenanceWindow: &MaintenanceWindowSpec{Schedule: "0 2 * * 6"}}, true},
+		{"MaintenanceWindowMissing", &UpgradePolicySpec{Type: policyType(UpgradePolicyMaintenanceWindow)}, false},
+		{"InvalidSchedule", &UpgradePolicySpec{Type: policyType(UpgradePolicyMaintenanceWindow), MaintenanceWindow: &MaintenanceWindowSpec{Schedule: "every saturday"}}, false},
+		{"ApprovedVersionNotManual", &UpgradePolicySpec{ApprovedVersion: &version}, false},
+		{"MaintenanceWindowNotMaintenanceWindow", &UpgradePolicySpec{Type: policyType(UpgradePolicyManual), MaintenanceWindow: &MaintenanceWindowSpec{Schedule: "0 2 * * 6"}}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apicast := &APIcast{
+				ObjectMeta: metav1.ObjectMeta{Name: "example"},
+				Spec:       APIcastSpec{UpgradePolicy: tc.spec},
+			}
+			errs := apicast.Validate()
+			if tc.valid && len(errs) > 0 {
+				subT.Fatalf("unexpected validation errors: %v", errs)
+			}
+			if !tc.valid && len(errs) == 0 {
+				subT.Fatal("expected validation errors")
+			}
+		})
+	}
+}
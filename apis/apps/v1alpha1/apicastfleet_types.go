@@ -0,0 +1,292 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	appscommon "github.com/3scale/apicast-operator/apis/apps"
+)
+
+const (
+	// APIcastFleetNameLabel is set on the APIcast members to the name of their fleet
+	APIcastFleetNameLabel = "apps.3scale.net/fleet-name"
+	// APIcastFleetNamespaceLabel is set on the APIcast members to the namespace of their fleet
+	APIcastFleetNamespaceLabel = "apps.3scale.net/fleet-namespace"
+	// APIcastFleetFinalizer removes the APIcast members when the fleet is deleted.
+	// Members in other namespaces cannot be garbage collected with owner references.
+	APIcastFleetFinalizer = "apps.3scale.net/apicastfleet-members"
+)
+
+// APIcastFleetTemplate is the base of the APIcast members of a fleet
+type APIcastFleetTemplate struct {
+	// Labels added to the APIcast members.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations added to the APIcast members.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Spec is the base spec of the APIcast members.
+	Spec APIcastSpec `json:"spec"`
+}
+
+// APIcastFleetNamespaceOverlay customizes the members of a namespace
+type APIcastFleetNamespaceOverlay struct {
+	// Namespace of the members the overlay applies to.
+	Namespace string `json:"namespace"`
+
+	// Overlay is a strategic merge patch applied on top of the base spec of
+	// the members of the namespace.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	Overlay runtime.RawExtension `json:"overlay"`
+}
+
+// APIcastFleetMember is an APIcast stamped out by the fleet
+type APIcastFleetMember struct {
+	// Name of the APIcast.
+	Name string `json:"name"`
+
+	// Namespace of the APIcast. Defaults to the namespace of the fleet.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Overlay is a strategic merge patch applied on top of the base spec and
+	// the namespace overlay.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	Overlay *runtime.RawExtension `json:"overlay,omitempty"`
+}
+
+// APIcastFleetSpec defines the desired state of APIcastFleet.
+type APIcastFleetSpec struct {
+	// Template is the base of the APIcast members.
+	Template APIcastFleetTemplate `json:"template"`
+
+	// NamespaceOverlays customize the members by namespace.
+	// +optional
+	NamespaceOverlays []APIcastFleetNamespaceOverlay `json:"namespaceOverlays,omitempty"`
+
+	// Members are the APIcasts of the fleet. APIcasts removed from the list
+	// are deleted.
+	// +optional
+	Members []APIcastFleetMember `json:"members,omitempty"`
+}
+
+// APIcastFleetMemberStatus reports the state of an APIcast member
+type APIcastFleetMemberStatus struct {
+	// Name of the APIcast.
+	Name string `json:"name"`
+
+	// Namespace of the APIcast.
+	Namespace string `json:"namespace"`
+
+	// Ready is true when the APIcast is ready.
+	Ready bool `json:"ready"`
+
+	// Message describes why the APIcast is not ready.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// APIcastFleetStatus defines the observed state of APIcastFleet.
+type APIcastFleetStatus struct {
+	// ObservedGeneration reflects the generation of the most recently observed spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// DesiredMembers is the number of APIcast members.
+	// +optional
+	DesiredMembers int32 `json:"desiredMembers,omitempty"`
+
+	// ReadyMembers is the number of ready APIcast members.
+	// +optional
+	ReadyMembers int32 `json:"readyMembers,omitempty"`
+
+	// Members reports the state of each APIcast member.
+	// +optional
+	Members []APIcastFleetMemberStatus `json:"members,omitempty"`
+
+	// Known .status.conditions.type are: "Ready"
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// APIcastFleet is the Schema for the apicastfleets API.
+// +kubebuilder:resource:path=apicastfleets,scope=Namespaced
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=`.status.desiredMembers`
+// +kubebuilder:printcolumn:name="Ready Members",type=integer,JSONPath=`.status.readyMembers`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +operator-sdk:csv:customresourcedefinitions:displayName="APIcastFleet"
+type APIcastFleet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APIcastFleetSpec   `json:"spec,omitempty"`
+	Status APIcastFleetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// APIcastFleetList contains a list of APIcastFleets.
+type APIcastFleetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIcastFleet `json:"items"`
+}
+
+// MemberNamespace returns the namespace of the member
+func (f *APIcastFleet) MemberNamespace(member APIcastFleetMember) string {
+	if member.Namespace != "" {
+		return member.Namespace
+	}
+	return f.Namespace
+}
+
+// MemberLabels returns the labels identifying the members of the fleet
+func (f *APIcastFleet) MemberLabels() map[string]string {
+	return map[string]string{
+		APIcastFleetNameLabel:      f.Name,
+		APIcastFleetNamespaceLabel: f.Namespace,
+	}
+}
+
+// MemberAPIcast returns the APIcast of the member: the template spec with the
+// namespace overlay and the member overlay applied, in this order, and the
+// defaults set.
+func (f *APIcastFleet) MemberAPIcast(member APIcastFleetMember) (*APIcast, error) {
+	namespace := f.MemberNamespace(member)
+
+	spec, err := json.Marshal(f.Spec.Template.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range f.Spec.NamespaceOverlays {
+		if f.Spec.NamespaceOverlays[idx].Namespace != namespace {
+			continue
+		}
+		spec, err = strategicpatch.StrategicMergePatch(spec, f.Spec.NamespaceOverlays[idx].Overlay.Raw, APIcastSpec{})
+		if err != nil {
+			return nil, fmt.Errorf("invalid overlay of namespace %s: %w", namespace, err)
+		}
+	}
+
+	if member.Overlay != nil {
+		spec, err = strategicpatch.StrategicMergePatch(spec, member.Overlay.Raw, APIcastSpec{})
+		if err != nil {
+			return nil, fmt.Errorf("invalid overlay of member %s/%s: %w", namespace, member.Name, err)
+		}
+	}
+
+	apicast := &APIcast{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: GroupVersion.String(),
+			Kind:       appscommon.APIcastKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        member.Name,
+			Namespace:   namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+	}
+	if err := json.Unmarshal(spec, &apicast.Spec); err != nil {
+		return nil, fmt.Errorf("invalid spec of member %s/%s: %w", namespace, member.Name, err)
+	}
+
+	// the defaults are set by the webhook, the members would be updated on every reconciliation otherwise
+	apicast.Default()
+
+	for k, v := range f.Spec.Template.Annotations {
+		apicast.Annotations[k] = v
+	}
+	for k, v := range f.Spec.Template.Labels {
+		apicast.Labels[k] = v
+	}
+	for k, v := range f.MemberLabels() {
+		apicast.Labels[k] = v
+	}
+
+	return apicast, nil
+}
+
+// Validate checks the members are unique and their APIcast specs are valid
+func (f *APIcastFleet) Validate() field.ErrorList {
+	errors := field.ErrorList{}
+
+	specFldPath := field.NewPath("spec")
+	membersFldPath := specFldPath.Child("members")
+
+	for idx, overlay := range f.Spec.NamespaceOverlays {
+		if overlay.Namespace == "" {
+			errors = append(errors, field.Required(specFldPath.Child("namespaceOverlays").Index(idx).Child("namespace"), ""))
+		}
+	}
+
+	members := map[string]bool{}
+	for idx, member := range f.Spec.Members {
+		memberFldPath := membersFldPath.Index(idx)
+
+		for _, msg := range validation.IsDNS1123Subdomain(member.Name) {
+			errors = append(errors, field.Invalid(memberFldPath.Child("name"), member.Name, msg))
+		}
+
+		key := fmt.Sprintf("%s/%s", f.MemberNamespace(member), member.Name)
+		if members[key] {
+			errors = append(errors, field.Duplicate(memberFldPath, key))
+			continue
+		}
+		members[key] = true
+
+		apicast, err := f.MemberAPIcast(member)
+		if err != nil {
+			errors = append(errors, field.Invalid(memberFldPath.Child("overlay"), member.Overlay, err.Error()))
+			continue
+		}
+		for _, memberErr := range apicast.Validate() {
+			// the path of the member spec fields
+			memberErr.Field = fmt.Sprintf("%s.%s", memberFldPath.String(), memberErr.Field)
+			errors = append(errors, memberErr)
+		}
+	}
+
+	return errors
+}
+
+func init() {
+	SchemeBuilder.Register(&APIcastFleet{}, &APIcastFleetList{})
+}
@@ -0,0 +1,132 @@
+//go:build unit
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func testAPIcastFleet() *APIcastFleet {
+	logLevel := "info"
+	replicas := int64(2)
+
+	return &APIcastFleet{
+		ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "gateways"},
+		Spec: APIcastFleetSpec{
+			Template: APIcastFleetTemplate{
+				Labels:      map[string]string{"team": "api"},
+				Annotations: map[string]string{"owner": "platform"},
+				Spec: APIcastSpec{
+					AdminPortalCredentialsRef: &corev1.LocalObjectReference{Name: "portal"},
+					LogLevel:                  &logLevel,
+					Replicas:                  &replicas,
+				},
+			},
+			NamespaceOverlays: []APIcastFleetNamespaceOverlay{
+				{Namespace: "staging", Overlay: runtime.RawExtension{Raw: []byte(`{"deploymentEnvironment":"staging","logLevel":"debug"}`)}},
+			},
+		},
+	}
+}
+
+func TestAPIcastFleetMemberAPIcast(t *testing.T) {
+	fleet := testAPIcastFleet()
+
+	tests := []struct {
+		name                  string
+		member                APIcastFleetMember
+		expectedNamespace     string
+		expectedLogLevel      string
+		expectedReplicas      int64
+		expectedDeploymentEnv string
+	}{
+		{"template", APIcastFleetMember{Name: "production"}, "gateways", "info", 2, ""},
+		{"namespace overlay", APIcastFleetMember{Name: "staging", Namespace: "staging"}, "staging", "debug", 2, "staging"},
+		{
+			"member overlay applied last",
+			APIcastFleetMember{Name: "staging", Namespace: "staging", Overlay: &runtime.RawExtension{Raw: []byte(`{"logLevel":"warn","replicas":3}`)}},
+			"staging", "warn", 3, "staging",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(subT *testing.T) {
+			apicast, err := fleet.MemberAPIcast(tc.member)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			if apicast.Name != tc.member.Name || apicast.Namespace != tc.expectedNamespace {
+				subT.Errorf("unexpected member %s/%s", apicast.Namespace, apicast.Name)
+			}
+			if apicast.Spec.LogLevel == nil || *apicast.Spec.LogLevel != tc.expectedLogLevel {
+				subT.Errorf("expected log level %s, got %v", tc.expectedLogLevel, apicast.Spec.LogLevel)
+			}
+			if apicast.Spec.Replicas == nil || *apicast.Spec.Replicas != tc.expectedReplicas {
+				subT.Errorf("expected replicas %d, got %v", tc.expectedReplicas, apicast.Spec.Replicas)
+			}
+			deploymentEnv := ""
+			if apicast.Spec.DeploymentEnvironment != nil {
+				deploymentEnv = string(*apicast.Spec.DeploymentEnvironment)
+			}
+			if deploymentEnv != tc.expectedDeploymentEnv {
+				subT.Errorf("expected deployment environment %q, got %q", tc.expectedDeploymentEnv, deploymentEnv)
+			}
+			if apicast.Spec.ServiceAccount == nil || *apicast.Spec.ServiceAccount != DefaultServiceAccount {
+				subT.Errorf("expected the defaults to be set, got service account %v", apicast.Spec.ServiceAccount)
+			}
+			if apicast.Labels["team"] != "api" || apicast.Labels[APIcastFleetNameLabel] != "fleet" || apicast.Labels[APIcastFleetNamespaceLabel] != "gateways" {
+				subT.Errorf("unexpected labels %v", apicast.Labels)
+			}
+			if apicast.Annotations["owner"] != "platform" {
+				subT.Errorf("unexpected annotations %v", apicast.Annotations)
+			}
+		})
+	}
+
+	// the template is not modified by the overlays
+	if *fleet.Spec.Template.Spec.LogLevel != "info" {
+		t.Errorf("the template spec was modified: %s", *fleet.Spec.Template.Spec.LogLevel)
+	}
+}
+
+func TestAPIcastFleetValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		members       []APIcastFleetMember
+		expectedField string
+	}{
+		{"valid", []APIcastFleetMember{{Name: "a"}, {Name: "a", Namespace: "staging"}}, ""},
+		{"invalid name", []APIcastFleetMember{{Name: "Invalid_Name"}}, "spec.members[0].name"},
+		{"duplicated member", []APIcastFleetMember{{Name: "a"}, {Name: "a", Namespace: "gateways"}}, "spec.members[1]"},
+		{"invalid overlay", []APIcastFleetMember{{Name: "a", Overlay: &runtime.RawExtension{Raw: []byte(`[]`)}}}, "spec.members[0].overlay"},
+		{"invalid member spec", []APIcastFleetMember{{Name: "a", Overlay: &runtime.RawExtension{Raw: []byte(`{"httpsPort":8080}`)}}}, "spec.members[0].spec.httpsPort"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(subT *testing.T) {
+			fleet := testAPIcastFleet()
+			fleet.Spec.Members = tc.members
+
+			errors := fleet.Validate()
+			if tc.expectedField == "" {
+				if len(errors) > 0 {
+					subT.Fatalf("unexpected errors: %v", errors)
+				}
+				return
+			}
+
+			if len(errors) == 0 {
+				subT.Fatalf("expected an error on %s", tc.expectedField)
+			}
+			if !strings.HasPrefix(errors[0].Field, tc.expectedField) {
+				subT.Errorf("expected an error on %s, got %v", tc.expectedField, errors)
+			}
+		})
+	}
+}
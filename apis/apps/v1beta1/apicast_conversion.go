@@ -0,0 +1,581 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+)
+
+// ConvertTo converts this APIcast to the Hub version (v1alpha1).
+func (src *APIcast) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.APIcast)
+	if !ok {
+		return fmt.Errorf("%T is not a *v1alpha1.APIcast", dstRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	in := src.Spec.DeepCopy()
+	out := &dst.Spec
+	*out = v1alpha1.APIcastSpec{}
+
+	out.AdminPortalCredentialsRef = in.AdminPortalCredentialsRef
+	out.AdminPortalCheck = (*v1alpha1.AdminPortalCheckSpec)(in.AdminPortalCheck)
+	out.EmbeddedConfigurationSecretRef = in.EmbeddedConfigurationSecretRef
+	out.Replicas = in.Replicas
+	if in.Autoscaling != nil {
+		// createOnly is the v1alpha1 "hpa: true" shorthand
+		if in.Autoscaling.CreateOnly {
+			out.Hpa = true
+		} else {
+			out.Autoscaling = &v1alpha1.AutoscalingSpec{
+				MinReplicas:             in.Autoscaling.MinReplicas,
+				MaxReplicas:             in.Autoscaling.MaxReplicas,
+				CPUTargetUtilization:    in.Autoscaling.CPUTargetUtilization,
+				MemoryTargetUtilization: in.Autoscaling.MemoryTargetUtilization,
+				Metrics:                 in.Autoscaling.Metrics,
+				Behavior:                in.Autoscaling.Behavior,
+			}
+		}
+	}
+	if in.PodDisruptionBudget != nil {
+		out.PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{Enabled: in.PodDisruptionBudget.Enabled}
+	}
+	out.ServiceAccount = in.ServiceAccount
+	out.Image = in.Image
+	out.ImagePullPolicy = in.ImagePullPolicy
+	out.ImagePullSecrets = in.ImagePullSecrets
+	out.ImageDigestPinning = in.ImageDigestPinning
+	if in.UpgradePolicy != nil {
+		out.UpgradePolicy = &v1alpha1.UpgradePolicySpec{
+			Type:              (*v1alpha1.UpgradePolicyType)(in.UpgradePolicy.Type),
+			ApprovedVersion:   in.UpgradePolicy.ApprovedVersion,
+			MaintenanceWindow: (*v1alpha1.MaintenanceWindowSpec)(in.UpgradePolicy.MaintenanceWindow),
+		}
+	}
+	out.Resources = in.Resources
+	if in.Scheduling != nil {
+		out.Affinity = in.Scheduling.Affinity
+		out.Tolerations = in.Scheduling.Tolerations
+		out.TopologySpreadConstraints = in.Scheduling.TopologySpreadConstraints
+		out.PriorityClassName = in.Scheduling.PriorityClassName
+	}
+	out.Sidecars = in.Sidecars
+	out.InitContainers = in.InitContainers
+	out.ExtraVolumes = in.ExtraVolumes
+	out.ExtraVolumeMounts = in.ExtraVolumeMounts
+	out.PodTemplateOverride = in.PodTemplateOverride
+	if in.Probes != nil {
+		out.Probes = &v1alpha1.ProbesSpec{
+			Liveness:  (*v1alpha1.ProbeSpec)(in.Probes.Liveness),
+			Readiness: (*v1alpha1.ProbeSpec)(in.Probes.Readiness),
+			Startup:   (*v1alpha1.ProbeSpec)(in.Probes.Startup),
+		}
+	}
+	if in.DeploymentStrategy != nil {
+		out.DeploymentStrategy = &v1alpha1.DeploymentStrategySpec{
+			Type:           (*v1alpha1.DeploymentStrategyType)(in.DeploymentStrategy.Type),
+			MaxSurge:       in.DeploymentStrategy.MaxSurge,
+			MaxUnavailable: in.DeploymentStrategy.MaxUnavailable,
+		}
+	}
+	out.MinReadySeconds = in.MinReadySeconds
+	out.ProgressDeadlineSeconds = in.ProgressDeadlineSeconds
+	out.RevisionHistoryLimit = in.RevisionHistoryLimit
+	out.GracefulShutdown = (*v1alpha1.GracefulShutdownSpec)(in.GracefulShutdown)
+	if in.Canary != nil {
+		out.Canary = &v1alpha1.CanarySpec{
+			ReplicasPercentage: in.Canary.ReplicasPercentage,
+			AnalysisSeconds:    in.Canary.AnalysisSeconds,
+			ErrorRate:          (*v1alpha1.CanaryErrorRateSpec)(in.Canary.ErrorRate),
+		}
+	}
+	if in.BlueGreen != nil {
+		out.BlueGreen = &v1alpha1.BlueGreenSpec{
+			ActiveColor: (*v1alpha1.BlueGreenColor)(in.BlueGreen.ActiveColor),
+		}
+	}
+	if in.ExposedHost != nil {
+		out.ExposedHost = &v1alpha1.APIcastExposedHost{
+			Host:             in.ExposedHost.Host,
+			IngressClassName: in.ExposedHost.IngressClassName,
+			TLS:              in.ExposedHost.TLS,
+		}
+		if in.ExposedHost.Route != nil {
+			out.ExposedHost.Route = &v1alpha1.APIcastExposedHostRoute{
+				Termination:                   in.ExposedHost.Route.Termination,
+				InsecureEdgeTerminationPolicy: in.ExposedHost.Route.InsecureEdgeTerminationPolicy,
+				WildcardPolicy:                in.ExposedHost.Route.WildcardPolicy,
+			}
+		}
+		if in.ExposedHost.Gateway != nil {
+			out.ExposedHost.Gateway = &v1alpha1.APIcastExposedHostGateway{
+				ParentRefs:         in.ExposedHost.Gateway.ParentRefs,
+				Hostnames:          in.ExposedHost.Gateway.Hostnames,
+				PathMatches:        in.ExposedHost.Gateway.PathMatches,
+				TLSRouteParentRefs: in.ExposedHost.Gateway.TLSRouteParentRefs,
+			}
+		}
+	}
+	if in.DeploymentEnvironment != nil {
+		deploymentEnvironment := v1alpha1.DeploymentEnvironmentType(*in.DeploymentEnvironment)
+		out.DeploymentEnvironment = &deploymentEnvironment
+	}
+	out.DNSResolverAddress = in.DNSResolverAddress
+	out.EnabledServices = in.EnabledServices
+	out.ConfigurationLoadMode = in.ConfigurationLoadMode
+	out.PathRoutingEnabled = in.PathRoutingEnabled
+	out.ResponseCodesIncluded = in.ResponseCodesIncluded
+	out.ManagementAPIScope = in.ManagementAPIScope
+	out.OpenSSLPeerVerificationEnabled = in.OpenSSLPeerVerificationEnabled
+	out.UpstreamRetryCases = in.UpstreamRetryCases
+	out.LoadServicesWhenNeeded = in.LoadServicesWhenNeeded
+	out.ServicesFilterByURL = in.ServicesFilterByURL
+	out.ServiceConfigurationVersionOverride = in.ServiceConfigurationVersionOverride
+	out.Workers = in.Workers
+	out.Timezone = in.Timezone
+	if in.TLS != nil {
+		out.HTTPSPort = in.TLS.HTTPSPort
+		out.HTTPSVerifyDepth = in.TLS.HTTPSVerifyDepth
+		out.HTTPSCertificateSecretRef = in.TLS.CertificateSecretRef
+		out.CACertificateSecretRef = in.TLS.CACertificateSecretRef
+	}
+	if in.Cache != nil {
+		out.CacheConfigurationSeconds = in.Cache.ConfigurationSeconds
+		out.CacheMaxTime = in.Cache.MaxTime
+		out.CacheStatusCodes = in.Cache.StatusCodes
+		out.ServiceCacheSize = in.Cache.ServiceCacheSize
+	}
+	if in.Proxy != nil {
+		out.AllProxy = in.Proxy.AllProxy
+		out.HTTPProxy = in.Proxy.HTTPProxy
+		out.HTTPSProxy = in.Proxy.HTTPSProxy
+		out.NoProxy = in.Proxy.NoProxy
+	}
+	if in.Observability != nil {
+		out.LogLevel = in.Observability.LogLevel
+		out.OidcLogLevel = in.Observability.OidcLogLevel
+		out.ExtendedMetrics = in.Observability.ExtendedMetrics
+		if in.Observability.OpenTelemetry != nil {
+			out.OpenTelemetry = &v1alpha1.OpenTelemetrySpec{
+				Enabled:                in.Observability.OpenTelemetry.Enabled,
+				TracingConfigSecretRef: in.Observability.OpenTelemetry.TracingConfigSecretRef,
+				TracingConfigSecretKey: in.Observability.OpenTelemetry.TracingConfigSecretKey,
+			}
+		}
+		if in.Observability.OpenTracing != nil {
+			out.OpenTracing = &v1alpha1.OpenTracingSpec{
+				Enabled:                in.Observability.OpenTracing.Enabled,
+				TracingLibrary:         in.Observability.OpenTracing.TracingLibrary,
+				TracingConfigSecretRef: in.Observability.OpenTracing.TracingConfigSecretRef,
+			}
+		}
+		if in.Observability.Monitoring != nil {
+			out.Monitoring = &v1alpha1.MonitoringSpec{
+				Enabled:       in.Observability.Monitoring.Enabled,
+				Kind:          in.Observability.Monitoring.Kind,
+				Interval:      in.Observability.Monitoring.Interval,
+				ScrapeTimeout: in.Observability.Monitoring.ScrapeTimeout,
+				Relabelings:   in.Observability.Monitoring.Relabelings,
+				Labels:        in.Observability.Monitoring.Labels,
+			}
+			if alerts := in.Observability.Monitoring.Alerts; alerts != nil {
+				out.Monitoring.Alerts = &v1alpha1.MonitoringAlertsSpec{
+					Enabled:                 alerts.Enabled,
+					PodsNotReadyFor:         alerts.PodsNotReadyFor,
+					HTTP5xxRatioThreshold:   alerts.HTTP5xxRatioThreshold,
+					WorkerRestartsThreshold: alerts.WorkerRestartsThreshold,
+					ErrorLogThreshold:       alerts.ErrorLogThreshold,
+					Severity:                alerts.Severity,
+				}
+			}
+			if dashboard := in.Observability.Monitoring.Dashboard; dashboard != nil {
+				out.Monitoring.Dashboard = &v1alpha1.MonitoringDashboardSpec{
+					Enabled:          dashboard.Enabled,
+					Labels:           dashboard.Labels,
+					InstanceSelector: dashboard.InstanceSelector,
+				}
+			}
+		}
+	}
+	for _, policy := range in.CustomPolicies {
+		out.CustomPolicies = append(out.CustomPolicies, v1alpha1.CustomPolicySpec{
+			Name:      policy.Name,
+			Version:   policy.Version,
+			SecretRef: policy.SecretRef,
+		})
+	}
+	for _, environment := range in.CustomEnvironments {
+		out.CustomEnvironments = append(out.CustomEnvironments, v1alpha1.CustomEnvironmentSpec{
+			SecretRef: environment.SecretRef,
+		})
+	}
+	for _, secretSource := range in.SecretSources {
+		out.SecretSources = append(out.SecretSources, v1alpha1.SecretSourceSpec{
+			Name: secretSource.Name,
+			SourceRef: v1alpha1.SecretSourceRef{
+				Namespace: secretSource.SourceRef.Namespace,
+				Name:      secretSource.SourceRef.Name,
+			},
+		})
+	}
+
+	dst.Status = v1alpha1.APIcastStatus{
+		Image:                        src.Status.Image,
+		ObservedGeneration:           src.Status.ObservedGeneration,
+		Conditions:                   src.Status.DeepCopy().Conditions,
+		Replicas:                     src.Status.Replicas,
+		ReadyReplicas:                src.Status.ReadyReplicas,
+		UpdatedReplicas:              src.Status.UpdatedReplicas,
+		URLs:                         src.Status.DeepCopy().URLs,
+		ConfigurationLoadMode:        src.Status.ConfigurationLoadMode,
+		SecretHashes:                 src.Status.DeepCopy().SecretHashes,
+		LastConfigurationRolloutTime: src.Status.LastConfigurationRolloutTime.DeepCopy(),
+		LastAdminPortalCheckTime:     src.Status.LastAdminPortalCheckTime.DeepCopy(),
+	}
+	if src.Status.Canary != nil {
+		dst.Status.Canary = &v1alpha1.CanaryStatus{
+			Image:             src.Status.Canary.Image,
+			Phase:             v1alpha1.CanaryPhase(src.Status.Canary.Phase),
+			Replicas:          src.Status.Canary.Replicas,
+			AvailableReplicas: src.Status.Canary.AvailableReplicas,
+			AvailableSince:    src.Status.Canary.AvailableSince.DeepCopy(),
+			Message:           src.Status.Canary.Message,
+		}
+	}
+	if src.Status.ResolvedImage != nil {
+		dst.Status.ResolvedImage = &v1alpha1.ResolvedImageStatus{
+			Image:  src.Status.ResolvedImage.Image,
+			Digest: src.Status.ResolvedImage.Digest,
+		}
+	}
+	if src.Status.Upgrade != nil {
+		dst.Status.Upgrade = &v1alpha1.UpgradeStatus{
+			Image:          src.Status.Upgrade.Image,
+			Version:        src.Status.Upgrade.Version,
+			PendingImage:   src.Status.Upgrade.PendingImage,
+			PendingVersion: src.Status.Upgrade.PendingVersion,
+			Message:        src.Status.Upgrade.Message,
+		}
+		for _, record := range src.Status.Upgrade.History {
+			dst.Status.Upgrade.History = append(dst.Status.Upgrade.History, v1alpha1.UpgradeRecord{
+				FromImage: record.FromImage,
+				ToImage:   record.ToImage,
+				Version:   record.Version,
+				Time:      *record.Time.DeepCopy(),
+			})
+		}
+	}
+	if src.Status.BlueGreen != nil {
+		dst.Status.BlueGreen = &v1alpha1.BlueGreenStatus{
+			ActiveColor:             v1alpha1.BlueGreenColor(src.Status.BlueGreen.ActiveColor),
+			ActiveConfigurationHash: src.Status.BlueGreen.ActiveConfigurationHash,
+			Message:                 src.Status.BlueGreen.Message,
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version.
+func (dst *APIcast) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.APIcast)
+	if !ok {
+		return fmt.Errorf("%T is not a *v1alpha1.APIcast", srcRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	in := src.Spec.DeepCopy()
+	out := &dst.Spec
+	*out = APIcastSpec{}
+
+	out.AdminPortalCredentialsRef = in.AdminPortalCredentialsRef
+	out.AdminPortalCheck = (*AdminPortalCheckSpec)(in.AdminPortalCheck)
+	out.EmbeddedConfigurationSecretRef = in.EmbeddedConfigurationSecretRef
+	out.Replicas = in.Replicas
+	if in.Autoscaling != nil {
+		out.Autoscaling = &AutoscalingSpec{
+			MinReplicas:             in.Autoscaling.MinReplicas,
+			MaxReplicas:             in.Autoscaling.MaxReplicas,
+			CPUTargetUtilization:    in.Autoscaling.CPUTargetUtilization,
+			MemoryTargetUtilization: in.Autoscaling.MemoryTargetUtilization,
+			Metrics:                 in.Autoscaling.Metrics,
+			Behavior:                in.Autoscaling.Behavior,
+		}
+	} else if in.Hpa {
+		// autoscaling takes precedence over the shorthand
+		out.Autoscaling = &AutoscalingSpec{CreateOnly: true}
+	}
+	if in.PodDisruptionBudget != nil {
+		out.PodDisruptionBudget = &PodDisruptionBudgetSpec{Enabled: in.PodDisruptionBudget.Enabled}
+	}
+	out.ServiceAccount = in.ServiceAccount
+	out.Image = in.Image
+	out.ImagePullPolicy = in.ImagePullPolicy
+	out.ImagePullSecrets = in.ImagePullSecrets
+	out.ImageDigestPinning = in.ImageDigestPinning
+	if in.UpgradePolicy != nil {
+		out.UpgradePolicy = &UpgradePolicySpec{
+			Type:              (*UpgradePolicyType)(in.UpgradePolicy.Type),
+			ApprovedVersion:   in.UpgradePolicy.ApprovedVersion,
+			MaintenanceWindow: (*MaintenanceWindowSpec)(in.UpgradePolicy.MaintenanceWindow),
+		}
+	}
+	out.Resources = in.Resources
+	scheduling := SchedulingSpec{
+		Affinity:                  in.Affinity,
+		Tolerations:               in.Tolerations,
+		TopologySpreadConstraints: in.TopologySpreadConstraints,
+		PriorityClassName:         in.PriorityClassName,
+	}
+	if scheduling.Affinity != nil || scheduling.Tolerations != nil ||
+		scheduling.TopologySpreadConstraints != nil || scheduling.PriorityClassName != nil {
+		out.Scheduling = &scheduling
+	}
+	out.Sidecars = in.Sidecars
+	out.InitContainers = in.InitContainers
+	out.ExtraVolumes = in.ExtraVolumes
+	out.ExtraVolumeMounts = in.ExtraVolumeMounts
+	out.PodTemplateOverride = in.PodTemplateOverride
+	if in.Probes != nil {
+		out.Probes = &ProbesSpec{
+			Liveness:  (*ProbeSpec)(in.Probes.Liveness),
+			Readiness: (*ProbeSpec)(in.Probes.Readiness),
+			Startup:   (*ProbeSpec)(in.Probes.Startup),
+		}
+	}
+	if in.DeploymentStrategy != nil {
+		out.DeploymentStrategy = &DeploymentStrategySpec{
+			Type:           (*DeploymentStrategyType)(in.DeploymentStrategy.Type),
+			MaxSurge:       in.DeploymentStrategy.MaxSurge,
+			MaxUnavailable: in.DeploymentStrategy.MaxUnavailable,
+		}
+	}
+	out.MinReadySeconds = in.MinReadySeconds
+	out.ProgressDeadlineSeconds = in.ProgressDeadlineSeconds
+	out.RevisionHistoryLimit = in.RevisionHistoryLimit
+	out.GracefulShutdown = (*GracefulShutdownSpec)(in.GracefulShutdown)
+	if in.Canary != nil {
+		out.Canary = &CanarySpec{
+			ReplicasPercentage: in.Canary.ReplicasPercentage,
+			AnalysisSeconds:    in.Canary.AnalysisSeconds,
+			ErrorRate:          (*CanaryErrorRateSpec)(in.Canary.ErrorRate),
+		}
+	}
+	if in.BlueGreen != nil {
+		out.BlueGreen = &BlueGreenSpec{
+			ActiveColor: (*BlueGreenColor)(in.BlueGreen.ActiveColor),
+		}
+	}
+	if in.ExposedHost != nil {
+		out.ExposedHost = &APIcastExposedHost{
+			Host:             in.ExposedHost.Host,
+			IngressClassName: in.ExposedHost.IngressClassName,
+			TLS:              in.ExposedHost.TLS,
+		}
+		if in.ExposedHost.Route != nil {
+			out.ExposedHost.Route = &APIcastExposedHostRoute{
+				Termination:                   in.ExposedHost.Route.Termination,
+				InsecureEdgeTerminationPolicy: in.ExposedHost.Route.InsecureEdgeTerminationPolicy,
+				WildcardPolicy:                in.ExposedHost.Route.WildcardPolicy,
+			}
+		}
+		if in.ExposedHost.Gateway != nil {
+			out.ExposedHost.Gateway = &APIcastExposedHostGateway{
+				ParentRefs:         in.ExposedHost.Gateway.ParentRefs,
+				Hostnames:          in.ExposedHost.Gateway.Hostnames,
+				PathMatches:        in.ExposedHost.Gateway.PathMatches,
+				TLSRouteParentRefs: in.ExposedHost.Gateway.TLSRouteParentRefs,
+			}
+		}
+	}
+	if in.DeploymentEnvironment != nil {
+		deploymentEnvironment := DeploymentEnvironmentType(*in.DeploymentEnvironment)
+		out.DeploymentEnvironment = &deploymentEnvironment
+	}
+	out.DNSResolverAddress = in.DNSResolverAddress
+	out.EnabledServices = in.EnabledServices
+	out.ConfigurationLoadMode = in.ConfigurationLoadMode
+	out.PathRoutingEnabled = in.PathRoutingEnabled
+	out.ResponseCodesIncluded = in.ResponseCodesIncluded
+	out.ManagementAPIScope = in.ManagementAPIScope
+	out.OpenSSLPeerVerificationEnabled = in.OpenSSLPeerVerificationEnabled
+	out.UpstreamRetryCases = in.UpstreamRetryCases
+	out.LoadServicesWhenNeeded = in.LoadServicesWhenNeeded
+	out.ServicesFilterByURL = in.ServicesFilterByURL
+	out.ServiceConfigurationVersionOverride = in.ServiceConfigurationVersionOverride
+	out.Workers = in.Workers
+	out.Timezone = in.Timezone
+	tls := TLSSpec{
+		HTTPSPort:              in.HTTPSPort,
+		HTTPSVerifyDepth:       in.HTTPSVerifyDepth,
+		CertificateSecretRef:   in.HTTPSCertificateSecretRef,
+		CACertificateSecretRef: in.CACertificateSecretRef,
+	}
+	if tls != (TLSSpec{}) {
+		out.TLS = &tls
+	}
+	cache := CacheSpec{
+		ConfigurationSeconds: in.CacheConfigurationSeconds,
+		MaxTime:              in.CacheMaxTime,
+		StatusCodes:          in.CacheStatusCodes,
+		ServiceCacheSize:     in.ServiceCacheSize,
+	}
+	if cache != (CacheSpec{}) {
+		out.Cache = &cache
+	}
+	proxy := ProxySpec{
+		AllProxy:   in.AllProxy,
+		HTTPProxy:  in.HTTPProxy,
+		HTTPSProxy: in.HTTPSProxy,
+		NoProxy:    in.NoProxy,
+	}
+	if proxy != (ProxySpec{}) {
+		out.Proxy = &proxy
+	}
+	observability := ObservabilitySpec{
+		LogLevel:        in.LogLevel,
+		OidcLogLevel:    in.OidcLogLevel,
+		ExtendedMetrics: in.ExtendedMetrics,
+	}
+	if in.OpenTelemetry != nil {
+		observability.OpenTelemetry = &OpenTelemetrySpec{
+			Enabled:                in.OpenTelemetry.Enabled,
+			TracingConfigSecretRef: in.OpenTelemetry.TracingConfigSecretRef,
+			TracingConfigSecretKey: in.OpenTelemetry.TracingConfigSecretKey,
+		}
+	}
+	if in.OpenTracing != nil {
+		observability.OpenTracing = &OpenTracingSpec{
+			Enabled:                in.OpenTracing.Enabled,
+			TracingLibrary:         in.OpenTracing.TracingLibrary,
+			TracingConfigSecretRef: in.OpenTracing.TracingConfigSecretRef,
+		}
+	}
+	if in.Monitoring != nil {
+		observability.Monitoring = &MonitoringSpec{
+			Enabled:       in.Monitoring.Enabled,
+			Kind:          in.Monitoring.Kind,
+			Interval:      in.Monitoring.Interval,
+			ScrapeTimeout: in.Monitoring.ScrapeTimeout,
+			Relabelings:   in.Monitoring.Relabelings,
+			Labels:        in.Monitoring.Labels,
+		}
+		if alerts := in.Monitoring.Alerts; alerts != nil {
+			observability.Monitoring.Alerts = &MonitoringAlertsSpec{
+				Enabled:                 alerts.Enabled,
+				PodsNotReadyFor:         alerts.PodsNotReadyFor,
+				HTTP5xxRatioThreshold:   alerts.HTTP5xxRatioThreshold,
+				WorkerRestartsThreshold: alerts.WorkerRestartsThreshold,
+				ErrorLogThreshold:       alerts.ErrorLogThreshold,
+				Severity:                alerts.Severity,
+			}
+		}
+		if dashboard := in.Monitoring.Dashboard; dashboard != nil {
+			observability.Monitoring.Dashboard = &MonitoringDashboardSpec{
+				Enabled:          dashboard.Enabled,
+				Labels:           dashboard.Labels,
+				InstanceSelector: dashboard.InstanceSelector,
+			}
+		}
+	}
+	if observability != (ObservabilitySpec{}) {
+		out.Observability = &observability
+	}
+	for _, policy := range in.CustomPolicies {
+		out.CustomPolicies = append(out.CustomPolicies, CustomPolicySpec{
+			Name:      policy.Name,
+			Version:   policy.Version,
+			SecretRef: policy.SecretRef,
+		})
+	}
+	for _, environment := range in.CustomEnvironments {
+		out.CustomEnvironments = append(out.CustomEnvironments, CustomEnvironmentSpec{
+			SecretRef: environment.SecretRef,
+		})
+	}
+	for _, secretSource := range in.SecretSources {
+		out.SecretSources = append(out.SecretSources, SecretSourceSpec{
+			Name: secretSource.Name,
+			SourceRef: SecretSourceRef{
+				Namespace: secretSource.SourceRef.Namespace,
+				Name:      secretSource.SourceRef.Name,
+			},
+		})
+	}
+
+	dst.Status = APIcastStatus{
+		Image:                        src.Status.Image,
+		ObservedGeneration:           src.Status.ObservedGeneration,
+		Conditions:                   src.Status.DeepCopy().Conditions,
+		Replicas:                     src.Status.Replicas,
+		ReadyReplicas:                src.Status.ReadyReplicas,
+		UpdatedReplicas:              src.Status.UpdatedReplicas,
+		URLs:                         src.Status.DeepCopy().URLs,
+		ConfigurationLoadMode:        src.Status.ConfigurationLoadMode,
+		SecretHashes:                 src.Status.DeepCopy().SecretHashes,
+		LastConfigurationRolloutTime: src.Status.LastConfigurationRolloutTime.DeepCopy(),
+		LastAdminPortalCheckTime:     src.Status.LastAdminPortalCheckTime.DeepCopy(),
+	}
+	if src.Status.Canary != nil {
+		dst.Status.Canary = &CanaryStatus{
+			Image:             src.Status.Canary.Image,
+			Phase:             CanaryPhase(src.Status.Canary.Phase),
+			Replicas:          src.Status.Canary.Replicas,
+			AvailableReplicas: src.Status.Canary.AvailableReplicas,
+			AvailableSince:    src.Status.Canary.AvailableSince.DeepCopy(),
+			Message:           src.Status.Canary.Message,
+		}
+	}
+	if src.Status.ResolvedImage != nil {
+		dst.Status.ResolvedImage = &ResolvedImageStatus{
+			Image:  src.Status.ResolvedImage.Image,
+			Digest: src.Status.ResolvedImage.Digest,
+		}
+	}
+	if src.Status.Upgrade != nil {
+		dst.Status.Upgrade = &UpgradeStatus{
+			Image:          src.Status.Upgrade.Image,
+			Version:        src.Status.Upgrade.Version,
+			PendingImage:   src.Status.Upgrade.PendingImage,
+			PendingVersion: src.Status.Upgrade.PendingVersion,
+			Message:        src.Status.Upgrade.Message,
+		}
+		for _, record := range src.Status.Upgrade.History {
+			dst.Status.Upgrade.History = append(dst.Status.Upgrade.History, UpgradeRecord{
+				FromImage: record.FromImage,
+				ToImage:   record.ToImage,
+				Version:   record.Version,
+				Time:      *record.Time.DeepCopy(),
+			})
+		}
+	}
+	if src.Status.BlueGreen != nil {
+		dst.Status.BlueGreen = &BlueGreenStatus{
+			ActiveColor:             BlueGreenColor(src.Status.BlueGreen.ActiveColor),
+			ActiveConfigurationHash: src.Status.BlueGreen.ActiveConfigurationHash,
+			Message:                 src.Status.BlueGreen.Message,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+//go:build unit
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+)
+
+func strPtr(s string) *string { return &s }
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func int64Ptr(i int64) *int64 { return &i }
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestAPIcastConvertTo(t *testing.T) {
+	src := &APIcast{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "ns"},
+		Spec: APIcastSpec{
+			AdminPortalCredentialsRef: &v1.LocalObjectReference{Name: "portal"},
+			Autoscaling:               &AutoscalingSpec{CreateOnly: true},
+			Scheduling:                &SchedulingSpec{PriorityClassName: strPtr("high")},
+			TLS: &TLSSpec{
+				HTTPSPort:            int32Ptr(8443),
+				CertificateSecretRef: &v1.LocalObjectReference{Name: "cert"},
+			},
+			Cache:         &CacheSpec{ConfigurationSeconds: int64Ptr(300), MaxTime: strPtr("1m")},
+			Proxy:         &ProxySpec{NoProxy: strPtr("*")},
+			Observability: &ObservabilitySpec{LogLevel: strPtr("debug"), OpenTelemetry: &OpenTelemetrySpec{Enabled: boolPtr(true)}},
+		},
+	}
+
+	dst := &v1alpha1.APIcast{}
+	if err := src.ConvertTo(dst); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := v1alpha1.APIcastSpec{
+		AdminPortalCredentialsRef: &v1.LocalObjectReference{Name: "portal"},
+		Hpa:                       true,
+		PriorityClassName:         strPtr("high"),
+		HTTPSPort:                 int32Ptr(8443),
+		HTTPSCertificateSecretRef: &v1.LocalObjectReference{Name: "cert"},
+		CacheConfigurationSeconds: int64Ptr(300),
+		CacheMaxTime:              strPtr("1m"),
+		NoProxy:                   strPtr("*"),
+		LogLevel:                  strPtr("debug"),
+		OpenTelemetry:             &v1alpha1.OpenTelemetrySpec{Enabled: boolPtr(true)},
+	}
+	if diff := cmp.Diff(expected, dst.Spec); diff != "" {
+		t.Errorf("unexpected spec (-want,+got):\n%s", diff)
+	}
+	if dst.Name != "example" || dst.Namespace != "ns" {
+		t.Errorf("unexpected metadata: %s/%s", dst.Namespace, dst.Name)
+	}
+}
+
+func TestAPIcastRoundTrip(t *testing.T) {
+	maxSurge := intstr.FromInt32(1)
+	maxUnavailable := intstr.FromString("0%")
+	green := BlueGreenColorGreen
+	maintenanceWindow := UpgradePolicyMaintenanceWindow
+	tests := []struct {
+		name string
+		spec APIcastSpec
+	}{
+		{"empty spec", APIcastSpec{}},
+		{
+			"route exposed host",
+			APIcastSpec{ExposedHost: &APIcastExposedHost{Host: "apicast.example.com", Route: &APIcastExposedHostRoute{Termination: strPtr("edge")}}},
+		},
+		{
+			"hpa shorthand",
+			APIcastSpec{Autoscaling: &AutoscalingSpec{CreateOnly: true}},
+		},
+		{
+			"autoscaling defaults",
+			APIcastSpec{Autoscaling: &AutoscalingSpec{}},
+		},
+		{
+			"all groups set",
+			APIcastSpec{
+				EmbeddedConfigurationSecretRef: &v1.LocalObjectReference{Name: "config"},
+				Replicas:                       int64Ptr(2),
+				Autoscaling:                    &AutoscalingSpec{MaxReplicas: int32Ptr(10)},
+				PodDisruptionBudget:            &PodDisruptionBudgetSpec{Enabled: true},
+				ExposedHost: &APIcastExposedHost{
+					Host: "apicast.example.com",
+					Gateway: &APIcastExposedHostGateway{
+						ParentRefs: []gatewayapiv1.ParentReference{{Name: "gateway"}},
+						Hostnames:  []gatewayapiv1.Hostname{"apicast.example.com"},
+					},
+				},
+				Scheduling: &SchedulingSpec{
+					Tolerations: []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpExists}},
+				},
+				EnabledServices: []string{"1", "2"},
+				TLS:             &TLSSpec{HTTPSVerifyDepth: int64Ptr(3), CACertificateSecretRef: &v1.LocalObjectReference{Name: "ca"}},
+				Cache:           &CacheSpec{StatusCodes: strPtr("200 302"), ServiceCacheSize: int32Ptr(100)},
+				Proxy:           &ProxySpec{AllProxy: strPtr("http://proxy:3128"), HTTPSProxy: strPtr("http://proxy:3128")},
+				Observability: &ObservabilitySpec{
+					OidcLogLevel:    strPtr("warn"),
+					ExtendedMetrics: boolPtr(true),
+					OpenTracing:     &OpenTracingSpec{Enabled: boolPtr(true)},
+					Monitoring: &MonitoringSpec{
+						Enabled: true,
+						Kind:    strPtr("PodMonitor"),
+						Labels:  map[string]string{"release": "prometheus"},
+						Alerts:  &MonitoringAlertsSpec{Enabled: true, HTTP5xxRatioThreshold: int32Ptr(10)},
+						Dashboard: &MonitoringDashboardSpec{
+							Enabled:          true,
+							InstanceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"dashboards": "grafana"}},
+						},
+					},
+				},
+				CustomPolicies:       []CustomPolicySpec{{Name: "policy", Version: "0.1", SecretRef: &v1.LocalObjectReference{Name: "policy"}}},
+				CustomEnvironments:   []CustomEnvironmentSpec{{SecretRef: &v1.LocalObjectReference{Name: "env"}}},
+				Sidecars:             []v1.Container{{Name: "log-shipper", Image: "fluent-bit"}},
+				InitContainers:       []v1.Container{{Name: "policy-downloader", Image: "curl"}},
+				ExtraVolumes:         []v1.Volume{{Name: "logs", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}},
+				ExtraVolumeMounts:    []v1.VolumeMount{{Name: "logs", MountPath: "/var/log/apicast"}},
+				Probes:               &ProbesSpec{Startup: &ProbeSpec{FailureThreshold: int32Ptr(60)}},
+				DeploymentStrategy:   &DeploymentStrategySpec{MaxSurge: &maxSurge, MaxUnavailable: &maxUnavailable},
+				MinReadySeconds:      int32Ptr(10),
+				RevisionHistoryLimit: int32Ptr(3),
+				GracefulShutdown:     &GracefulShutdownSpec{PreStopDelaySeconds: int32Ptr(20), TerminationGracePeriodSeconds: int64Ptr(90)},
+				Canary:               &CanarySpec{ReplicasPercentage: int32Ptr(20), ErrorRate: &CanaryErrorRateSpec{PrometheusURL: "http://prometheus:9090"}},
+				BlueGreen:            &BlueGreenSpec{ActiveColor: &green},
+				AdminPortalCheck:     &AdminPortalCheckSpec{IntervalSeconds: int32Ptr(60)},
+				SecretSources:        []SecretSourceSpec{{Name: "env", SourceRef: SecretSourceRef{Namespace: "shared", Name: "env"}}},
+				ImagePullSecrets:     []v1.LocalObjectReference{{Name: "registry"}},
+				ImageDigestPinning:   boolPtr(true),
+				UpgradePolicy: &UpgradePolicySpec{
+					Type:              &maintenanceWindow,
+					MaintenanceWindow: &MaintenanceWindowSpec{Schedule: "0 2 * * 6", DurationMinutes: int32Ptr(120)},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(subT *testing.T) {
+			lastConfigurationRolloutTime := metav1.Unix(1700000000, 0)
+			src := &APIcast{
+				ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "ns"},
+				Spec:       tc.spec,
+				Status: APIcastStatus{
+					Image:                        "quay.io/3scale/apicast:latest",
+					ObservedGeneration:           3,
+					Canary:                       &CanaryStatus{Image: "quay.io/3scale/apicast:next", Phase: CanaryPhaseProgressing, Replicas: 1},
+					BlueGreen:                    &BlueGreenStatus{ActiveColor: BlueGreenColorBlue, ActiveConfigurationHash: "abc"},
+					ResolvedImage:                &ResolvedImageStatus{Image: "quay.io/3scale/apicast:latest", Digest: "quay.io/3scale/apicast@sha256:abc"},
+					Replicas:                     2,
+					ReadyReplicas:                1,
+					UpdatedReplicas:              1,
+					URLs:                         []string{"https://api.example.com"},
+					ConfigurationLoadMode:        "boot",
+					SecretHashes:                 map[string]string{"my-config": "abc"},
+					LastConfigurationRolloutTime: &lastConfigurationRolloutTime,
+					LastAdminPortalCheckTime:     &lastConfigurationRolloutTime,
+					Upgrade: &UpgradeStatus{
+						Image:          "quay.io/3scale/apicast:2.15",
+						Version:        "2.15.0",
+						PendingImage:   "quay.io/3scale/apicast:2.16",
+						PendingVersion: "2.16.0",
+						History:        []UpgradeRecord{{FromImage: "quay.io/3scale/apicast:2.14", ToImage: "quay.io/3scale/apicast:2.15", Version: "2.15.0", Time: lastConfigurationRolloutTime}},
+					},
+				},
+			}
+
+			hub := &v1alpha1.APIcast{}
+			if err := src.ConvertTo(hub); err != nil {
+				subT.Fatal(err)
+			}
+
+			dst := &APIcast{}
+			if err := dst.ConvertFrom(hub); err != nil {
+				subT.Fatal(err)
+			}
+
+			if diff := cmp.Diff(src, dst); diff != "" {
+				subT.Errorf("round trip is not lossless (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAPIcastHubRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		spec v1alpha1.APIcastSpec
+	}{
+		{"hpa shorthand", v1alpha1.APIcastSpec{Hpa: true}},
+		{"autoscaling defaults", v1alpha1.APIcastSpec{Autoscaling: &v1alpha1.AutoscalingSpec{}}},
+		{"autoscaling", v1alpha1.APIcastSpec{Autoscaling: &v1alpha1.AutoscalingSpec{MinReplicas: int32Ptr(2)}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(subT *testing.T) {
+			src := &v1alpha1.APIcast{
+				ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "ns"},
+				Spec:       tc.spec,
+			}
+
+			spoke := &APIcast{}
+			if err := spoke.ConvertFrom(src); err != nil {
+				subT.Fatal(err)
+			}
+
+			dst := &v1alpha1.APIcast{}
+			if err := spoke.ConvertTo(dst); err != nil {
+				subT.Fatal(err)
+			}
+
+			if diff := cmp.Diff(src.Spec, dst.Spec); diff != "" {
+				subT.Errorf("round trip is not lossless (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,979 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// CustomEnvironmentSpec contains or has reference to an APIcast custom environment
+type CustomEnvironmentSpec struct {
+	SecretRef *v1.LocalObjectReference `json:"secretRef"`
+}
+
+// SecretSourceSpec declares a managed copy of a secret living in another namespace
+type SecretSourceSpec struct {
+	// Name of the managed copy created in the APIcast namespace.
+	Name string `json:"name"`
+	// SourceRef references the secret to be copied.
+	SourceRef SecretSourceRef `json:"sourceRef"`
+}
+
+// SecretSourceRef references a secret in another namespace
+type SecretSourceRef struct {
+	// Namespace of the source secret
+	Namespace string `json:"namespace"`
+	// Name of the source secret
+	Name string `json:"name"`
+}
+
+// CustomPolicySpec contains or has reference to an APIcast custom policy
+type CustomPolicySpec struct {
+	// Name specifies the name of the custom policy
+	Name string `json:"name"`
+	// Version specifies the name of the custom policy
+	Version string `json:"version"`
+
+	// SecretRef specifies the secret holding the custom policy metadata and lua code
+	SecretRef *v1.LocalObjectReference `json:"secretRef"`
+}
+
+type PodDisruptionBudgetSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// AutoscalingSpec contains the HorizontalPodAutoscaler configuration of the
+// APIcast deployment. HPA is enabled when set.
+// +kubebuilder:validation:XValidation:rule="!has(self.createOnly) || !self.createOnly || !(has(self.minReplicas) || has(self.maxReplicas) || has(self.cpuTargetUtilization) || has(self.memoryTargetUtilization) || has(self.metrics) || has(self.behavior))",message="createOnly cannot be set with other autoscaling fields"
+type AutoscalingSpec struct {
+	// CreateOnly creates the HorizontalPodAutoscaler with the default values
+	// and does not revert the changes made to it afterwards. It cannot be set
+	// with the other fields.
+	// +optional
+	CreateOnly bool `json:"createOnly,omitempty"`
+	// MinReplicas is the lower limit for the number of replicas the autoscaler
+	// can scale down to. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit for the number of replicas the autoscaler
+	// can scale up to. Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// CPUTargetUtilization is the target average CPU utilization, represented
+	// as a percentage of the requested CPU. Defaults to 85. Set to 0 to disable
+	// the CPU based target.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CPUTargetUtilization *int32 `json:"cpuTargetUtilization,omitempty"`
+	// MemoryTargetUtilization is the target average memory utilization,
+	// represented as a percentage of the requested memory. Defaults to 85. Set
+	// to 0 to disable the memory based target.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MemoryTargetUtilization *int32 `json:"memoryTargetUtilization,omitempty"`
+	// Metrics contains additional metrics used to calculate the desired
+	// replica count, like custom or external metrics.
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+	// Behavior configures the scaling behavior of the autoscaler in both
+	// scale up and scale down directions.
+	// +optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// SchedulingSpec groups the pod scheduling settings of the APIcast deployment
+type SchedulingSpec struct {
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// +optional
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+}
+
+// TLSSpec groups the HTTPS listener and certificate settings of APIcast
+type TLSSpec struct {
+	// HTTPSPort controls on which port APIcast should start listening for HTTPS connections. If this clashes with HTTP port it will be used only for HTTPS.
+	// +optional
+	HTTPSPort *int32 `json:"httpsPort,omitempty"` // APICAST_HTTPS_PORT
+	// HTTPSVerifyDepth defines the maximum length of the client certificate chain.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	HTTPSVerifyDepth *int64 `json:"httpsVerifyDepth,omitempty"` // APICAST_HTTPS_VERIFY_DEPTH
+	// CertificateSecretRef references secret containing the X.509 certificate in the PEM format and the X.509 certificate secret key.
+	// +optional
+	CertificateSecretRef *v1.LocalObjectReference `json:"certificateSecretRef,omitempty"`
+	// CACertificateSecretRef references secret containing the X.509 CA certificate in the PEM format.
+	// +optional
+	CACertificateSecretRef *v1.LocalObjectReference `json:"caCertificateSecretRef,omitempty"`
+}
+
+// CacheSpec groups the APIcast caching settings
+type CacheSpec struct {
+	// The period (in seconds) that the APIcast configuration will be stored in
+	// APIcast's cache.
+	// +optional
+	ConfigurationSeconds *int64 `json:"configurationSeconds,omitempty"` // APICAST_CONFIGURATION_CACHE
+	// MaxTime indicates the maximum time to be cached. If cache-control header is not set, the time to be cached will be the defined one.
+	// +optional
+	MaxTime *string `json:"maxTime,omitempty"` // APICAST_CACHE_MAX_TIME
+	// StatusCodes defines the status codes for which the response content will be cached.
+	// +optional
+	StatusCodes *string `json:"statusCodes,omitempty"` // APICAST_CACHE_STATUS_CODES
+	// ServiceCacheSize specifies the number of services that APICast can store in the internal cache
+	// +optional
+	ServiceCacheSize *int32 `json:"serviceCacheSize,omitempty"` // APICAST_SERVICE_CACHE_SIZE
+}
+
+// ProxySpec groups the outbound HTTP(S) proxy settings of APIcast
+type ProxySpec struct {
+	// AllProxy specifies a HTTP(S) proxy to be used for connecting to services if
+	// a protocol-specific proxy is not specified. Authentication is not supported.
+	// Format is <scheme>://<host>:<port>
+	// +optional
+	AllProxy *string `json:"allProxy,omitempty"` // ALL_PROXY
+	// HTTPProxy specifies a HTTP(S) Proxy to be used for connecting to HTTP services.
+	// Authentication is not supported. Format is <scheme>://<host>:<port>
+	// +optional
+	HTTPProxy *string `json:"httpProxy,omitempty"` // HTTP_PROXY
+	// HTTPSProxy specifies a HTTP(S) Proxy to be used for connecting to HTTPS services.
+	// Authentication is not supported. Format is <scheme>://<host>:<port>
+	// +optional
+	HTTPSProxy *string `json:"httpsProxy,omitempty"` // HTTPS_PROXY
+	// NoProxy specifies a comma-separated list of hostnames and domain
+	// names for which the requests should not be proxied. Setting to a single
+	// * character, which matches all hosts, effectively disables the proxy.
+	// +optional
+	NoProxy *string `json:"noProxy,omitempty"` // NO_PROXY
+}
+
+// ObservabilitySpec groups the logging, metrics and tracing settings of APIcast
+type ObservabilitySpec struct {
+	// LogLevel controls the log level of APIcast's OpenResty logs.
+	// +optional
+	// +kubebuilder:validation:Enum=debug;info;notice;warn;error;crit;alert;emerg
+	LogLevel *string `json:"logLevel,omitempty"` // APICAST_LOG_LEVEL
+	// OidcLogLevel allows to set the log level for the logs related to OpenID Connect integration.
+	// +kubebuilder:validation:Enum=debug;info;notice;warn;error;crit;alert;emerg
+	// +optional
+	OidcLogLevel *string `json:"oidcLogLevel,omitempty"` // APICAST_OIDC_LOG_LEVEL
+	// ExtendedMetrics enables additional information on Prometheus metrics; some labels will be used with specific information that will provide more in-depth details about APIcast.
+	// +optional
+	ExtendedMetrics *bool `json:"extendedMetrics,omitempty"` // APICAST_EXTENDED_METRICS
+	// OpenTelemetry contains the gateway instrumentation configuration
+	// with APIcast.
+	// +optional
+	OpenTelemetry *OpenTelemetrySpec `json:"openTelemetry,omitempty"`
+	// OpenTracingSpec contains the OpenTracing integration configuration
+	// with APIcast.
+	// Deprecated
+	// +optional
+	OpenTracing *OpenTracingSpec `json:"openTracing,omitempty"`
+	// Monitoring contains the Prometheus Operator monitoring configuration
+	// of the APIcast metrics.
+	// +optional
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+}
+
+// MonitoringDashboardSpec contains the configuration of the APIcast Grafana
+// dashboard
+type MonitoringDashboardSpec struct {
+	// Enabled controls whether a Grafana dashboard for the APIcast metrics
+	// is created. The dashboard is created as a GrafanaDashboard resource
+	// when the Grafana Operator API is available, otherwise as a ConfigMap
+	// for the Grafana dashboards sidecar. Dashboards are only created when
+	// monitoring is enabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Labels are added to the dashboard resource. Defaults to the
+	// grafana_dashboard: "1" label watched by the Grafana dashboards sidecar.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// InstanceSelector selects the Grafana instances the GrafanaDashboard
+	// resource is imported into. Defaults to all the Grafana instances.
+	// +optional
+	InstanceSelector *metav1.LabelSelector `json:"instanceSelector,omitempty"`
+}
+
+// MonitoringAlertsSpec contains the configuration of the default APIcast
+// alerts
+type MonitoringAlertsSpec struct {
+	// Enabled controls whether a PrometheusRule with the default APIcast
+	// alerts is created. Alerts are only created when monitoring is enabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// PodsNotReadyFor is the time the number of ready pods must stay below
+	// the desired replicas before alerting. Defaults to 5m.
+	// +optional
+	PodsNotReadyFor *monitoringv1.Duration `json:"podsNotReadyFor,omitempty"`
+	// HTTP5xxRatioThreshold is the percentage of 5xx responses over the
+	// last 5 minutes above which an alert is raised. Defaults to 5.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	HTTP5xxRatioThreshold *int32 `json:"http5xxRatioThreshold,omitempty"`
+	// WorkerRestartsThreshold is the number of nginx worker restarts over
+	// the last 15 minutes above which an alert is raised. Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WorkerRestartsThreshold *int32 `json:"workerRestartsThreshold,omitempty"`
+	// ErrorLogThreshold is the number of error log entries, like failures
+	// loading the configuration, over the last 5 minutes above which an
+	// alert is raised. Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ErrorLogThreshold *int32 `json:"errorLogThreshold,omitempty"`
+	// Severity label of the alerts. Defaults to warning.
+	// +kubebuilder:validation:Enum=critical;warning;info
+	// +optional
+	Severity *string `json:"severity,omitempty"`
+}
+
+// ProbeSpec overrides the timing settings of an APIcast container probe
+type ProbeSpec struct {
+	// Number of seconds after the container has started before the probe is initiated.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// Number of seconds after which the probe times out.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// How often (in seconds) to perform the probe.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// Minimum consecutive successes for the probe to be considered successful after having failed.
+	// Must be 1 for liveness and startup probes.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+	// Minimum consecutive failures for the probe to be considered failed after having succeeded.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// ProbesSpec contains the APIcast container probes configuration
+type ProbesSpec struct {
+	// Liveness overrides the liveness probe settings.
+	// +optional
+	Liveness *ProbeSpec `json:"liveness,omitempty"`
+	// Readiness overrides the readiness probe settings.
+	// +optional
+	Readiness *ProbeSpec `json:"readiness,omitempty"`
+	// Startup enables a startup probe holding off the liveness and readiness
+	// probes until APIcast has booted, with the given settings. Enable it when
+	// APIcast needs long to boot, for instance, with large embedded
+	// configurations.
+	// +optional
+	Startup *ProbeSpec `json:"startup,omitempty"`
+}
+
+// DeploymentStrategyType describes how the APIcast pods are replaced on updates
+// +kubebuilder:validation:Enum=RollingUpdate;Recreate
+type DeploymentStrategyType string
+
+const (
+	// RollingUpdateDeploymentStrategyType replaces the old pods gradually.
+	RollingUpdateDeploymentStrategyType DeploymentStrategyType = "RollingUpdate"
+	// RecreateDeploymentStrategyType kills all the old pods before creating new ones.
+	RecreateDeploymentStrategyType DeploymentStrategyType = "Recreate"
+)
+
+// DeploymentStrategySpec describes how the APIcast deployment replaces
+// existing pods with new ones
+type DeploymentStrategySpec struct {
+	// Type of the deployment strategy. RollingUpdate or Recreate. Defaults to RollingUpdate.
+	// +optional
+	Type *DeploymentStrategyType `json:"type,omitempty"`
+	// MaxSurge is the maximum number of pods that can be scheduled above the
+	// desired number of pods during a rolling update. Value can be an absolute
+	// number or a percentage of desired pods. Defaults to 25%.
+	// Only valid with the RollingUpdate strategy.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+	// MaxUnavailable is the maximum number of pods that can be unavailable
+	// during a rolling update. Value can be an absolute number or a
+	// percentage of desired pods. Defaults to 25%.
+	// Only valid with the RollingUpdate strategy.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// GracefulShutdownSpec configures how APIcast pods drain in-flight requests
+// before they are stopped
+type GracefulShutdownSpec struct {
+	// PreStopDelaySeconds is the time the APIcast container keeps serving
+	// after the pod is marked for termination, so the pod is removed from the
+	// service endpoints before APIcast stops accepting connections. APIcast
+	// then finishes the in-flight requests and exits. Defaults to 15.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PreStopDelaySeconds *int32 `json:"preStopDelaySeconds,omitempty"`
+	// TerminationGracePeriodSeconds is the total time given to the APIcast
+	// pod to stop, including the preStop delay, before it is killed. Must be
+	// greater than preStopDelaySeconds. Defaults to 60.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+}
+
+// CanarySpec configures canary releases of new APIcast images
+type CanarySpec struct {
+	// ReplicasPercentage is the number of canary pods, as a percentage of the
+	// stable deployment replicas, rounded up. There is always at least one canary
+	// pod. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ReplicasPercentage *int32 `json:"replicasPercentage,omitempty"`
+	// AnalysisSeconds is the time all the canary pods must stay available before
+	// the new image is promoted. Defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AnalysisSeconds *int32 `json:"analysisSeconds,omitempty"`
+	// ErrorRate aborts the canary release when the ratio of 5xx responses
+	// returned by the canary pods is too high.
+	// +optional
+	ErrorRate *CanaryErrorRateSpec `json:"errorRate,omitempty"`
+}
+
+// CanaryErrorRateSpec configures the error rate check of the canary pods
+type CanaryErrorRateSpec struct {
+	// PrometheusURL is the base URL of the Prometheus HTTP API scraping the
+	// APIcast metrics. For instance, http://prometheus-operated.monitoring.svc:9090
+	PrometheusURL string `json:"prometheusURL"`
+	// MaxPercentage is the maximum percentage of 5xx responses of the canary
+	// pods over the analysis period. Defaults to 5.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxPercentage *int32 `json:"maxPercentage,omitempty"`
+}
+
+// CanaryPhase is the state of a canary release
+type CanaryPhase string
+
+const (
+	// CanaryPhaseProgressing means the canary pods are running the new image
+	CanaryPhaseProgressing CanaryPhase = "Progressing"
+	// CanaryPhasePromoted means the new image was rolled out to the stable deployment
+	CanaryPhasePromoted CanaryPhase = "Promoted"
+	// CanaryPhaseAborted means the new image was rejected and the stable deployment kept the previous image
+	CanaryPhaseAborted CanaryPhase = "Aborted"
+)
+
+// CanaryStatus reports the progress of the last canary release
+type CanaryStatus struct {
+	// Image is the image being released.
+	Image string `json:"image"`
+	// Phase of the canary release. Progressing, Promoted or Aborted.
+	Phase CanaryPhase `json:"phase"`
+	// Replicas is the desired number of canary pods.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// AvailableReplicas is the number of available canary pods.
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// AvailableSince is the time all the canary pods became available.
+	// +optional
+	AvailableSince *metav1.Time `json:"availableSince,omitempty"`
+	// Message is a human readable description of the canary state.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// UpgradePolicyType is the policy of the upgrades of the default image
+// +kubebuilder:validation:Enum=Automatic;Manual;MaintenanceWindow
+type UpgradePolicyType string
+
+const (
+	// UpgradePolicyAutomatic upgrades the image as soon as the operator is upgraded
+	UpgradePolicyAutomatic UpgradePolicyType = "Automatic"
+	// UpgradePolicyManual upgrades the image once the version is approved
+	UpgradePolicyManual UpgradePolicyType = "Manual"
+	// UpgradePolicyMaintenanceWindow upgrades the image during the maintenance window
+	UpgradePolicyMaintenanceWindow UpgradePolicyType = "MaintenanceWindow"
+)
+
+// UpgradePolicySpec controls the upgrades of the default image. Upgrades are
+// blocked while the APIcast is not ready.
+type UpgradePolicySpec struct {
+	// Type of the upgrade policy. Automatic, Manual or MaintenanceWindow.
+	// Defaults to Automatic.
+	// +optional
+	Type *UpgradePolicyType `json:"type,omitempty"`
+	// ApprovedVersion approves the upgrade to the 3scale version reported in
+	// status.upgrade.pendingVersion, when the policy is Manual.
+	// +optional
+	ApprovedVersion *string `json:"approvedVersion,omitempty"`
+	// MaintenanceWindow is the window upgrades are allowed in, when the
+	// policy is MaintenanceWindow.
+	// +optional
+	MaintenanceWindow *MaintenanceWindowSpec `json:"maintenanceWindow,omitempty"`
+}
+
+// MaintenanceWindowSpec is a recurring window upgrades are allowed in
+type MaintenanceWindowSpec struct {
+	// Schedule is the cron schedule of the start of the window, in UTC. For
+	// example "0 2 * * 6" starts the window every Saturday at 02:00.
+	Schedule string `json:"schedule"`
+	// DurationMinutes is the duration of the window. Defaults to 60.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1440
+	// +optional
+	DurationMinutes *int32 `json:"durationMinutes,omitempty"`
+}
+
+// UpgradeStatus reports the upgrades of the default image
+type UpgradeStatus struct {
+	// Image is the default image run by the APIcast pods.
+	Image string `json:"image"`
+	// Version is the 3scale version of the image, empty when the image was
+	// deployed by an operator version not tracking upgrades.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// PendingImage is the default image of the operator not rolled out yet.
+	// +optional
+	PendingImage string `json:"pendingImage,omitempty"`
+	// PendingVersion is the 3scale version of the pending image.
+	// +optional
+	PendingVersion string `json:"pendingVersion,omitempty"`
+	// Message is a human readable description of why the upgrade is pending.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// History of the upgrades, most recent first.
+	// +optional
+	History []UpgradeRecord `json:"history,omitempty"`
+}
+
+// UpgradeRecord is an upgrade of the default image
+type UpgradeRecord struct {
+	// FromImage is the image before the upgrade.
+	FromImage string `json:"fromImage"`
+	// ToImage is the image after the upgrade.
+	ToImage string `json:"toImage"`
+	// Version is the 3scale version of the image after the upgrade.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Time of the upgrade.
+	Time metav1.Time `json:"time"`
+}
+
+// ResolvedImageStatus is the digest an image tag was resolved to
+type ResolvedImageStatus struct {
+	// Image is the image reference that was resolved.
+	Image string `json:"image"`
+	// Digest is the image reference pinned to the digest of the image.
+	Digest string `json:"digest"`
+}
+
+// AdminPortalCheckSpec configures the connectivity check of the admin portal
+type AdminPortalCheckSpec struct {
+	// IntervalSeconds is the time between two checks. Defaults to 300.
+	// +kubebuilder:validation:Minimum=30
+	// +optional
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+}
+
+// BlueGreenColor identifies one of the two blue/green APIcast deployments
+// +kubebuilder:validation:Enum=blue;green
+type BlueGreenColor string
+
+const (
+	BlueGreenColorBlue  BlueGreenColor = "blue"
+	BlueGreenColorGreen BlueGreenColor = "green"
+)
+
+// BlueGreenSpec configures blue/green rollouts of embedded configuration changes
+type BlueGreenSpec struct {
+	// ActiveColor pins the deployment receiving the traffic. When unset, the
+	// traffic is switched to the deployment running the latest configuration
+	// as soon as all its pods are ready. Setting it to the color of the
+	// previous deployment rolls back to the previous configuration.
+	// +optional
+	ActiveColor *BlueGreenColor `json:"activeColor,omitempty"`
+}
+
+// BlueGreenStatus reports the state of the blue/green deployments
+type BlueGreenStatus struct {
+	// ActiveColor is the color of the deployment receiving the traffic. Empty
+	// until the first blue/green deployment is ready.
+	// +optional
+	ActiveColor BlueGreenColor `json:"activeColor,omitempty"`
+	// ActiveConfigurationHash is the hash of the embedded configuration
+	// served by the active deployment.
+	// +optional
+	ActiveConfigurationHash string `json:"activeConfigurationHash,omitempty"`
+	// Message is a human readable description of the blue/green state.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// MonitoringSpec contains the Prometheus Operator monitoring configuration
+// of the APIcast metrics
+type MonitoringSpec struct {
+	// Enabled controls whether a ServiceMonitor or PodMonitor scraping the
+	// APIcast metrics is created. By default it is not enabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Kind of the monitor resource scraping the APIcast metrics. Defaults to
+	// ServiceMonitor.
+	// +kubebuilder:validation:Enum=ServiceMonitor;PodMonitor
+	// +optional
+	Kind *string `json:"kind,omitempty"`
+	// Interval at which the metrics are scraped. Defaults to the Prometheus
+	// global scrape interval.
+	// +optional
+	Interval *monitoringv1.Duration `json:"interval,omitempty"`
+	// ScrapeTimeout is the timeout after which the scrape is ended. Defaults
+	// to the Prometheus global scrape timeout.
+	// +optional
+	ScrapeTimeout *monitoringv1.Duration `json:"scrapeTimeout,omitempty"`
+	// Relabelings are applied to the scraped targets before ingestion.
+	// +optional
+	Relabelings []monitoringv1.RelabelConfig `json:"relabelings,omitempty"`
+	// Labels are added to the monitor resource, so it can be selected by the
+	// Prometheus instance.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Alerts contains the configuration of the PrometheusRule with the
+	// default APIcast alerts. The monitoring labels are also added to the
+	// PrometheusRule.
+	// +optional
+	Alerts *MonitoringAlertsSpec `json:"alerts,omitempty"`
+	// Dashboard contains the configuration of the APIcast Grafana dashboard.
+	// +optional
+	Dashboard *MonitoringDashboardSpec `json:"dashboard,omitempty"`
+}
+
+// APIcastSpec defines the desired state of APIcast.
+type APIcastSpec struct {
+	// Secret reference to a Kubernetes Secret containing the admin portal
+	// endpoint URL. The Secret must be located in the same namespace.
+	// +optional
+	AdminPortalCredentialsRef *v1.LocalObjectReference `json:"adminPortalCredentialsRef,omitempty"`
+	// AdminPortalCheck enables probing the admin portal proxy configs
+	// endpoint with the access token of adminPortalCredentialsRef. The result
+	// is reported in the AdminPortalReachable condition.
+	// +optional
+	AdminPortalCheck *AdminPortalCheckSpec `json:"adminPortalCheck,omitempty"`
+	// Secret reference to a Kubernetes secret containing the gateway
+	// configuration. The Secret must be located in the same namespace.
+	// +optional
+	EmbeddedConfigurationSecretRef *v1.LocalObjectReference `json:"embeddedConfigurationSecretRef,omitempty"`
+
+	// Number of replicas of the APIcast Deployment.
+	// +optional
+	Replicas *int64 `json:"replicas,omitempty"`
+	// Autoscaling contains the HorizontalPodAutoscaler configuration. HPA is
+	// enabled when set.
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	// Kubernetes Service Account name to be used for the APIcast Deployment. The
+	// Service Account must exist beforehand.
+	// +optional
+	ServiceAccount *string `json:"serviceAccount,omitempty"`
+	// Image allows overriding the default APIcast gateway container image.
+	// This setting should only be used for dev/testing purposes. Setting
+	// this disables automated upgrades of the image.
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// ImagePullPolicy of the APIcast container. Defaults to Always.
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +optional
+	ImagePullPolicy *v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// ImagePullSecrets are the secrets used to pull the APIcast image. The
+	// Secrets must be located in the same namespace.
+	// +optional
+	ImagePullSecrets []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// ImageDigestPinning enables resolving the image tag to its digest in
+	// the registry. The APIcast pods run the digest, so moving the tag in
+	// the registry does not roll them out. The tag is resolved again when
+	// the image changes.
+	// +optional
+	ImageDigestPinning *bool `json:"imageDigestPinning,omitempty"`
+	// UpgradePolicy controls when the APIcast pods are upgraded to the
+	// default image of a new operator version. Ignored when image is set.
+	// +optional
+	UpgradePolicy *UpgradePolicySpec `json:"upgradePolicy,omitempty"`
+	// Resources can be used to set custom compute Kubernetes Resource
+	// Requirements for the APIcast deployment.
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+	// Scheduling groups the pod scheduling settings.
+	// +optional
+	Scheduling *SchedulingSpec `json:"scheduling,omitempty"`
+	// Sidecars specifies additional containers added to the APIcast pod
+	// next to the APIcast container.
+	// +optional
+	Sidecars []v1.Container `json:"sidecars,omitempty"`
+	// InitContainers specifies init containers added to the APIcast pod.
+	// +optional
+	InitContainers []v1.Container `json:"initContainers,omitempty"`
+	// ExtraVolumes specifies additional volumes added to the APIcast pod.
+	// They can be mounted by the APIcast container, the sidecars and the init containers.
+	// +optional
+	ExtraVolumes []v1.Volume `json:"extraVolumes,omitempty"`
+	// ExtraVolumeMounts specifies additional volume mounts of the APIcast container.
+	// +optional
+	ExtraVolumeMounts []v1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+	// PodTemplateOverride is a strategic merge patch applied on top of the pod
+	// template generated by the operator. It allows setting pod fields not
+	// modelled by the APIcast spec, like securityContext or hostAliases. The
+	// APIcast container and the pod selector labels cannot be overridden.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	PodTemplateOverride *runtime.RawExtension `json:"podTemplateOverride,omitempty"`
+	// Probes overrides the settings of the APIcast container liveness,
+	// readiness and startup probes.
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
+	// DeploymentStrategy describes how the APIcast pods are replaced on
+	// updates.
+	// +optional
+	DeploymentStrategy *DeploymentStrategySpec `json:"deploymentStrategy,omitempty"`
+	// MinReadySeconds is the minimum number of seconds for which a newly
+	// created APIcast pod should be ready without any of its containers
+	// crashing to be considered available. Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+	// ProgressDeadlineSeconds is the maximum time in seconds for the APIcast
+	// deployment to make progress before it is reported as failed with the
+	// ProgressDeadlineExceeded reason. Must be greater than minReadySeconds.
+	// Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+	// RevisionHistoryLimit is the number of old ReplicaSets kept to allow
+	// rollbacks. Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+	// GracefulShutdown enables draining the in-flight requests of APIcast
+	// pods before they are stopped, for instance, on rollouts.
+	// +optional
+	GracefulShutdown *GracefulShutdownSpec `json:"gracefulShutdown,omitempty"`
+	// Canary enables canary releases: when the APIcast image changes, the new
+	// image first runs in a canary deployment receiving part of the traffic,
+	// and it is promoted to all the pods only when the canary is healthy.
+	// +optional
+	Canary *CanarySpec `json:"canary,omitempty"`
+	// BlueGreen enables blue/green rollouts of embedded configuration
+	// changes: the new configuration is deployed next to the current one,
+	// the traffic is switched once all the new pods are ready, and the
+	// previous deployment is kept running for quick rollbacks. Requires
+	// embeddedConfigurationSecretRef.
+	// +optional
+	BlueGreen *BlueGreenSpec `json:"blueGreen,omitempty"`
+	// ExposedHost is the domain name used for external access. By default no
+	// external access is configured.
+	// +optional
+	ExposedHost *APIcastExposedHost `json:"exposedHost,omitempty"`
+
+	// DeploymentEnvironment is the environment for which the configuration will
+	// be downloaded from 3scale (Staging or Production), when using APIcast.
+	// +optional
+	DeploymentEnvironment *DeploymentEnvironmentType `json:"deploymentEnvironment,omitempty"` // THREESCALE_DEPLOYMENT_ENV
+	// DNSResolverAddress can be used to specify a custom DNS resolver address
+	// to be used by OpenResty.
+	// +optional
+	DNSResolverAddress *string `json:"dnsResolverAddress,omitempty"` // RESOLVER
+	// EnabledServices can be used to specify a list of service IDs used to
+	// filter the configured services.
+	// +optional
+	EnabledServices []string `json:"enabledServices,omitempty"` // APICAST_SERVICES_LIST
+	// ConfigurationLoadMode can be used to set APIcast's configuration load mode.
+	// +optional
+	// +kubebuilder:validation:Enum=boot;lazy
+	ConfigurationLoadMode *string `json:"configurationLoadMode,omitempty"` // APICAST_CONFIGURATION_LOADER
+	// PathRoutingEnabled can be used to enable APIcast's path-based routing
+	// in addition to to the default host-based routing.
+	// +optional
+	PathRoutingEnabled *bool `json:"pathRoutingEnabled,omitempty"` // APICAST_PATH_ROUTING
+	// ResponseCodesIncluded can be set to log the response codes of the responses
+	// in Apisonator, so they can then be visualized in the 3scale admin portal.
+	// +optional
+	ResponseCodesIncluded *bool `json:"responseCodesIncluded,omitempty"` // APICAST_RESPONSE_CODES
+	// ManagementAPIScope controls APIcast Management API scope. The Management
+	// API is powerful and can control the APIcast configuration. debug level
+	// should only be enabled for debugging purposes.
+	// +optional
+	// +kubebuilder:validation:Enum=disabled;status;policies;debug
+	ManagementAPIScope *string `json:"managementAPIScope,omitempty"` // APICAST_MANAGEMENT_API
+	// OpenSSLPeerVerificationEnabled controls OpenSSL peer verification.
+	// +optional
+	OpenSSLPeerVerificationEnabled *bool `json:"openSSLPeerVerificationEnabled,omitempty"` // OPENSSL_VERIFY
+	// UpstreamRetryCases Used only when the retry policy is configured. Specified in which cases a request to the upstream API should be retried.
+	// +kubebuilder:validation:Enum=error;timeout;invalid_header;http_500;http_502;http_503;http_504;http_403;http_404;http_429;non_idempotent; off
+	// +optional
+	UpstreamRetryCases *string `json:"upstreamRetryCases,omitempty"` // APICAST_UPSTREAM_RETRY_CASES
+	// LoadServicesWhenNeeded makes the configurations to be loaded lazily. APIcast will only load the ones configured for the host specified in the host header of the request.
+	// +optional
+	LoadServicesWhenNeeded *bool `json:"loadServicesWhenNeeded,omitempty"` // APICAST_LOAD_SERVICES_WHEN_NEEDED
+	// ServicesFilterByURL is used to filter the service configured in the 3scale API Manager, the filter matches with the public base URL (Staging or production).
+	// +optional
+	ServicesFilterByURL *string `json:"servicesFilterByURL,omitempty"` // APICAST_SERVICES_FILTER_BY_URL
+	// ServiceConfigurationVersionOverride contains service configuration version map to prevent it from auto-updating.
+	// +optional
+	ServiceConfigurationVersionOverride map[string]string `json:"serviceConfigurationVersionOverride,omitempty"` // APICAST_SERVICE_${ID}_CONFIGURATION_VERSION
+	// Workers defines the number of APIcast's worker processes per pod.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Workers *int32 `json:"workers,omitempty"` // APICAST_WORKERS
+	// Timezone specifies the local timezone of the APIcast deployment pods. A timezone value available in the TZ database must be set.
+	// +optional
+	Timezone *string `json:"timezone,omitempty"` // TZ
+
+	// TLS groups the HTTPS listener and certificate settings.
+	// +optional
+	TLS *TLSSpec `json:"tls,omitempty"`
+	// Cache groups the caching settings.
+	// +optional
+	Cache *CacheSpec `json:"cache,omitempty"`
+	// Proxy groups the outbound HTTP(S) proxy settings.
+	// +optional
+	Proxy *ProxySpec `json:"proxy,omitempty"`
+	// Observability groups the logging, metrics and tracing settings.
+	// +optional
+	Observability *ObservabilitySpec `json:"observability,omitempty"`
+
+	// CustomPolicies specifies an array of defined custome policies to be loaded
+	// +optional
+	CustomPolicies []CustomPolicySpec `json:"customPolicies,omitempty"`
+	// CustomEnvironments specifies an array of defined custome environments to be loaded
+	// +optional
+	CustomEnvironments []CustomEnvironmentSpec `json:"customEnvironments,omitempty"` // APICAST_ENVIRONMENT
+	// SecretSources specifies secrets from other namespaces that the operator
+	// copies into the APIcast namespace and keeps in sync.
+	// +optional
+	SecretSources []SecretSourceSpec `json:"secretSources,omitempty"`
+}
+
+type DeploymentEnvironmentType string
+
+type APIcastExposedHost struct {
+	Host string `json:"host"`
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// +optional
+	TLS []networkingv1.IngressTLS `json:"tls,omitempty"`
+	// Route exposes the host with an OpenShift Route instead of an Ingress.
+	// The ingressClassName and tls fields are ignored when set.
+	// +optional
+	Route *APIcastExposedHostRoute `json:"route,omitempty"`
+	// Gateway exposes the host attaching the APIcast service to existing
+	// Gateway API gateways instead of an Ingress. The ingressClassName and tls
+	// fields are ignored when set.
+	// +optional
+	Gateway *APIcastExposedHostGateway `json:"gateway,omitempty"`
+}
+
+type APIcastExposedHostGateway struct {
+	// ParentRefs references the gateways the HTTPRoute is attached to.
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []gatewayapiv1.ParentReference `json:"parentRefs"`
+	// Hostnames of the routes. Defaults to the exposed host.
+	// +optional
+	Hostnames []gatewayapiv1.Hostname `json:"hostnames,omitempty"`
+	// PathMatches of the HTTPRoute. Defaults to the "/" path prefix.
+	// +optional
+	PathMatches []gatewayapiv1.HTTPPathMatch `json:"pathMatches,omitempty"`
+	// TLSRouteParentRefs references the gateways a TLSRoute, targeting the
+	// APIcast HTTPS port, is attached to. The TLSRoute is only created when
+	// set, and requires httpsPort or httpsCertificateSecretRef to be set.
+	// +optional
+	TLSRouteParentRefs []gatewayapiv1.ParentReference `json:"tlsRouteParentRefs,omitempty"`
+}
+
+type APIcastExposedHostRoute struct {
+	// Termination indicates the TLS termination type. When not set, the
+	// route is not secured. reencrypt and passthrough terminations target the
+	// APIcast HTTPS port, which requires httpsPort or
+	// httpsCertificateSecretRef to be set.
+	// +kubebuilder:validation:Enum=edge;reencrypt;passthrough
+	// +optional
+	Termination *string `json:"termination,omitempty"`
+	// InsecureEdgeTerminationPolicy indicates the desired behavior for
+	// insecure connections to a secured route.
+	// +kubebuilder:validation:Enum=None;Allow;Redirect
+	// +optional
+	InsecureEdgeTerminationPolicy *string `json:"insecureEdgeTerminationPolicy,omitempty"`
+	// WildcardPolicy indicates whether the route admits a wildcard host.
+	// Defaults to None.
+	// +kubebuilder:validation:Enum=None;Subdomain
+	// +optional
+	WildcardPolicy *string `json:"wildcardPolicy,omitempty"`
+}
+
+type OpenTelemetrySpec struct {
+	// Enabled controls whether OpenTelemetry integration with APIcast is enabled.
+	// By default it is not enabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// TracingConfigSecretRef contains a Secret reference the Opentelemetry configuration.
+	// The configuration file specification is defined in the Nginx instrumentation library repo
+	// https://github.com/open-telemetry/opentelemetry-cpp-contrib/tree/main/instrumentation/nginx
+	// +optional
+	TracingConfigSecretRef *v1.LocalObjectReference `json:"tracingConfigSecretRef,omitempty"`
+
+	// TracingConfigSecretKey contains the key of the secret to select the configuration from.
+	// if unspecified, the first secret key in lexicographical order will be selected.
+	// +optional
+	TracingConfigSecretKey *string `json:"tracingConfigSecretKey,omitempty"`
+}
+
+type OpenTracingSpec struct {
+	// Enabled controls whether OpenTracing integration with APIcast is enabled.
+	// By default it is not enabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// TracingLibrary controls which OpenTracing library is loaded. At the moment
+	// the only supported tracer is `jaeger`. If not set, `jaeger` will be used.
+	// +optional
+	TracingLibrary *string `json:"tracingLibrary,omitempty"`
+	// TracingConfigSecretRef contains a Secret reference the OpenTracing configuration.
+	// Each supported tracing library provides a default configuration file
+	// that is used if TracingConfig is not specified.
+	// +optional
+	TracingConfigSecretRef *v1.LocalObjectReference `json:"tracingConfigSecretRef,omitempty"`
+}
+
+// APIcastStatus defines the observed state of APIcast.
+type APIcastStatus struct {
+	// The image being used in the APIcast deployment.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the observations of a foo's current state.
+	// Known .status.conditions.type are: "Available"
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// Canary reports the progress of the last canary release.
+	// +optional
+	Canary *CanaryStatus `json:"canary,omitempty"`
+
+	// BlueGreen reports the state of the blue/green deployments.
+	// +optional
+	BlueGreen *BlueGreenStatus `json:"blueGreen,omitempty"`
+
+	// ResolvedImage is the digest the image was resolved to, when image
+	// digest pinning is enabled.
+	// +optional
+	ResolvedImage *ResolvedImageStatus `json:"resolvedImage,omitempty"`
+
+	// Upgrade reports the upgrades of the default image.
+	// +optional
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+
+	// Replicas is the desired number of APIcast pods.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// ReadyReplicas is the number of APIcast pods ready to serve traffic.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// UpdatedReplicas is the number of APIcast pods running the latest pod template.
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// URLs are the URLs APIcast is exposed at by the Ingress or the Route.
+	// +optional
+	URLs []string `json:"urls,omitempty"`
+
+	// ConfigurationLoadMode is the configuration load mode used by APIcast,
+	// either set in the spec or the default of the deployment environment.
+	// +optional
+	ConfigurationLoadMode string `json:"configurationLoadMode,omitempty"`
+
+	// SecretHashes are the hashes of the data of the watched secrets, by secret name.
+	// +optional
+	SecretHashes map[string]string `json:"secretHashes,omitempty"`
+
+	// LastConfigurationRolloutTime is the last time the APIcast pods were
+	// rolled out because of a configuration change.
+	// +optional
+	LastConfigurationRolloutTime *metav1.Time `json:"lastConfigurationRolloutTime,omitempty"`
+
+	// LastAdminPortalCheckTime is the last time the admin portal connectivity
+	// was checked.
+	// +optional
+	LastAdminPortalCheckTime *metav1.Time `json:"lastAdminPortalCheckTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// APIcast is the Schema for the apicasts API.
+// +kubebuilder:resource:path=apicasts,scope=Namespaced
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.status.replicas`
+// +kubebuilder:printcolumn:name="Ready Replicas",type=integer,JSONPath=`.status.readyReplicas`
+// +kubebuilder:printcolumn:name="Up-to-date",type=integer,JSONPath=`.status.updatedReplicas`
+// +kubebuilder:printcolumn:name="URLs",type=string,JSONPath=`.status.urls`
+// +kubebuilder:printcolumn:name="Load Mode",type=string,JSONPath=`.status.configurationLoadMode`,priority=1
+// +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.status.image`,priority=1
+// +kubebuilder:printcolumn:name="Last Config Rollout",type=date,JSONPath=`.status.lastConfigurationRolloutTime`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +operator-sdk:csv:customresourcedefinitions:displayName="APIcast"
+type APIcast struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APIcastSpec   `json:"spec,omitempty"`
+	Status APIcastStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// APIcastList contains a list of APIcasts.
+type APIcastList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIcast `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&APIcast{}, &APIcastList{})
+}
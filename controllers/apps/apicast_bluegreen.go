@@ -0,0 +1,268 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+	"github.com/3scale/apicast-operator/pkg/apicast"
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
+	"github.com/3scale/apicast-operator/pkg/reconcilers"
+)
+
+var blueGreenColors = []appsv1alpha1.BlueGreenColor{appsv1alpha1.BlueGreenColorBlue, appsv1alpha1.BlueGreenColorGreen}
+
+// reconcileBlueGreen runs the blue/green rollout of the embedded configuration.
+// A new configuration is deployed to the color not receiving the traffic, and
+// the traffic is switched once all its pods are ready. The previous color keeps
+// running the previous configuration, so pinning it rolls back instantly.
+func (r *APIcastLogicReconciler) reconcileBlueGreen(ctx context.Context, apicastFactory *apicast.APIcast) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if r.BlueGreenStatus == nil {
+		r.BlueGreenStatus = &appsv1alpha1.BlueGreenStatus{}
+	}
+	activeColor := r.BlueGreenStatus.ActiveColor
+
+	configurationHashes := map[appsv1alpha1.BlueGreenColor]string{}
+	for _, color := range blueGreenColors {
+		deployment := &appsv1.Deployment{}
+		err = r.Client().Get(ctx, r.blueGreenDeploymentKey(color), deployment)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		if err == nil {
+			configurationHashes[color] = deployment.Spec.Template.Annotations[apicast.BlueGreenConfigurationHashAnnotation]
+		}
+	}
+
+	desiredHash := apicastFactory.BlueGreenConfigurationHash()
+	if r.isConfigurationInvalid() {
+		// the invalid configuration is not deployed to any color
+		if activeColor == "" {
+			r.BlueGreenStatus.Message = "Waiting for a valid embedded configuration"
+			return nil
+		}
+		desiredHash = r.BlueGreenStatus.ActiveConfigurationHash
+	}
+	targetColor := blueGreenTargetColor(activeColor, desiredHash, configurationHashes)
+
+	if !r.isConfigurationInvalid() {
+		if configurationHashes[targetColor] != desiredHash {
+			logger.Info("deploying new embedded configuration", "color", targetColor)
+		}
+
+		err = r.ReconcileResource(ctx, &v1.Secret{}, apicastFactory.BlueGreenConfigurationSecret(string(targetColor)),
+			reconcilers.SecretMutator(reconcilers.SecretDataMutator, reconcilers.SecretAnnotationsMutator))
+		if err != nil {
+			return err
+		}
+		configurationHashes[targetColor] = desiredHash
+	}
+
+	// blue/green pods are always scaled by the operator
+	blueGreenMutators := append([]reconcilers.DeploymentMutateFn{reconcilers.DeploymentReplicasMutator}, deploymentTemplateMutators()...)
+	replicas := map[appsv1alpha1.BlueGreenColor]int32{}
+	for _, color := range blueGreenColors {
+		configurationHash, ok := configurationHashes[color]
+		if !ok {
+			// the other color is only deployed on configuration changes
+			continue
+		}
+
+		desired, err := apicastFactory.BlueGreenDeployment(ctx, r.Client(), string(color), configurationHash)
+		if err != nil {
+			return err
+		}
+		err = r.ReconcileResource(ctx, &appsv1.Deployment{}, desired, reconcilers.DeploymentMutator(blueGreenMutators...))
+		if err != nil {
+			return err
+		}
+		replicas[color] = ptr.Deref(desired.Spec.Replicas, 1)
+	}
+
+	switchColor := targetColor
+	if pinnedColor := apicastFactory.BlueGreen().ActiveColor; pinnedColor != "" {
+		switchColor = appsv1alpha1.BlueGreenColor(pinnedColor)
+	}
+
+	if switchColor != activeColor {
+		ready, err := r.isBlueGreenDeploymentReady(ctx, switchColor, configurationHashes[switchColor], replicas[switchColor])
+		if err != nil {
+			return err
+		}
+		if ready {
+			logger.Info("switching traffic", "color", switchColor, "previousColor", activeColor)
+			r.RecordEventf(v1.EventTypeNormal, EventReasonTrafficSwitched, "Switched traffic to %s", switchColor)
+			if activeColor != "" {
+				now := metav1.Now()
+				r.LastConfigurationRolloutTime = &now
+			}
+			activeColor = switchColor
+		}
+	}
+
+	r.BlueGreenStatus.ActiveColor = activeColor
+	r.BlueGreenStatus.ActiveConfigurationHash = configurationHashes[activeColor]
+	r.BlueGreenStatus.Message = blueGreenMessage(activeColor, switchColor, targetColor, replicas)
+
+	return nil
+}
+
+// blueGreenTargetColor returns the color that must run the desired
+// configuration: the color already running it, or the color not receiving the
+// traffic. The active color is never replaced.
+func blueGreenTargetColor(activeColor appsv1alpha1.BlueGreenColor, desiredHash string, configurationHashes map[appsv1alpha1.BlueGreenColor]string) appsv1alpha1.BlueGreenColor {
+	if activeColor != "" && configurationHashes[activeColor] == desiredHash {
+		return activeColor
+	}
+
+	for _, color := range blueGreenColors {
+		if color != activeColor && configurationHashes[color] == desiredHash {
+			return color
+		}
+	}
+
+	if activeColor == appsv1alpha1.BlueGreenColorBlue {
+		return appsv1alpha1.BlueGreenColorGreen
+	}
+	return appsv1alpha1.BlueGreenColorBlue
+}
+
+func blueGreenMessage(activeColor, switchColor, targetColor appsv1alpha1.BlueGreenColor, replicas map[appsv1alpha1.BlueGreenColor]int32) string {
+	if switchColor != activeColor {
+		switchReplicas, ok := replicas[switchColor]
+		if !ok {
+			return fmt.Sprintf("Traffic pinned to %s, which has never been deployed", switchColor)
+		}
+		return fmt.Sprintf("Waiting for %d %s pods to be ready", switchReplicas, switchColor)
+	}
+	if activeColor != targetColor {
+		return fmt.Sprintf("Traffic pinned to %s, the latest configuration is deployed to %s", activeColor, targetColor)
+	}
+	return fmt.Sprintf("Traffic switched to %s", activeColor)
+}
+
+// isBlueGreenDeploymentReady returns true when all the pods of the color run
+// the given configuration and are available
+func (r *APIcastLogicReconciler) isBlueGreenDeploymentReady(ctx context.Context, color appsv1alpha1.BlueGreenColor, configurationHash string, replicas int32) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	err := r.Client().Get(ctx, r.blueGreenDeploymentKey(color), deployment)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// the cache may not have seen the latest update of the deployment yet
+	if deployment.Spec.Template.Annotations[apicast.BlueGreenConfigurationHashAnnotation] != configurationHash {
+		return false, nil
+	}
+
+	return isDeploymentAvailable(deployment, replicas), nil
+}
+
+// reconcileBlueGreenTeardown keeps routing the traffic to the active color
+// until the stable deployment is available, once blue/green rollouts are disabled
+func (r *APIcastLogicReconciler) reconcileBlueGreenTeardown(ctx context.Context) error {
+	if r.BlueGreenStatus == nil || r.BlueGreenStatus.ActiveColor == "" {
+		r.BlueGreenStatus = nil
+		return nil
+	}
+
+	stable := &appsv1.Deployment{}
+	err := r.Client().Get(ctx, client.ObjectKey{Name: apicast.APIcastDeploymentName(r.APIcastCR), Namespace: r.APIcastCR.Namespace}, stable)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if isDeploymentAvailable(stable, ptr.Deref(stable.Spec.Replicas, 1)) {
+		r.BlueGreenStatus = nil
+	}
+
+	return nil
+}
+
+// deleteReplacedDeployments deletes the deployments the APIcast service no
+// longer routes traffic to
+func (r *APIcastLogicReconciler) deleteReplacedDeployments(ctx context.Context) error {
+	if r.BlueGreenStatus == nil {
+		for _, color := range blueGreenColors {
+			err := r.deleteBlueGreenDeployment(ctx, color)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if r.BlueGreenStatus.ActiveColor == "" {
+		return nil
+	}
+
+	r.CanaryStatus = nil
+	err := r.deleteCanaryDeployment(ctx)
+	if err != nil {
+		return err
+	}
+
+	stable := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apicast.APIcastDeploymentName(r.APIcastCR),
+			Namespace: r.APIcastCR.Namespace,
+		},
+	}
+	k8sutils.TagObjectToDelete(stable)
+	return r.ReconcileResource(ctx, &appsv1.Deployment{}, stable, reconcilers.CreateOnlyMutator)
+}
+
+func (r *APIcastLogicReconciler) deleteBlueGreenDeployment(ctx context.Context, color appsv1alpha1.BlueGreenColor) error {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apicast.BlueGreenDeploymentName(r.APIcastCR, string(color)),
+			Namespace: r.APIcastCR.Namespace,
+		},
+	}
+	k8sutils.TagObjectToDelete(deployment)
+	err := r.ReconcileResource(ctx, &appsv1.Deployment{}, deployment, reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return err
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apicast.BlueGreenConfigurationSecretName(r.APIcastCR, string(color)),
+			Namespace: r.APIcastCR.Namespace,
+		},
+	}
+	k8sutils.TagObjectToDelete(secret)
+	return r.ReconcileResource(ctx, &v1.Secret{}, secret, reconcilers.CreateOnlyMutator)
+}
+
+func (r *APIcastLogicReconciler) blueGreenDeploymentKey(color appsv1alpha1.BlueGreenColor) client.ObjectKey {
+	return client.ObjectKey{Name: apicast.BlueGreenDeploymentName(r.APIcastCR, string(color)), Namespace: r.APIcastCR.Namespace}
+}
+
+// servingDeploymentName returns the name of the deployment the APIcast
+// service routes traffic to
+func servingDeploymentName(cr *appsv1alpha1.APIcast, blueGreenStatus *appsv1alpha1.BlueGreenStatus) string {
+	if blueGreenStatus != nil && blueGreenStatus.ActiveColor != "" {
+		return apicast.BlueGreenDeploymentName(cr, string(blueGreenStatus.ActiveColor))
+	}
+	return apicast.APIcastDeploymentName(cr)
+}
@@ -0,0 +1,154 @@
+//go:build integration
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+	apicastpkg "github.com/3scale/apicast-operator/pkg/apicast"
+)
+
+var _ = Describe("APIcast blue/green configuration rollout", func() {
+	const (
+		retryInterval = time.Second * 5
+	)
+	var testNamespace string
+	apicastName := "example-apicast"
+
+	BeforeEach(CreateNamespaceCallback(&testNamespace))
+	AfterEach(DeleteNamespaceCallback(&testNamespace))
+
+	apicastKey := func() types.NamespacedName {
+		return types.NamespacedName{Name: apicastName, Namespace: testNamespace}
+	}
+	stableKey := func() types.NamespacedName {
+		return types.NamespacedName{Name: "apicast-" + apicastName, Namespace: testNamespace}
+	}
+	colorKey := func(color appsv1alpha1.BlueGreenColor) types.NamespacedName {
+		return types.NamespacedName{Name: "apicast-" + apicastName + "-" + string(color), Namespace: testNamespace}
+	}
+
+	// expectServingColor waits for the service and the APIcast status to
+	// route the traffic to the given color, an empty color being the stable deployment
+	expectServingColor := func(ctx context.Context, color appsv1alpha1.BlueGreenColor) {
+		Eventually(func(g Gomega) {
+			service := &v1.Service{}
+			g.Expect(testClient().Get(ctx, stableKey(), service)).To(Succeed())
+			apicast := &appsv1alpha1.APIcast{}
+			g.Expect(testClient().Get(ctx, apicastKey(), apicast)).To(Succeed())
+
+			if color == "" {
+				g.Expect(service.Spec.Selector).ToNot(HaveKey(apicastpkg.BlueGreenColorLabel))
+				g.Expect(apicast.Status.BlueGreen).To(BeNil())
+				return
+			}
+
+			g.Expect(service.Spec.Selector).To(HaveKeyWithValue(apicastpkg.BlueGreenColorLabel, string(color)))
+			g.Expect(apicast.Status.BlueGreen).ToNot(BeNil())
+			g.Expect(apicast.Status.BlueGreen.ActiveColor).To(Equal(color))
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+	}
+
+	expectDeleted := func(ctx context.Context, key types.NamespacedName) {
+		Eventually(func(g Gomega) {
+			err := testClient().Get(ctx, key, &appsv1.Deployment{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+	}
+
+	// createAPIcast creates a blue/green APIcast and waits for the traffic to
+	// be switched to the blue deployment
+	createAPIcast := func(ctx context.Context) {
+		err := testCreateAPIcastEmbeddedConfigurationSecret(ctx, testNamespace)
+		Expect(err).ToNot(HaveOccurred())
+
+		apicast := &appsv1alpha1.APIcast{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      apicastName,
+				Namespace: testNamespace,
+			},
+			Spec: appsv1alpha1.APIcastSpec{
+				EmbeddedConfigurationSecretRef: &v1.LocalObjectReference{
+					Name: testAPIcastEmbeddedConfigurationSecretName,
+				},
+				BlueGreen: &appsv1alpha1.BlueGreenSpec{},
+			},
+		}
+		Expect(testClient().Create(ctx, apicast)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(testClient().Get(ctx, colorKey(appsv1alpha1.BlueGreenColorBlue), &appsv1.Deployment{})).To(Succeed())
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+
+		UpdateDeploymentStatus(ctx, colorKey(appsv1alpha1.BlueGreenColorBlue), DeploymentAvailable(1))
+		expectServingColor(ctx, appsv1alpha1.BlueGreenColorBlue)
+	}
+
+	It("Should roll out a configuration change to the inactive color and switch the traffic", func(ctx SpecContext) {
+		createAPIcast(ctx)
+
+		blue := &appsv1.Deployment{}
+		Expect(testClient().Get(ctx, colorKey(appsv1alpha1.BlueGreenColorBlue), blue)).To(Succeed())
+		blueHash := blue.Spec.Template.Annotations[apicastpkg.BlueGreenConfigurationHashAnnotation]
+		Expect(blueHash).ToNot(BeEmpty())
+
+		// the embedded configuration changes
+		Eventually(func(g Gomega) {
+			secret := &v1.Secret{}
+			g.Expect(testClient().Get(ctx, types.NamespacedName{Name: testAPIcastEmbeddedConfigurationSecretName, Namespace: testNamespace}, secret)).To(Succeed())
+			secret.Data["config.json"] = []byte(strings.Replace(testAPIcastEmbeddedConfigurationContent(), "echo-api.3scale.net", "echo-api-v2.3scale.net", 1))
+			g.Expect(testClient().Update(ctx, secret)).To(Succeed())
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+
+		// the green deployment runs the new configuration, the traffic stays on blue
+		Eventually(func(g Gomega) {
+			green := &appsv1.Deployment{}
+			g.Expect(testClient().Get(ctx, colorKey(appsv1alpha1.BlueGreenColorGreen), green)).To(Succeed())
+			greenHash := green.Spec.Template.Annotations[apicastpkg.BlueGreenConfigurationHashAnnotation]
+			g.Expect(greenHash).ToNot(BeEmpty())
+			g.Expect(greenHash).ToNot(Equal(blueHash))
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+		expectServingColor(ctx, appsv1alpha1.BlueGreenColorBlue)
+
+		// the traffic is switched once the green pods are ready
+		UpdateDeploymentStatus(ctx, colorKey(appsv1alpha1.BlueGreenColorGreen), DeploymentAvailable(1))
+		expectServingColor(ctx, appsv1alpha1.BlueGreenColorGreen)
+
+		// blue keeps the previous configuration for rollbacks
+		Expect(testClient().Get(ctx, colorKey(appsv1alpha1.BlueGreenColorBlue), blue)).To(Succeed())
+		Expect(blue.Spec.Template.Annotations).To(HaveKeyWithValue(apicastpkg.BlueGreenConfigurationHashAnnotation, blueHash))
+	})
+
+	It("Should tear down the blue/green deployments when disabled", func(ctx SpecContext) {
+		createAPIcast(ctx)
+		expectDeleted(ctx, stableKey())
+
+		Eventually(func(g Gomega) {
+			apicast := &appsv1alpha1.APIcast{}
+			g.Expect(testClient().Get(ctx, apicastKey(), apicast)).To(Succeed())
+			apicast.Spec.BlueGreen = nil
+			g.Expect(testClient().Update(ctx, apicast)).To(Succeed())
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+
+		// the traffic stays on blue until the deployment is available
+		Eventually(func(g Gomega) {
+			g.Expect(testClient().Get(ctx, stableKey(), &appsv1.Deployment{})).To(Succeed())
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+		expectServingColor(ctx, appsv1alpha1.BlueGreenColorBlue)
+
+		UpdateDeploymentStatus(ctx, stableKey(), DeploymentAvailable(1))
+		expectServingColor(ctx, "")
+		expectDeleted(ctx, colorKey(appsv1alpha1.BlueGreenColorBlue))
+	})
+})
@@ -0,0 +1,187 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+	"github.com/3scale/apicast-operator/pkg/apicast"
+	"github.com/3scale/apicast-operator/pkg/helper"
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
+	"github.com/3scale/apicast-operator/pkg/reconcilers"
+)
+
+// canaryErrorRateCheckPeriod is how often the canary error rate is checked
+// during the analysis
+const canaryErrorRateCheckPeriod = 30 * time.Second
+
+var canaryHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// reconcileCanary runs the canary release of a new APIcast image. It returns
+// the image the stable deployment must run: the current one while the canary is
+// progressing or after it was aborted, the desired one otherwise. The returned
+// duration is the time after which the analysis must be resumed.
+func (r *APIcastLogicReconciler) reconcileCanary(ctx context.Context, apicastFactory *apicast.APIcast, desiredImage string) (string, time.Duration, error) {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if !apicastFactory.Canary().Enabled {
+		r.CanaryStatus = nil
+		return desiredImage, 0, r.deleteCanaryDeployment(ctx)
+	}
+
+	stable := &appsv1.Deployment{}
+	err = r.Client().Get(ctx, client.ObjectKey{Name: apicast.APIcastDeploymentName(r.APIcastCR), Namespace: r.APIcastCR.Namespace}, stable)
+	if err != nil && !errors.IsNotFound(err) {
+		return "", 0, err
+	}
+	if errors.IsNotFound(err) {
+		// nothing to compare with on the first deployment
+		return desiredImage, 0, r.deleteCanaryDeployment(ctx)
+	}
+
+	stableImage := stable.Spec.Template.Spec.Containers[0].Image
+	if stableImage == desiredImage {
+		if r.CanaryStatus != nil && r.CanaryStatus.Phase == appsv1alpha1.CanaryPhaseProgressing {
+			r.setCanaryPhase(appsv1alpha1.CanaryPhaseAborted, "Image change reverted")
+		}
+		return desiredImage, 0, r.deleteCanaryDeployment(ctx)
+	}
+
+	if r.CanaryStatus != nil && r.CanaryStatus.Image == desiredImage {
+		switch r.CanaryStatus.Phase {
+		case appsv1alpha1.CanaryPhaseAborted:
+			// the stable deployment keeps the current image until the image changes again
+			return stableImage, 0, r.deleteCanaryDeployment(ctx)
+		case appsv1alpha1.CanaryPhasePromoted:
+			return desiredImage, 0, r.deleteCanaryDeployment(ctx)
+		}
+	}
+
+	if r.CanaryStatus == nil || r.CanaryStatus.Image != desiredImage {
+		logger.Info("starting canary release", "image", desiredImage, "stableImage", stableImage)
+		r.CanaryStatus = &appsv1alpha1.CanaryStatus{
+			Image: desiredImage,
+			Phase: appsv1alpha1.CanaryPhaseProgressing,
+		}
+	}
+
+	stableReplicas := int32(1)
+	if stable.Spec.Replicas != nil {
+		stableReplicas = *stable.Spec.Replicas
+	}
+	replicas := apicastFactory.CanaryReplicas(stableReplicas)
+
+	desired, err := apicastFactory.CanaryDeployment(ctx, r.Client(), desiredImage, replicas)
+	if err != nil {
+		return "", 0, err
+	}
+	deploy, err := r.holdBackConfigurationRollout(ctx, desired)
+	if err != nil {
+		return "", 0, err
+	}
+	if !deploy {
+		r.CanaryStatus.Message = "Waiting for a valid embedded configuration to start the canary pods"
+		return stableImage, 0, nil
+	}
+	// canary pods are always scaled by the operator, even when the stable deployment is autoscaled
+	canaryMutators := append([]reconcilers.DeploymentMutateFn{reconcilers.DeploymentReplicasMutator}, deploymentTemplateMutators()...)
+	err = r.ReconcileResource(ctx, &appsv1.Deployment{}, desired, reconcilers.DeploymentMutator(canaryMutators...))
+	if err != nil {
+		return "", 0, err
+	}
+
+	r.CanaryStatus.Replicas = replicas
+
+	canary := &appsv1.Deployment{}
+	err = r.Client().Get(ctx, client.ObjectKeyFromObject(desired), canary)
+	if err != nil && !errors.IsNotFound(err) {
+		return "", 0, err
+	}
+	if errors.IsNotFound(err) {
+		// just created, the deployment watch triggers the next reconciliation
+		r.CanaryStatus.Message = fmt.Sprintf("Waiting for %d canary pods to be available", replicas)
+		return stableImage, 0, nil
+	}
+
+	r.CanaryStatus.AvailableReplicas = canary.Status.AvailableReplicas
+
+	if cond := k8sutils.FindProgressDeadlineExceededCondition(canary.Status.Conditions); cond != nil {
+		logger.Info("aborting canary release, deployment did not progress", "image", desiredImage)
+		r.setCanaryPhase(appsv1alpha1.CanaryPhaseAborted, fmt.Sprintf("Canary pods did not become available: %s", cond.Message))
+		return stableImage, 0, r.deleteCanaryDeployment(ctx)
+	}
+
+	if !isDeploymentAvailable(canary, replicas) {
+		r.CanaryStatus.AvailableSince = nil
+		r.CanaryStatus.Message = fmt.Sprintf("Waiting for %d canary pods to be available", replicas)
+		return stableImage, 0, nil
+	}
+
+	if r.CanaryStatus.AvailableSince == nil {
+		now := metav1.NewTime(time.Now().Truncate(time.Second))
+		r.CanaryStatus.AvailableSince = &now
+	}
+
+	analysisDeadline := r.CanaryStatus.AvailableSince.Add(time.Duration(apicastFactory.Canary().AnalysisSeconds) * time.Second)
+	r.CanaryStatus.Message = fmt.Sprintf("Canary pods available, promotion at %s", analysisDeadline.UTC().Format(time.RFC3339))
+
+	requeueAfter := time.Until(analysisDeadline)
+	if errorRate := apicastFactory.Canary().ErrorRate; errorRate != nil {
+		percentage, found, err := helper.PrometheusInstantQuery(ctx, canaryHTTPClient, errorRate.PrometheusURL, apicastFactory.CanaryErrorRateQuery())
+		if err != nil {
+			// the canary is neither promoted nor aborted while its error rate is unknown
+			logger.Info("canary error rate check failed", "error", err.Error())
+			r.CanaryStatus.Message = fmt.Sprintf("Canary error rate check failed: %v", err)
+			return stableImage, canaryErrorRateCheckPeriod, nil
+		}
+
+		if found && percentage > float64(errorRate.MaxPercentage) {
+			logger.Info("aborting canary release, error rate too high", "image", desiredImage, "percentage", percentage)
+			r.setCanaryPhase(appsv1alpha1.CanaryPhaseAborted, fmt.Sprintf("Canary 5xx responses ratio %.2f%% above the %d%% threshold", percentage, errorRate.MaxPercentage))
+			return stableImage, 0, r.deleteCanaryDeployment(ctx)
+		}
+
+		if requeueAfter > canaryErrorRateCheckPeriod {
+			requeueAfter = canaryErrorRateCheckPeriod
+		}
+	}
+
+	if requeueAfter > 0 {
+		return stableImage, requeueAfter, nil
+	}
+
+	logger.Info("promoting canary release", "image", desiredImage)
+	r.setCanaryPhase(appsv1alpha1.CanaryPhasePromoted, fmt.Sprintf("Canary promoted, replacing %s", stableImage))
+	return desiredImage, 0, r.deleteCanaryDeployment(ctx)
+}
+
+// setCanaryPhase ends the canary release
+func (r *APIcastLogicReconciler) setCanaryPhase(phase appsv1alpha1.CanaryPhase, message string) {
+	r.CanaryStatus.Phase = phase
+	r.CanaryStatus.Message = message
+	r.CanaryStatus.Replicas = 0
+	r.CanaryStatus.AvailableReplicas = 0
+	r.CanaryStatus.AvailableSince = nil
+}
+
+func (r *APIcastLogicReconciler) deleteCanaryDeployment(ctx context.Context) error {
+	canary := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apicast.CanaryDeploymentName(r.APIcastCR),
+			Namespace: r.APIcastCR.Namespace,
+		},
+	}
+	k8sutils.TagObjectToDelete(canary)
+	return r.ReconcileResource(ctx, &appsv1.Deployment{}, canary, reconcilers.CreateOnlyMutator)
+}
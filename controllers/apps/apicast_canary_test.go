@@ -0,0 +1,184 @@
+//go:build integration
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+	apicastpkg "github.com/3scale/apicast-operator/pkg/apicast"
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
+)
+
+var _ = Describe("APIcast canary release", func() {
+	const (
+		retryInterval = time.Second * 5
+		stableImage   = "quay.io/3scale/apicast:stable"
+		canaryImage   = "quay.io/3scale/apicast:canary"
+	)
+	var testNamespace string
+	apicastName := "example-apicast"
+
+	BeforeEach(CreateNamespaceCallback(&testNamespace))
+	AfterEach(DeleteNamespaceCallback(&testNamespace))
+
+	apicastKey := func() types.NamespacedName {
+		return types.NamespacedName{Name: apicastName, Namespace: testNamespace}
+	}
+	stableKey := func() types.NamespacedName {
+		return types.NamespacedName{Name: "apicast-" + apicastName, Namespace: testNamespace}
+	}
+	canaryKey := func() types.NamespacedName {
+		return types.NamespacedName{Name: "apicast-" + apicastName + "-canary", Namespace: testNamespace}
+	}
+
+	// createAPIcast creates the APIcast running the stable image and waits for its deployment
+	createAPIcast := func(ctx context.Context, canary *appsv1alpha1.CanarySpec) {
+		err := testCreateAPIcastEmbeddedConfigurationSecret(ctx, testNamespace)
+		Expect(err).ToNot(HaveOccurred())
+
+		apicast := &appsv1alpha1.APIcast{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      apicastName,
+				Namespace: testNamespace,
+			},
+			Spec: appsv1alpha1.APIcastSpec{
+				Image: ptr.To(stableImage),
+				EmbeddedConfigurationSecretRef: &v1.LocalObjectReference{
+					Name: testAPIcastEmbeddedConfigurationSecretName,
+				},
+				Canary: canary,
+			},
+		}
+		Expect(testClient().Create(ctx, apicast)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			deployment := &appsv1.Deployment{}
+			g.Expect(testClient().Get(ctx, stableKey(), deployment)).To(Succeed())
+			g.Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal(stableImage))
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+	}
+
+	setImage := func(ctx context.Context, image string) {
+		Eventually(func(g Gomega) {
+			apicast := &appsv1alpha1.APIcast{}
+			g.Expect(testClient().Get(ctx, apicastKey(), apicast)).To(Succeed())
+			apicast.Spec.Image = ptr.To(image)
+			g.Expect(testClient().Update(ctx, apicast)).To(Succeed())
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+	}
+
+	// waitForCanary waits for the canary deployment running the canary image
+	// next to the stable deployment still running the stable image
+	waitForCanary := func(ctx context.Context) {
+		Eventually(func(g Gomega) {
+			canary := &appsv1.Deployment{}
+			g.Expect(testClient().Get(ctx, canaryKey(), canary)).To(Succeed())
+			g.Expect(canary.Spec.Template.Spec.Containers[0].Image).To(Equal(canaryImage))
+			g.Expect(canary.Spec.Selector.MatchLabels).To(HaveKeyWithValue(apicastpkg.TrackLabel, apicastpkg.CanaryTrackLabelValue))
+
+			stable := &appsv1.Deployment{}
+			g.Expect(testClient().Get(ctx, stableKey(), stable)).To(Succeed())
+			g.Expect(stable.Spec.Template.Spec.Containers[0].Image).To(Equal(stableImage))
+			g.Expect(stable.Spec.Selector.MatchLabels).To(HaveKeyWithValue(apicastpkg.TrackLabel, apicastpkg.StableTrackLabelValue))
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+	}
+
+	// expectCanaryEnded waits for the canary release phase, the canary
+	// deployment to be deleted and the stable deployment to run the given image
+	expectCanaryEnded := func(ctx context.Context, phase appsv1alpha1.CanaryPhase, image string) {
+		Eventually(func(g Gomega) {
+			apicast := &appsv1alpha1.APIcast{}
+			g.Expect(testClient().Get(ctx, apicastKey(), apicast)).To(Succeed())
+			g.Expect(apicast.Status.Canary).ToNot(BeNil())
+			g.Expect(apicast.Status.Canary.Image).To(Equal(canaryImage))
+			g.Expect(apicast.Status.Canary.Phase).To(Equal(phase))
+
+			err := testClient().Get(ctx, canaryKey(), &appsv1.Deployment{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			stable := &appsv1.Deployment{}
+			g.Expect(testClient().Get(ctx, stableKey(), stable)).To(Succeed())
+			g.Expect(stable.Spec.Template.Spec.Containers[0].Image).To(Equal(image))
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+	}
+
+	It("Should promote the canary image once the canary pods are available", func(ctx SpecContext) {
+		createAPIcast(ctx, &appsv1alpha1.CanarySpec{AnalysisSeconds: ptr.To(int32(0))})
+
+		setImage(ctx, canaryImage)
+		waitForCanary(ctx)
+
+		UpdateDeploymentStatus(ctx, canaryKey(), DeploymentAvailable(1))
+		expectCanaryEnded(ctx, appsv1alpha1.CanaryPhasePromoted, canaryImage)
+	})
+
+	It("Should abort the canary release when the error rate is too high", func(ctx SpecContext) {
+		prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[%d,"50"]}]}}`, time.Now().Unix())
+		}))
+		defer prometheus.Close()
+
+		createAPIcast(ctx, &appsv1alpha1.CanarySpec{
+			AnalysisSeconds: ptr.To(int32(600)),
+			ErrorRate:       &appsv1alpha1.CanaryErrorRateSpec{PrometheusURL: prometheus.URL, MaxPercentage: ptr.To(int32(5))},
+		})
+
+		setImage(ctx, canaryImage)
+		waitForCanary(ctx)
+
+		UpdateDeploymentStatus(ctx, canaryKey(), DeploymentAvailable(1))
+		expectCanaryEnded(ctx, appsv1alpha1.CanaryPhaseAborted, stableImage)
+	})
+
+	It("Should abort the canary release when the image change is reverted", func(ctx SpecContext) {
+		createAPIcast(ctx, &appsv1alpha1.CanarySpec{AnalysisSeconds: ptr.To(int32(600))})
+
+		setImage(ctx, canaryImage)
+		waitForCanary(ctx)
+
+		setImage(ctx, stableImage)
+		expectCanaryEnded(ctx, appsv1alpha1.CanaryPhaseAborted, stableImage)
+
+		apicast := &appsv1alpha1.APIcast{}
+		Expect(testClient().Get(ctx, apicastKey(), apicast)).To(Succeed())
+		Expect(apicast.Status.Canary.Message).To(Equal("Image change reverted"))
+	})
+
+	It("Should abort the canary release when the canary deployment does not progress", func(ctx SpecContext) {
+		createAPIcast(ctx, &appsv1alpha1.CanarySpec{AnalysisSeconds: ptr.To(int32(600))})
+
+		setImage(ctx, canaryImage)
+		waitForCanary(ctx)
+
+		UpdateDeploymentStatus(ctx, canaryKey(), func(status *appsv1.DeploymentStatus) {
+			status.Conditions = []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  v1.ConditionFalse,
+				Reason:  k8sutils.DeploymentProgressDeadlineExceededReason,
+				Message: "ReplicaSet has timed out progressing.",
+			}}
+		})
+		expectCanaryEnded(ctx, appsv1alpha1.CanaryPhaseAborted, stableImage)
+
+		// the aborted image is not retried until the image changes again
+		Consistently(func(g Gomega) {
+			err := testClient().Get(ctx, canaryKey(), &appsv1.Deployment{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}, 15*time.Second, retryInterval).Should(Succeed())
+	})
+})
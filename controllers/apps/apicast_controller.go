@@ -21,19 +21,27 @@ import (
 	"encoding/json"
 
 	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+	"github.com/3scale/apicast-operator/pkg/apicast"
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
 	"github.com/3scale/apicast-operator/pkg/reconcilers"
 	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	hpa "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimachinerymetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 // APIcastReconciler reconciles a APIcast object
@@ -41,7 +49,25 @@ type APIcastReconciler struct {
 	reconcilers.BaseControllerReconciler
 	Log                 logr.Logger
 	SecretLabelSelector apimachinerymetav1.LabelSelector
-	WatchedNamespace    string
+	WatchedNamespaces   k8sutils.WatchedNamespaces
+	// RouteAPIAvailable is true when the cluster serves the OpenShift Route API
+	RouteAPIAvailable bool
+	// HTTPRouteAPIAvailable is true when the cluster serves the Gateway API HTTPRoute v1 API
+	HTTPRouteAPIAvailable bool
+	// TLSRouteAPIAvailable is true when the cluster serves the Gateway API TLSRoute v1alpha2 API
+	TLSRouteAPIAvailable bool
+	// ServiceMonitorAPIAvailable is true when the cluster serves the Prometheus Operator ServiceMonitor API
+	ServiceMonitorAPIAvailable bool
+	// PodMonitorAPIAvailable is true when the cluster serves the Prometheus Operator PodMonitor API
+	PodMonitorAPIAvailable bool
+	// PrometheusRuleAPIAvailable is true when the cluster serves the Prometheus Operator PrometheusRule API
+	PrometheusRuleAPIAvailable bool
+	// GrafanaDashboardAPIAvailable is true when the cluster serves the Grafana Operator GrafanaDashboard API
+	GrafanaDashboardAPIAvailable bool
+	// OperatorConfig are the organization-wide defaults of the APIcast resources
+	OperatorConfig *apicast.OperatorConfig
+	// ImageResolver resolves the image tags of the APIcast resources pinning the image digest
+	ImageResolver apicast.ImageResolver
 }
 
 // blank assignment to verify that ReconcileAPIcast implements reconcile.Reconciler
@@ -53,13 +79,15 @@ var _ reconcile.Reconciler = &APIcastReconciler{}
 // +kubebuilder:rbac:groups=policy,namespace=placeholder,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,namespace=placeholder,resources=pods;services;services/finalizers;endpoints;persistentvolumeclaims;events;configmaps;secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,namespace=placeholder,resources=deployments;daemonsets;replicasets;statefulsets,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=monitoring.coreos.com,namespace=placeholder,resources=servicemonitors,verbs=get;create
+// +kubebuilder:rbac:groups=monitoring.coreos.com,namespace=placeholder,resources=servicemonitors;podmonitors;prometheusrules,verbs=get;list;watch;create;update;patch;delete
 // TODO the permission to update deployments/finalizer originally was limited
 // to the 'apicast-operator' resource name. It seems it is not possible anymore
 // with kubebuilder markers???
 // +kubebuilder:rbac:groups=apps,namespace=placeholder,resources=deployments/finalizers,verbs=update
 // +kubebuilder:rbac:groups=networking.k8s.io,namespace=placeholder,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=route.openshift.io,namespace=placeholder,resources=routes/custom-host,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,namespace=placeholder,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=grafana.integreatly.org,namespace=placeholder,resources=grafanadashboards,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,namespace=placeholder,resources=httproutes;tlsroutes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=autoscaling,namespace=placeholder,resources=horizontalpodautoscalers,verbs=create;update;delete;get;list;watch
 
 func (r *APIcastReconciler) Reconcile(eventCtx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -88,15 +116,24 @@ func (r *APIcastReconciler) Reconcile(eventCtx context.Context, req ctrl.Request
 		log.V(1).Info(string(jsonData))
 	}
 
-	baseReconciler := reconcilers.NewBaseReconciler(r.Client(), r.APIClientReader(), r.Scheme(), log)
+	baseReconciler := reconcilers.NewBaseReconciler(r.Client(), r.APIClientReader(), r.Scheme(), log, r.EventRecorder(), instance)
 	logicReconciler := NewAPIcastLogicReconciler(baseReconciler, instance)
+	logicReconciler.RouteAPIAvailable = r.RouteAPIAvailable
+	logicReconciler.HTTPRouteAPIAvailable = r.HTTPRouteAPIAvailable
+	logicReconciler.TLSRouteAPIAvailable = r.TLSRouteAPIAvailable
+	logicReconciler.ServiceMonitorAPIAvailable = r.ServiceMonitorAPIAvailable
+	logicReconciler.PodMonitorAPIAvailable = r.PodMonitorAPIAvailable
+	logicReconciler.PrometheusRuleAPIAvailable = r.PrometheusRuleAPIAvailable
+	logicReconciler.GrafanaDashboardAPIAvailable = r.GrafanaDashboardAPIAvailable
+	logicReconciler.OperatorConfig = r.OperatorConfig
+	logicReconciler.ImageResolver = r.ImageResolver
 	specResult, specErr := logicReconciler.Reconcile(ctx)
 	if specErr == nil && specResult.Requeue {
 		log.V(1).Info("Reconciling spec not finished. Requeueing.")
 		return specResult, nil
 	}
 
-	statusResult, statusErr := r.reconcileStatus(ctx, instance, specErr)
+	statusResult, statusErr := r.reconcileStatus(ctx, instance, &logicReconciler, specErr)
 
 	if specErr != nil {
 		// Ignore conflicts, resource might just be outdated.
@@ -118,15 +155,16 @@ func (r *APIcastReconciler) Reconcile(eventCtx context.Context, req ctrl.Request
 	}
 
 	log.Info("Successfully reconciled")
-	return ctrl.Result{}, nil
+	// time based steps, like the canary analysis, are resumed later
+	return ctrl.Result{RequeueAfter: specResult.RequeueAfter}, nil
 }
 
 func (r *APIcastReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	secretToApicastEventMapper := &SecretToApicastEventMapper{
-		Context:   context.TODO(),
-		K8sClient: r.Client(),
-		Logger:    r.Log.WithName("secretToApicastEventMapper"),
-		Namespace: r.WatchedNamespace,
+		Context:    context.TODO(),
+		K8sClient:  r.Client(),
+		Logger:     r.Log.WithName("secretToApicastEventMapper"),
+		Namespaces: r.WatchedNamespaces,
 	}
 
 	// LabelSelectorPredicate only applies to the new object in update events
@@ -139,7 +177,7 @@ func (r *APIcastReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return nil
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&appsv1alpha1.APIcast{}).
 		Watches(
 			&corev1.Secret{},
@@ -150,5 +188,39 @@ func (r *APIcastReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&corev1.Service{}).
 		Owns(&networkingv1.Ingress{}).
-		Complete(r)
+		Owns(&corev1.ConfigMap{}).
+		// HPA status is updated on every sync period, only spec changes are relevant
+		Owns(&hpa.HorizontalPodAutoscaler{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+
+	if r.RouteAPIAvailable {
+		controllerBuilder = controllerBuilder.Owns(&routev1.Route{})
+	}
+
+	if r.HTTPRouteAPIAvailable {
+		controllerBuilder = controllerBuilder.Owns(&gatewayapiv1.HTTPRoute{})
+	}
+
+	if r.TLSRouteAPIAvailable {
+		controllerBuilder = controllerBuilder.Owns(&gatewayapiv1alpha2.TLSRoute{})
+	}
+
+	if r.ServiceMonitorAPIAvailable {
+		controllerBuilder = controllerBuilder.Owns(&monitoringv1.ServiceMonitor{})
+	}
+
+	if r.PodMonitorAPIAvailable {
+		controllerBuilder = controllerBuilder.Owns(&monitoringv1.PodMonitor{})
+	}
+
+	if r.PrometheusRuleAPIAvailable {
+		controllerBuilder = controllerBuilder.Owns(&monitoringv1.PrometheusRule{})
+	}
+
+	if r.GrafanaDashboardAPIAvailable {
+		grafanaDashboard := &unstructured.Unstructured{}
+		grafanaDashboard.SetGroupVersionKind(apicast.GrafanaDashboardGVK)
+		controllerBuilder = controllerBuilder.Owns(grafanaDashboard)
+	}
+
+	return controllerBuilder.Complete(r)
 }
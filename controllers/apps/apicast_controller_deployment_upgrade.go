@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"reflect"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -23,6 +24,7 @@ func (r *APIcastLogicReconciler) upgradeDeploymentSelector(ctx context.Context,
 	// Some previously released apicast operator (v0.6.0) added labels with release version in the
 	// deployment selector, which happens to be immutable
 	// https://kubernetes.io/docs/concepts/workloads/controllers/deployment/#label-selector-updates
+	// Later releases added the stable track label, so the deployment does not select the canary pods.
 	//
 	// This upgrading specific procedure changes the inmutable deployment selector.
 	// Since the deployment selector is immutable, the deployment needs to be deleted first.
@@ -41,6 +43,11 @@ func (r *APIcastLogicReconciler) upgradeDeploymentSelector(ctx context.Context,
 		return ctrl.Result{}, err
 	}
 
+	if apicastFactory.BlueGreen().Enabled {
+		// blue/green deployments replace the deployment, which is torn down
+		return ctrl.Result{}, nil
+	}
+
 	expectedDeployment, err := apicastFactory.Deployment(ctx, r.Client())
 	if err != nil {
 		return ctrl.Result{}, err
@@ -68,6 +75,7 @@ func (r *APIcastLogicReconciler) upgradeDeploymentSelector(ctx context.Context,
 			// temp deployment exists, workload was not interrupted, create upgraded deployment
 			err = r.Client().Create(ctx, expectedDeployment)
 			logger.Info("Upgrade deployment: creating new deployment", "key", client.ObjectKeyFromObject(expectedDeployment), "error", err)
+			r.recordUpgradeStep("creating new deployment", err)
 			return ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}, err
 		}
 	} else {
@@ -78,7 +86,7 @@ func (r *APIcastLogicReconciler) upgradeDeploymentSelector(ctx context.Context,
 			return ctrl.Result{}, nil
 		}
 
-		if _, ok := existingDeployment.Spec.Selector.MatchLabels["rht.comp_ver"]; !ok {
+		if reflect.DeepEqual(existingDeployment.Spec.Selector.MatchLabels, expectedDeployment.Spec.Selector.MatchLabels) {
 			return r.upgradedDeploymentWorkflow(ctx, existingDeployment)
 		}
 
@@ -115,8 +123,9 @@ func (r *APIcastLogicReconciler) upgradedDeploymentWorkflow(ctx context.Context,
 		return ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
 	}
 
-	_ = r.Client().Delete(ctx, tempExistingDeployment)
+	err = r.Client().Delete(ctx, tempExistingDeployment)
 	logger.Info("Upgrade deployment: delete temp deployment")
+	r.recordUpgradeStep("deleting tmp deployment", err)
 	return ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
 }
 
@@ -148,6 +157,7 @@ func (r *APIcastLogicReconciler) reconcileUpgradeService(ctx context.Context, ap
 		}
 		err = r.Client().Update(ctx, existingService)
 		logger.Info("Upgrade deployment: updating service", "key", client.ObjectKeyFromObject(existingService), "error", err)
+		r.recordUpgradeStep("updating service selector", err)
 		return err
 	}
 
@@ -177,6 +187,7 @@ func (r *APIcastLogicReconciler) notUpgradedDeploymentWorkflow(ctx context.Conte
 			tempDesiredDeployment := newTempDeployment(existingDeployment, r.APIcastCR)
 			err = r.Client().Create(ctx, tempDesiredDeployment)
 			logger.Info("Upgrade deployment: creating tmp deployment", "key", client.ObjectKeyFromObject(tempDesiredDeployment), "error", err)
+			r.recordUpgradeStep("creating tmp deployment", err)
 			return ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}, err
 		}
 		return ctrl.Result{}, err
@@ -192,6 +203,7 @@ func (r *APIcastLogicReconciler) notUpgradedDeploymentWorkflow(ctx context.Conte
 	// if error is returned, it needs to be raised. Deletion must succeed to accomplish the upgrade
 	err = r.Client().Delete(ctx, existingDeployment)
 	logger.Info("Upgrade deployment: delete old deployment", "key", client.ObjectKeyFromObject(existingDeployment), "error", err)
+	r.recordUpgradeStep("deleting old deployment", err)
 	return ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}, err
 }
 
@@ -57,7 +57,7 @@ var _ = Describe("APIcast v0.6.0 deployment upgrade procedure", func() {
 				},
 			}
 
-			apicastFactory, err := apicast.Factory(context.TODO(), apicastCR, testClient())
+			apicastFactory, err := apicast.Factory(context.TODO(), apicastCR, testClient(), nil, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			// v0.6.0 deployment selector
@@ -5,11 +5,14 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -19,9 +22,11 @@ import (
 	"github.com/3scale/apicast-operator/pkg/k8sutils"
 )
 
-func (r *APIcastReconciler) reconcileStatus(ctx context.Context, cr *appsv1alpha1.APIcast, specErr error) (ctrl.Result, error) {
+// reconcileStatus updates the APIcast status with the state computed by the
+// logic reconciler
+func (r *APIcastReconciler) reconcileStatus(ctx context.Context, cr *appsv1alpha1.APIcast, logicReconciler *APIcastLogicReconciler, specErr error) (ctrl.Result, error) {
 	logger, _ := logr.FromContext(ctx)
-	newStatus, err := r.calculateStatus(ctx, cr, specErr)
+	newStatus, err := r.calculateStatus(ctx, cr, logicReconciler, specErr)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -57,14 +62,20 @@ func (r *APIcastReconciler) reconcileStatus(ctx context.Context, cr *appsv1alpha
 	return ctrl.Result{}, nil
 }
 
-func (r *APIcastReconciler) calculateStatus(ctx context.Context, cr *appsv1alpha1.APIcast, specErr error) (*appsv1alpha1.APIcastStatus, error) {
+func (r *APIcastReconciler) calculateStatus(ctx context.Context, cr *appsv1alpha1.APIcast, logicReconciler *APIcastLogicReconciler, specErr error) (*appsv1alpha1.APIcastStatus, error) {
 	newStatus := &appsv1alpha1.APIcastStatus{
 		// Copy initial conditions. Otherwise, status will always be updated
 		Conditions:         k8sutils.CopyConditions(cr.Status.Conditions),
 		ObservedGeneration: cr.Status.ObservedGeneration,
+		Canary:             logicReconciler.CanaryStatus,
+		BlueGreen:          logicReconciler.BlueGreenStatus,
+		ResolvedImage:      logicReconciler.ResolvedImage,
+		Upgrade:            logicReconciler.UpgradeStatus,
 	}
 
-	availableCond, err := r.readyCondition(ctx, cr, specErr)
+	deploymentName := servingDeploymentName(cr, logicReconciler.BlueGreenStatus)
+
+	availableCond, err := r.readyCondition(ctx, cr, deploymentName, specErr)
 	if err != nil {
 		return nil, err
 	}
@@ -73,28 +84,83 @@ func (r *APIcastReconciler) calculateStatus(ctx context.Context, cr *appsv1alpha
 
 	r.reconcileHpaWarningMessage(&newStatus.Conditions, cr)
 
-	image, err := r.deploymentImage(ctx, cr)
+	reconcileComputedCondition(&newStatus.Conditions, appsv1alpha1.ConfigurationValidConditionType,
+		cr.Spec.EmbeddedConfigurationSecretRef != nil, logicReconciler.ConfigurationValidCondition)
+
+	reconcileComputedCondition(&newStatus.Conditions, appsv1alpha1.AdminPortalReachableConditionType,
+		cr.Spec.AdminPortalCheck != nil, logicReconciler.AdminPortalReachableCondition)
+
+	err = r.reconcileDeploymentStatus(ctx, newStatus, cr, deploymentName)
 	if err != nil {
 		return nil, err
 	}
-	newStatus.Image = image
+
+	urls, err := r.exposedURLs(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	newStatus.URLs = urls
+
+	newStatus.ConfigurationLoadMode = apicast.EffectiveConfigurationLoadMode(cr)
+	if len(logicReconciler.SecretHashes) > 0 {
+		newStatus.SecretHashes = logicReconciler.SecretHashes
+	}
+	newStatus.LastConfigurationRolloutTime = logicReconciler.LastConfigurationRolloutTime
+	newStatus.LastAdminPortalCheckTime = logicReconciler.LastAdminPortalCheckTime
 
 	return newStatus, nil
 }
 
-func (r *APIcastReconciler) deploymentImage(ctx context.Context, cr *appsv1alpha1.APIcast) (string, error) {
-	dKey := client.ObjectKey{Name: apicast.APIcastDeploymentName(cr), Namespace: cr.Namespace}
+// reconcileDeploymentStatus reports the image and the replicas of the
+// deployment the APIcast service routes traffic to
+func (r *APIcastReconciler) reconcileDeploymentStatus(ctx context.Context, status *appsv1alpha1.APIcastStatus, cr *appsv1alpha1.APIcast, deploymentName string) error {
+	dKey := client.ObjectKey{Name: deploymentName, Namespace: cr.Namespace}
 	deployment := &appsv1.Deployment{}
 	err := r.Client().Get(ctx, dKey, deployment)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			return "", nil
+			return nil
 		}
 
-		return "", err
+		return err
+	}
+
+	status.Image = deployment.Spec.Template.Spec.Containers[0].Image
+	status.Replicas = ptr.Deref(deployment.Spec.Replicas, 1)
+	status.ReadyReplicas = deployment.Status.ReadyReplicas
+	status.UpdatedReplicas = deployment.Status.UpdatedReplicas
+
+	return nil
+}
+
+// exposedURLs returns the URLs of the Ingress and the Route exposing APIcast
+func (r *APIcastReconciler) exposedURLs(ctx context.Context, cr *appsv1alpha1.APIcast) ([]string, error) {
+	key := client.ObjectKey{Name: apicast.APIcastDeploymentName(cr), Namespace: cr.Namespace}
+
+	var urls []string
+	ingress := &networkingv1.Ingress{}
+	err := r.Client().Get(ctx, key, ingress)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		urls = append(urls, apicast.IngressURLs(ingress)...)
+	}
+
+	if !r.RouteAPIAvailable {
+		return urls, nil
 	}
 
-	return deployment.Spec.Template.Spec.Containers[0].Image, nil
+	route := &routev1.Route{}
+	err = r.Client().Get(ctx, key, route)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		urls = append(urls, apicast.RouteURLs(route)...)
+	}
+
+	return urls, nil
 }
 
 func (r *APIcastReconciler) reconcileHpaWarningMessage(conditions *[]metav1.Condition, cr *appsv1alpha1.APIcast) {
@@ -109,17 +175,31 @@ func (r *APIcastReconciler) reconcileHpaWarningMessage(conditions *[]metav1.Cond
 	foundCondition := meta.FindStatusCondition(*conditions, "Warning")
 
 	// If hpa is enabled but the condition is not found, add it
-	if cr.Spec.Hpa && foundCondition == nil {
+	if cr.IsHPAEnabled() && foundCondition == nil {
 		meta.SetStatusCondition(conditions, *cond)
 	}
 
 	// if hpa is disabled and condition is found, remove it
-	if !cr.Spec.Hpa && foundCondition != nil {
+	if !cr.IsHPAEnabled() && foundCondition != nil {
 		meta.RemoveStatusCondition(conditions, "Warning")
 	}
 }
 
-func (r *APIcastReconciler) readyCondition(ctx context.Context, cr *appsv1alpha1.APIcast, specErr error) (*metav1.Condition, error) {
+// reconcileComputedCondition sets a condition computed by the spec
+// reconciliation. The last result is kept when the reconciliation failed
+// before computing it, and the condition is removed when it does not apply.
+func reconcileComputedCondition(conditions *[]metav1.Condition, conditionType string, applies bool, cond *metav1.Condition) {
+	if !applies {
+		meta.RemoveStatusCondition(conditions, conditionType)
+		return
+	}
+
+	if cond != nil {
+		meta.SetStatusCondition(conditions, *cond)
+	}
+}
+
+func (r *APIcastReconciler) readyCondition(ctx context.Context, cr *appsv1alpha1.APIcast, deploymentName string, specErr error) (*metav1.Condition, error) {
 	cond := &metav1.Condition{
 		Type:    appsv1alpha1.ReadyConditionType,
 		Status:  metav1.ConditionTrue,
@@ -134,42 +214,49 @@ func (r *APIcastReconciler) readyCondition(ctx context.Context, cr *appsv1alpha1
 		return cond, nil
 	}
 
-	reason, err := r.checkDeploymentAvailable(ctx, cr)
+	reason, message, err := r.checkDeploymentAvailable(ctx, cr, deploymentName)
 	if err != nil {
 		return nil, err
 	}
-	if reason != nil {
+	if message != nil {
 		cond.Status = metav1.ConditionFalse
-		cond.Reason = "DeploymentNotReady"
-		cond.Message = *reason
+		cond.Reason = reason
+		cond.Message = *message
 		return cond, nil
 	}
 
 	return cond, nil
 }
 
-func (r *APIcastReconciler) checkDeploymentAvailable(ctx context.Context, cr *appsv1alpha1.APIcast) (*string, error) {
-	dKey := client.ObjectKey{Name: apicast.APIcastDeploymentName(cr), Namespace: cr.Namespace}
+// checkDeploymentAvailable returns the reason and the message why the APIcast
+// deployment is not available. The message is nil when the deployment is available.
+func (r *APIcastReconciler) checkDeploymentAvailable(ctx context.Context, cr *appsv1alpha1.APIcast, deploymentName string) (string, *string, error) {
+	dKey := client.ObjectKey{Name: deploymentName, Namespace: cr.Namespace}
 	deployment := &appsv1.Deployment{}
 	err := r.Client().Get(ctx, dKey, deployment)
 	if err != nil && !errors.IsNotFound(err) {
-		return nil, err
+		return "", nil, err
 	}
 
 	if err != nil && errors.IsNotFound(err) {
 		tmp := err.Error()
-		return &tmp, nil
+		return "DeploymentNotReady", &tmp, nil
+	}
+
+	// a stuck rollout is reported even when the previous pods are still available
+	if progressCondition := k8sutils.FindProgressDeadlineExceededCondition(deployment.Status.Conditions); progressCondition != nil {
+		return k8sutils.DeploymentProgressDeadlineExceededReason, &progressCondition.Message, nil
 	}
 
 	availableCondition := k8sutils.FindDeploymentStatusCondition(deployment.Status.Conditions, appsv1.DeploymentAvailable)
 	if availableCondition == nil {
 		tmp := "Available condition not found"
-		return &tmp, nil
+		return "DeploymentNotReady", &tmp, nil
 	}
 
 	if availableCondition.Status != corev1.ConditionTrue {
-		return &availableCondition.Message, nil
+		return "DeploymentNotReady", &availableCondition.Message, nil
 	}
 
-	return nil, nil
+	return "", nil, nil
 }
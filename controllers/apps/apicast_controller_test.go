@@ -621,6 +621,66 @@ var _ = Describe("APIcast controller", func() {
 			}, 5*time.Minute, retryInterval).Should(Succeed())
 		})
 	})
+
+	Context("Run APIcast with the image set in the spec", func() {
+		const customImage = "quay.io/3scale/apicast:custom"
+
+		getAPIcast := func(g Gomega, ctx context.Context) *appsv1alpha1.APIcast {
+			apicast := &appsv1alpha1.APIcast{}
+			g.Expect(testClient().Get(ctx, types.NamespacedName{Name: apicastName, Namespace: testNamespace}, apicast)).To(Succeed())
+			return apicast
+		}
+
+		setImage := func(ctx context.Context, image *string) {
+			Eventually(func(g Gomega) {
+				apicast := getAPIcast(g, ctx)
+				apicast.Spec.Image = image
+				g.Expect(testClient().Update(ctx, apicast)).To(Succeed())
+			}, 5*time.Minute, retryInterval).Should(Succeed())
+		}
+
+		It("Should clear the upgrade status while the image is set", func(ctx SpecContext) {
+			err := testCreateAPIcastEmbeddedConfigurationSecret(ctx, testNamespace)
+			Expect(err).ToNot(HaveOccurred())
+
+			apicast := &appsv1alpha1.APIcast{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      apicastName,
+					Namespace: testNamespace,
+				},
+				Spec: appsv1alpha1.APIcastSpec{
+					EmbeddedConfigurationSecretRef: &v1.LocalObjectReference{
+						Name: testAPIcastEmbeddedConfigurationSecretName,
+					},
+				},
+			}
+			Expect(testClient().Create(ctx, apicast)).To(Succeed())
+
+			// the default image is tracked by the upgrade status
+			Eventually(func(g Gomega) {
+				apicast := getAPIcast(g, ctx)
+				g.Expect(apicast.Status.Upgrade).ToNot(BeNil())
+				g.Expect(apicast.Status.Upgrade.Image).To(Equal(apicastpkg.GetDefaultImageVersion()))
+			}, 5*time.Minute, retryInterval).Should(Succeed())
+
+			setImage(ctx, ptr.To(customImage))
+			Eventually(func(g Gomega) {
+				apicast := getAPIcast(g, ctx)
+				g.Expect(apicast.Status.Image).To(Equal(customImage))
+				g.Expect(apicast.Status.Upgrade).To(BeNil())
+			}, 5*time.Minute, retryInterval).Should(Succeed())
+
+			// back to the default image, the upgrade from the custom image goes
+			// through the upgrade policy
+			setImage(ctx, nil)
+			Eventually(func(g Gomega) {
+				apicast := getAPIcast(g, ctx)
+				g.Expect(apicast.Status.Upgrade).ToNot(BeNil())
+				g.Expect(apicast.Status.Upgrade.Image).To(Equal(customImage))
+				g.Expect(apicast.Status.Upgrade.PendingImage).To(Equal(apicastpkg.GetDefaultImageVersion()))
+			}, 5*time.Minute, retryInterval).Should(Succeed())
+		})
+	})
 })
 
 func testAPIcastEmbeddedConfigurationContent() string {
@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/3scale/apicast-operator/pkg/apicast"
+)
+
+// Reasons of the events recorded on the APIcast. The events of the created,
+// updated and deleted resources use the reasons of the reconcilers package.
+const (
+	EventReasonInvalidSpec             = "InvalidSpec"
+	EventReasonInvalidConfiguration    = "InvalidConfiguration"
+	EventReasonConfigurationWarning    = "ConfigurationWarning"
+	EventReasonConfigurationRollout    = "ConfigurationRollout"
+	EventReasonTrafficSwitched         = "TrafficSwitched"
+	EventReasonDeploymentUpgrade       = "DeploymentUpgrade"
+	EventReasonDeploymentUpgradeFailed = "DeploymentUpgradeFailed"
+	EventReasonImageResolved           = "ImageResolved"
+	EventReasonUpgradePending          = "UpgradePending"
+	EventReasonImageUpgraded           = "ImageUpgraded"
+)
+
+// recordUpgradeStep records a step of the deployment selector upgrade
+func (r *APIcastLogicReconciler) recordUpgradeStep(step string, err error) {
+	if err != nil {
+		r.RecordEventf(v1.EventTypeWarning, EventReasonDeploymentUpgradeFailed, "Upgrade deployment: %s failed: %v", step, err)
+		return
+	}
+	r.RecordEventf(v1.EventTypeNormal, EventReasonDeploymentUpgrade, "Upgrade deployment: %s", step)
+}
+
+// recordUpgrade keeps the upgrade state of the default image and records the
+// upgrades and the new pending upgrades. The state is cleared when the image is
+// set in the spec.
+func (r *APIcastLogicReconciler) recordUpgrade(upgrade apicast.UpgradeOptions) {
+	if upgrade.Status == nil {
+		r.UpgradeStatus = nil
+		return
+	}
+
+	switch {
+	case upgrade.Upgraded:
+		record := upgrade.Status.History[0]
+		r.RecordEventf(v1.EventTypeNormal, EventReasonImageUpgraded, "Upgrading image from %s to %s", record.FromImage, record.ToImage)
+	case upgrade.Status.PendingImage != "" && (r.UpgradeStatus == nil || r.UpgradeStatus.PendingImage != upgrade.Status.PendingImage):
+		r.RecordEventf(v1.EventTypeNormal, EventReasonUpgradePending, "Upgrade to %s pending: %s", upgrade.Status.PendingImage, upgrade.Status.Message)
+	}
+
+	r.UpgradeStatus = upgrade.Status
+}
@@ -3,16 +3,26 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
 	apicast "github.com/3scale/apicast-operator/pkg/apicast"
@@ -25,12 +35,59 @@ import (
 type APIcastLogicReconciler struct {
 	reconcilers.BaseReconciler
 	APIcastCR *appsv1alpha1.APIcast
+	// RouteAPIAvailable is true when the cluster serves the OpenShift Route API
+	RouteAPIAvailable bool
+	// HTTPRouteAPIAvailable is true when the cluster serves the Gateway API HTTPRoute v1 API
+	HTTPRouteAPIAvailable bool
+	// TLSRouteAPIAvailable is true when the cluster serves the Gateway API TLSRoute v1alpha2 API
+	TLSRouteAPIAvailable bool
+	// ServiceMonitorAPIAvailable is true when the cluster serves the Prometheus Operator ServiceMonitor API
+	ServiceMonitorAPIAvailable bool
+	// PodMonitorAPIAvailable is true when the cluster serves the Prometheus Operator PodMonitor API
+	PodMonitorAPIAvailable bool
+	// PrometheusRuleAPIAvailable is true when the cluster serves the Prometheus Operator PrometheusRule API
+	PrometheusRuleAPIAvailable bool
+	// GrafanaDashboardAPIAvailable is true when the cluster serves the Grafana Operator GrafanaDashboard API
+	GrafanaDashboardAPIAvailable bool
+	// OperatorConfig are the organization-wide defaults of the APIcast resources
+	OperatorConfig *apicast.OperatorConfig
+	// ImageResolver resolves the image tag when image digest pinning is enabled
+	ImageResolver apicast.ImageResolver
+	// ResolvedImage is the digest the image was resolved to, nil when image
+	// digest pinning is disabled
+	ResolvedImage *appsv1alpha1.ResolvedImageStatus
+	// UpgradeStatus is the upgrade state of the default image computed by the reconciliation
+	UpgradeStatus *appsv1alpha1.UpgradeStatus
+	// CanaryStatus is the canary release state computed by the reconciliation
+	CanaryStatus *appsv1alpha1.CanaryStatus
+	// BlueGreenStatus is the blue/green rollout state computed by the reconciliation
+	BlueGreenStatus *appsv1alpha1.BlueGreenStatus
+	// ConfigurationValidCondition is the result of the embedded configuration
+	// validation, nil when the configuration is provided by the admin portal
+	ConfigurationValidCondition *metav1.Condition
+	// AdminPortalReachableCondition is the result of the admin portal
+	// connectivity check, nil when the check is disabled
+	AdminPortalReachableCondition *metav1.Condition
+	// SecretHashes are the hashes of the watched secrets computed by the reconciliation
+	SecretHashes map[string]string
+	// LastConfigurationRolloutTime is the last time the reconciliation rolled
+	// out the pods because of a configuration change
+	LastConfigurationRolloutTime *metav1.Time
+	// LastAdminPortalCheckTime is the last time the admin portal connectivity was checked
+	LastAdminPortalCheckTime *metav1.Time
 }
 
 func NewAPIcastLogicReconciler(b reconcilers.BaseReconciler, cr *appsv1alpha1.APIcast) APIcastLogicReconciler {
 	return APIcastLogicReconciler{
-		BaseReconciler: b,
-		APIcastCR:      cr,
+		BaseReconciler:               b,
+		APIcastCR:                    cr,
+		CanaryStatus:                 cr.Status.Canary.DeepCopy(),
+		BlueGreenStatus:              cr.Status.BlueGreen.DeepCopy(),
+		ResolvedImage:                cr.Status.ResolvedImage.DeepCopy(),
+		UpgradeStatus:                cr.Status.Upgrade.DeepCopy(),
+		SecretHashes:                 cr.Status.DeepCopy().SecretHashes,
+		LastConfigurationRolloutTime: cr.Status.LastConfigurationRolloutTime.DeepCopy(),
+		LastAdminPortalCheckTime:     cr.Status.LastAdminPortalCheckTime.DeepCopy(),
 	}
 }
 
@@ -40,6 +97,17 @@ func (r *APIcastLogicReconciler) Reconcile(ctx context.Context) (reconcile.Resul
 		return ctrl.Result{}, err
 	}
 
+	err = r.validateAPicastCR(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Managed copies must exist before secret references are resolved
+	err = r.reconcileSecretSources(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	res, err := r.reconcileAPIcastCR(ctx)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -48,15 +116,15 @@ func (r *APIcastLogicReconciler) Reconcile(ctx context.Context) (reconcile.Resul
 		return res, nil
 	}
 
-	err = r.validateAPicastCR(ctx)
+	apicastFactory, err := apicast.Factory(ctx, r.APIcastCR, r.Client(), r.OperatorConfig, r.ImageResolver)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
-
-	apicastFactory, err := apicast.Factory(ctx, r.APIcastCR, r.Client())
-	if err != nil {
-		return reconcile.Result{}, err
+	if resolved := apicastFactory.ResolvedImage(); resolved != nil && (r.ResolvedImage == nil || *r.ResolvedImage != *resolved) {
+		r.RecordEventf(v1.EventTypeNormal, EventReasonImageResolved, "Pinned image %s to %s", resolved.Image, resolved.Digest)
 	}
+	r.ResolvedImage = apicastFactory.ResolvedImage()
+	r.recordUpgrade(apicastFactory.Upgrade())
 
 	upgradeDeploymentResult, err := r.upgradeDeploymentSelector(ctx, apicastFactory)
 	if err != nil {
@@ -68,34 +136,29 @@ func (r *APIcastLogicReconciler) Reconcile(ctx context.Context) (reconcile.Resul
 	}
 
 	//
-	// Gateway deployment
+	// Admin portal connectivity
 	//
-	deploymentMutators := make([]reconcilers.DeploymentMutateFn, 0)
-	if !r.APIcastCR.Spec.Hpa {
-		deploymentMutators = append(deploymentMutators, reconcilers.DeploymentReplicasMutator)
+	var adminPortalCheckRequeueAfter time.Duration
+	if apicastFactory.AdminPortalCheck().Enabled {
+		adminPortalCheckRequeueAfter = r.checkAdminPortal(ctx, apicastFactory)
+	} else {
+		r.LastAdminPortalCheckTime = nil
 	}
 
-	deploymentMutators = append(deploymentMutators,
-		reconcilers.DeploymentImageMutator,
-		reconcilers.DeploymentServiceAccountNameMutator,
-		reconcilers.DeploymentEnvVarsMutator,
-		reconcilers.DeploymentAffinityMutator,
-		reconcilers.DeploymentTolerationsMutator,
-		reconcilers.DeploymentResourceMutator,
-		reconcilers.DeploymentTopologySpreadConstraintsMutator,
-		reconcilers.DeploymentPriorityClassNameMutator,
-		reconcilers.DeploymentPodTemplateAnnotationsMutator,
-		reconcilers.DeploymentVolumesMutator,
-		reconcilers.DeploymentVolumeMountsMutator,
-		reconcilers.DeploymentPortsMutator,
-		reconcilers.DeploymentTemplateLabelsMutator,
-	)
-
-	deployment, err := apicastFactory.Deployment(ctx, r.Client())
-	if err != nil {
-		return reconcile.Result{}, err
+	//
+	// Gateway deployment
+	//
+	configurationErrors, configurationWarnings := apicastFactory.ValidateEmbeddedConfiguration()
+	r.ConfigurationValidCondition = r.configurationValidCondition(configurationErrors, configurationWarnings)
+	if len(configurationErrors) > 0 {
+		logger.Info("invalid embedded configuration, holding back the configuration rollout", "errors", configurationErrors.ToAggregate().Error())
+		r.RecordEventf(v1.EventTypeWarning, EventReasonInvalidConfiguration, "Configuration rollout held back, invalid embedded configuration: %v", configurationErrors.ToAggregate())
 	}
-	err = r.ReconcileResource(ctx, &appsv1.Deployment{}, deployment, reconcilers.DeploymentMutator(deploymentMutators...))
+	if len(configurationWarnings) > 0 {
+		r.RecordEventf(v1.EventTypeWarning, EventReasonConfigurationWarning, "Embedded configuration warnings: %s", strings.Join(configurationWarnings, ", "))
+	}
+
+	canaryRequeueAfter, err := r.reconcileDeployment(ctx, apicastFactory)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -120,6 +183,7 @@ func (r *APIcastLogicReconciler) Reconcile(ctx context.Context) (reconcile.Resul
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	r.SecretHashes = secret.StringData
 
 	//
 	// Gateway service
@@ -127,14 +191,24 @@ func (r *APIcastLogicReconciler) Reconcile(ctx context.Context) (reconcile.Resul
 	serviceMutators := []reconcilers.ServiceMutateFn{
 		reconcilers.ServicePortMutator,
 		reconcilers.ServiceSelectorMutator,
+		reconcilers.ServiceLabelsMutator,
 	}
 
 	service := apicastFactory.Service()
+	if r.BlueGreenStatus != nil && r.BlueGreenStatus.ActiveColor != "" {
+		service.Spec.Selector = apicast.BlueGreenLabels(service.Spec.Selector, string(r.BlueGreenStatus.ActiveColor))
+	}
 	err = r.ReconcileResource(ctx, &v1.Service{}, service, reconcilers.ServiceMutator(serviceMutators...))
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
+	// deployments are deleted once the service no longer routes traffic to them
+	err = r.deleteReplacedDeployments(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	//
 	// Gateway ingress
 	//
@@ -144,11 +218,69 @@ func (r *APIcastLogicReconciler) Reconcile(ctx context.Context) (reconcile.Resul
 		return reconcile.Result{}, err
 	}
 
+	//
+	// Gateway route
+	//
+	route := apicastFactory.Route()
+	err = r.reconcileRoute(ctx, route)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	//
+	// Gateway API routes
+	//
+	err = r.reconcileHTTPRoute(ctx, apicastFactory.HTTPRoute())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	err = r.reconcileTLSRoute(ctx, apicastFactory.TLSRoute())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	//
+	// Gateway metrics monitoring
+	//
+	err = r.reconcileServiceMonitor(ctx, apicastFactory.ServiceMonitor())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	err = r.reconcilePodMonitor(ctx, apicastFactory.PodMonitor())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	err = r.reconcilePrometheusRule(ctx, apicastFactory.PrometheusRule())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	err = r.reconcileGrafanaDashboard(ctx, apicastFactory)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Prepare HPA object
 	hpaDesired := reconcilers.HpaCR(r.APIcastCR)
 
-	if r.APIcastCR.Spec.Hpa {
-		// If HPA is enabled but only the enable field is set, create the initial HPA object and do not reconcile further
+	if r.APIcastCR.Spec.Autoscaling != nil {
+		hpaMutators := []reconcilers.HpaMutateFn{
+			reconcilers.HpaOwnerReferencesMutator,
+			reconcilers.HpaScaleTargetRefMutator,
+			reconcilers.HpaMinReplicasMutator,
+			reconcilers.HpaMaxReplicasMutator,
+			reconcilers.HpaMetricsMutator,
+			reconcilers.HpaBehaviorMutator,
+		}
+		err = r.ReconcileResource(ctx, &hpa.HorizontalPodAutoscaler{}, hpaDesired, reconcilers.HpaMutator(hpaMutators...))
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	} else if r.APIcastCR.Spec.Hpa {
+		// The HPA created with the defaults can be edited, changes are not reverted
 		err = r.ReconcileResource(ctx, &hpa.HorizontalPodAutoscaler{}, hpaDesired, reconcilers.HpaCreateOnlyMutator())
 		if err != nil {
 			return reconcile.Result{}, err
@@ -162,7 +294,195 @@ func (r *APIcastLogicReconciler) Reconcile(ctx context.Context) (reconcile.Resul
 		}
 	}
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: minRequeueAfter(canaryRequeueAfter, adminPortalCheckRequeueAfter, apicastFactory.Upgrade().RequeueAfter)}, nil
+}
+
+// reconcileDeployment reconciles the deployments running APIcast. The
+// returned duration is the time after which the canary analysis must be resumed.
+func (r *APIcastLogicReconciler) reconcileDeployment(ctx context.Context, apicastFactory *apicast.APIcast) (time.Duration, error) {
+	if apicastFactory.BlueGreen().Enabled {
+		return 0, r.reconcileBlueGreen(ctx, apicastFactory)
+	}
+
+	deploymentMutators := make([]reconcilers.DeploymentMutateFn, 0)
+	if !r.APIcastCR.IsHPAEnabled() {
+		deploymentMutators = append(deploymentMutators, reconcilers.DeploymentReplicasMutator)
+	}
+	deploymentMutators = append(deploymentMutators, deploymentTemplateMutators()...)
+
+	deployment, err := apicastFactory.Deployment(ctx, r.Client())
+	if err != nil {
+		return 0, err
+	}
+
+	deploy, err := r.holdBackConfigurationRollout(ctx, deployment)
+	if err != nil || !deploy {
+		return 0, err
+	}
+
+	stableImage, canaryRequeueAfter, err := r.reconcileCanary(ctx, apicastFactory, deployment.Spec.Template.Spec.Containers[0].Image)
+	if err != nil {
+		return 0, err
+	}
+	deployment.Spec.Template.Spec.Containers[0].Image = stableImage
+
+	err = r.recordConfigurationRollout(ctx, deployment)
+	if err != nil {
+		return 0, err
+	}
+
+	err = r.ReconcileResource(ctx, &appsv1.Deployment{}, deployment, reconcilers.DeploymentMutator(deploymentMutators...))
+	if err != nil {
+		return 0, err
+	}
+
+	return canaryRequeueAfter, r.reconcileBlueGreenTeardown(ctx)
+}
+
+// recordConfigurationRollout records the time of the rollout when the desired
+// deployment rolls out the pods because a watched secret changed
+func (r *APIcastLogicReconciler) recordConfigurationRollout(ctx context.Context, desired *appsv1.Deployment) error {
+	existing := &appsv1.Deployment{}
+	err := r.Client().Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if apicast.WatchedSecretAnnotationsChanged(existing.Spec.Template.Annotations, desired.Spec.Template.Annotations) {
+		now := metav1.Now()
+		r.LastConfigurationRolloutTime = &now
+		r.RecordEventf(v1.EventTypeNormal, EventReasonConfigurationRollout, "Rolling out deployment %s, watched secrets changed", desired.Name)
+	}
+
+	return nil
+}
+
+// holdBackConfigurationRollout keeps the embedded configuration annotation of
+// the existing deployment while the embedded configuration is not valid, so the
+// configuration change does not roll out the pods. Other changes are applied.
+// It returns false when the deployment does not exist and must not be created yet.
+func (r *APIcastLogicReconciler) holdBackConfigurationRollout(ctx context.Context, desired *appsv1.Deployment) (bool, error) {
+	if !r.isConfigurationInvalid() {
+		return true, nil
+	}
+
+	existing := &appsv1.Deployment{}
+	err := r.Client().Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if resourceVersion, ok := existing.Spec.Template.Annotations[apicast.GatewayConfigurationSecretResverAnnotation]; ok {
+		desired.Spec.Template.Annotations[apicast.GatewayConfigurationSecretResverAnnotation] = resourceVersion
+	} else {
+		delete(desired.Spec.Template.Annotations, apicast.GatewayConfigurationSecretResverAnnotation)
+	}
+
+	return true, nil
+}
+
+// isConfigurationInvalid returns true when the embedded configuration failed the validation
+func (r *APIcastLogicReconciler) isConfigurationInvalid() bool {
+	return r.ConfigurationValidCondition != nil && r.ConfigurationValidCondition.Status == metav1.ConditionFalse
+}
+
+// configurationValidCondition reports the embedded configuration validation
+// errors. Warnings do not make the configuration invalid.
+func (r *APIcastLogicReconciler) configurationValidCondition(errs field.ErrorList, warnings []string) *metav1.Condition {
+	if r.APIcastCR.Spec.EmbeddedConfigurationSecretRef == nil {
+		return nil
+	}
+
+	if len(errs) > 0 {
+		return &metav1.Condition{
+			Type:    appsv1alpha1.ConfigurationValidConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "InvalidConfiguration",
+			Message: errs.ToAggregate().Error(),
+		}
+	}
+
+	condition := &metav1.Condition{
+		Type:    appsv1alpha1.ConfigurationValidConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ValidConfiguration",
+		Message: "Embedded configuration is valid",
+	}
+	if len(warnings) > 0 {
+		condition.Message = fmt.Sprintf("Embedded configuration is valid, with warnings: %s", strings.Join(warnings, ", "))
+	}
+
+	return condition
+}
+
+// checkAdminPortal checks the admin portal connectivity at most once per
+// interval, since the check blocks the reconciliation until the admin portal
+// answers or the request times out. It returns the time until the next check.
+func (r *APIcastLogicReconciler) checkAdminPortal(ctx context.Context, apicastFactory *apicast.APIcast) time.Duration {
+	interval := time.Duration(apicastFactory.AdminPortalCheck().IntervalSeconds) * time.Second
+
+	// the status keeps the result of the last check
+	if r.LastAdminPortalCheckTime != nil && meta.FindStatusCondition(r.APIcastCR.Status.Conditions, appsv1alpha1.AdminPortalReachableConditionType) != nil {
+		if elapsed := time.Since(r.LastAdminPortalCheckTime.Time); elapsed >= 0 && elapsed < interval {
+			return interval - elapsed
+		}
+	}
+
+	r.AdminPortalReachableCondition = adminPortalReachableCondition(apicastFactory.CheckAdminPortal(ctx))
+	now := metav1.Now()
+	r.LastAdminPortalCheckTime = &now
+	return interval
+}
+
+// adminPortalReachableCondition reports the admin portal connectivity check result
+func adminPortalReachableCondition(result apicast.AdminPortalCheckResult) *metav1.Condition {
+	status := metav1.ConditionFalse
+	if result.Reachable {
+		status = metav1.ConditionTrue
+	}
+
+	return &metav1.Condition{
+		Type:    appsv1alpha1.AdminPortalReachableConditionType,
+		Status:  status,
+		Reason:  result.Reason,
+		Message: result.Message,
+	}
+}
+
+// deploymentTemplateMutators returns the mutators shared by the stable, the
+// canary and the blue/green deployments
+func deploymentTemplateMutators() []reconcilers.DeploymentMutateFn {
+	return []reconcilers.DeploymentMutateFn{
+		reconcilers.DeploymentImageMutator,
+		reconcilers.DeploymentImagePullMutator,
+		reconcilers.DeploymentServiceAccountNameMutator,
+		reconcilers.DeploymentEnvVarsMutator,
+		reconcilers.DeploymentAffinityMutator,
+		reconcilers.DeploymentTolerationsMutator,
+		reconcilers.DeploymentResourceMutator,
+		reconcilers.DeploymentTopologySpreadConstraintsMutator,
+		reconcilers.DeploymentPriorityClassNameMutator,
+		reconcilers.DeploymentPodTemplateAnnotationsMutator,
+		reconcilers.DeploymentVolumesMutator,
+		reconcilers.DeploymentVolumeMountsMutator,
+		reconcilers.DeploymentPortsMutator,
+		reconcilers.DeploymentTemplateLabelsMutator,
+		reconcilers.DeploymentProbesMutator,
+		reconcilers.DeploymentGracefulShutdownMutator,
+		reconcilers.DeploymentSidecarsMutator,
+		reconcilers.DeploymentInitContainersMutator,
+		reconcilers.DeploymentPodTemplateOverrideMutator,
+		reconcilers.DeploymentStrategyMutator,
+		reconcilers.DeploymentMinReadySecondsMutator,
+		reconcilers.DeploymentProgressDeadlineSecondsMutator,
+		reconcilers.DeploymentRevisionHistoryLimitMutator,
+	}
 }
 
 func (r *APIcastLogicReconciler) reconcileAPIcastCR(ctx context.Context) (ctrl.Result, error) {
@@ -270,17 +590,260 @@ func (r *APIcastLogicReconciler) getSecretUIDs(ctx context.Context) (map[string]
 		uidMap[string(secret.GetUID())] = watchedByVal
 	}
 
+	// secret sources live in other namespaces, which might not be cached
+	for idx := range r.APIcastCR.Spec.SecretSources {
+		secret := &v1.Secret{}
+		secretKey := secretSourceKey(r.APIcastCR.Spec.SecretSources[idx])
+		err := r.APIClientReader().Get(ctx, secretKey, secret)
+		r.Logger().V(1).Info("reading secret source", "objectKey", secretKey, "error", err)
+		if err != nil {
+			return nil, err
+		}
+
+		watchedByVal := fmt.Sprintf("%t", k8sutils.IsSecretWatchedByApicast(secret))
+		uidMap[string(secret.GetUID())] = watchedByVal
+	}
+
 	return uidMap, nil
 }
 
+func secretSourceKey(secretSource appsv1alpha1.SecretSourceSpec) client.ObjectKey {
+	return client.ObjectKey{
+		Name:      secretSource.SourceRef.Name,
+		Namespace: secretSource.SourceRef.Namespace,
+	}
+}
+
+// reconcileSecretSources copies the secret sources into the APIcast namespace
+// and removes the managed copies no longer declared in the spec
+func (r *APIcastLogicReconciler) reconcileSecretSources(ctx context.Context) error {
+	desiredCopies := map[string]bool{}
+
+	for _, secretSource := range r.APIcastCR.Spec.SecretSources {
+		sourceKey := secretSourceKey(secretSource)
+		source := &v1.Secret{}
+		// secret sources live in other namespaces, which might not be cached
+		err := r.APIClientReader().Get(ctx, sourceKey, source)
+		if err != nil {
+			return fmt.Errorf("reading secret source %s: %w", sourceKey, err)
+		}
+
+		// the owner of the source secret grants access to the APIcast namespace
+		if !k8sutils.IsSecretSharedWithNamespace(source, r.APIcastCR.Namespace) {
+			return fmt.Errorf("secret source %s is not shared with namespace %s, see the %s annotation",
+				sourceKey, r.APIcastCR.Namespace, k8sutils.SecretAllowedNamespacesAnnotation)
+		}
+
+		desired := apicast.SecretSourceCopy(r.APIcastCR, secretSource, source)
+
+		// never take over secrets not managed by this APIcast
+		existing := &v1.Secret{}
+		err = r.Client().Get(ctx, client.ObjectKeyFromObject(desired), existing)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		if err == nil && !metav1.IsControlledBy(existing, r.APIcastCR) {
+			return fmt.Errorf("secret %s already exists and is not managed by the APIcast", client.ObjectKeyFromObject(desired))
+		}
+		// the secret type is immutable, the copy is created again when the source type changed
+		if err == nil && existing.Type != desired.Type {
+			k8sutils.TagObjectToDelete(existing)
+			err = r.ReconcileResource(ctx, &v1.Secret{}, existing, reconcilers.CreateOnlyMutator)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = r.ReconcileResource(ctx, &v1.Secret{}, desired, reconcilers.SecretMutator(
+			reconcilers.SecretDataMutator,
+			reconcilers.SecretLabelsMutator,
+			reconcilers.SecretAnnotationsMutator,
+		))
+		if err != nil {
+			return err
+		}
+
+		desiredCopies[desired.Name] = true
+	}
+
+	copyList := &v1.SecretList{}
+	err := r.Client().List(ctx, copyList,
+		client.InNamespace(r.APIcastCR.Namespace),
+		client.MatchingLabels{apicast.SecretSourceCopyLabel: r.APIcastCR.Name},
+	)
+	if err != nil {
+		return err
+	}
+
+	for idx := range copyList.Items {
+		secretCopy := &copyList.Items[idx]
+		if desiredCopies[secretCopy.Name] || !metav1.IsControlledBy(secretCopy, r.APIcastCR) {
+			continue
+		}
+
+		k8sutils.TagObjectToDelete(secretCopy)
+		err = r.ReconcileResource(ctx, &v1.Secret{}, secretCopy, reconcilers.CreateOnlyMutator)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *APIcastLogicReconciler) reconcileIngress(ctx context.Context, desired *networkingv1.Ingress) error {
-	if r.APIcastCR.Spec.ExposedHost == nil {
+	if r.APIcastCR.Spec.ExposedHost == nil || r.APIcastCR.IsRouteEnabled() || r.APIcastCR.IsGatewayEnabled() {
 		k8sutils.TagObjectToDelete(desired)
 	}
 
 	return r.ReconcileResource(ctx, &networkingv1.Ingress{}, desired, reconcilers.IngressMutator)
 }
 
+func (r *APIcastLogicReconciler) reconcileRoute(ctx context.Context, desired *routev1.Route) error {
+	if !r.RouteAPIAvailable {
+		if r.APIcastCR.IsRouteEnabled() {
+			return fmt.Errorf("exposedHost.route requires the OpenShift Route API, which is not available in the cluster")
+		}
+		// Nothing to clean up
+		return nil
+	}
+
+	if !r.APIcastCR.IsRouteEnabled() {
+		k8sutils.TagObjectToDelete(desired)
+	}
+
+	return r.ReconcileResource(ctx, &routev1.Route{}, desired, reconcilers.RouteMutator)
+}
+
+func (r *APIcastLogicReconciler) reconcileHTTPRoute(ctx context.Context, desired *gatewayapiv1.HTTPRoute) error {
+	if !r.HTTPRouteAPIAvailable {
+		if r.APIcastCR.IsGatewayEnabled() {
+			return fmt.Errorf("exposedHost.gateway requires the Gateway API HTTPRoute v1 API, which is not available in the cluster")
+		}
+		// Nothing to clean up
+		return nil
+	}
+
+	if !r.APIcastCR.IsGatewayEnabled() {
+		k8sutils.TagObjectToDelete(desired)
+	}
+
+	return r.ReconcileResource(ctx, &gatewayapiv1.HTTPRoute{}, desired, reconcilers.HTTPRouteMutator)
+}
+
+func (r *APIcastLogicReconciler) reconcileTLSRoute(ctx context.Context, desired *gatewayapiv1alpha2.TLSRoute) error {
+	enabled := r.APIcastCR.IsGatewayEnabled() && len(r.APIcastCR.Spec.ExposedHost.Gateway.TLSRouteParentRefs) > 0
+
+	if !r.TLSRouteAPIAvailable {
+		if enabled {
+			return fmt.Errorf("exposedHost.gateway.tlsRouteParentRefs requires the Gateway API TLSRoute v1alpha2 API, which is not available in the cluster")
+		}
+		// Nothing to clean up
+		return nil
+	}
+
+	if !enabled {
+		k8sutils.TagObjectToDelete(desired)
+	}
+
+	return r.ReconcileResource(ctx, &gatewayapiv1alpha2.TLSRoute{}, desired, reconcilers.TLSRouteMutator)
+}
+
+func (r *APIcastLogicReconciler) reconcileServiceMonitor(ctx context.Context, desired *monitoringv1.ServiceMonitor) error {
+	enabled := r.APIcastCR.IsMonitoringEnabled() && r.APIcastCR.MonitoringKind() == appsv1alpha1.MonitoringKindServiceMonitor
+
+	if !r.ServiceMonitorAPIAvailable {
+		if enabled {
+			logger, err := logr.FromContext(ctx)
+			if err != nil {
+				return err
+			}
+			logger.Info("ServiceMonitor API not available in the cluster, skipping ServiceMonitor reconciliation")
+		}
+		return nil
+	}
+
+	if !enabled {
+		k8sutils.TagObjectToDelete(desired)
+	}
+
+	return r.ReconcileResource(ctx, &monitoringv1.ServiceMonitor{}, desired, reconcilers.ServiceMonitorMutator)
+}
+
+func (r *APIcastLogicReconciler) reconcilePodMonitor(ctx context.Context, desired *monitoringv1.PodMonitor) error {
+	enabled := r.APIcastCR.IsMonitoringEnabled() && r.APIcastCR.MonitoringKind() == appsv1alpha1.MonitoringKindPodMonitor
+
+	if !r.PodMonitorAPIAvailable {
+		if enabled {
+			logger, err := logr.FromContext(ctx)
+			if err != nil {
+				return err
+			}
+			logger.Info("PodMonitor API not available in the cluster, skipping PodMonitor reconciliation")
+		}
+		return nil
+	}
+
+	if !enabled {
+		k8sutils.TagObjectToDelete(desired)
+	}
+
+	return r.ReconcileResource(ctx, &monitoringv1.PodMonitor{}, desired, reconcilers.PodMonitorMutator)
+}
+
+func (r *APIcastLogicReconciler) reconcilePrometheusRule(ctx context.Context, desired *monitoringv1.PrometheusRule) error {
+	if !r.PrometheusRuleAPIAvailable {
+		if r.APIcastCR.IsMonitoringAlertsEnabled() {
+			logger, err := logr.FromContext(ctx)
+			if err != nil {
+				return err
+			}
+			logger.Info("PrometheusRule API not available in the cluster, skipping PrometheusRule reconciliation")
+		}
+		return nil
+	}
+
+	if !r.APIcastCR.IsMonitoringAlertsEnabled() {
+		k8sutils.TagObjectToDelete(desired)
+	}
+
+	return r.ReconcileResource(ctx, &monitoringv1.PrometheusRule{}, desired, reconcilers.PrometheusRuleMutator)
+}
+
+// reconcileGrafanaDashboard reconciles the dashboard as a GrafanaDashboard
+// resource when the Grafana Operator API is available, and as a ConfigMap
+// for the Grafana dashboards sidecar otherwise
+func (r *APIcastLogicReconciler) reconcileGrafanaDashboard(ctx context.Context, apicastFactory *apicast.APIcast) error {
+	enabled := r.APIcastCR.IsMonitoringDashboardEnabled()
+
+	configMap, err := apicastFactory.GrafanaDashboardConfigMap()
+	if err != nil {
+		return err
+	}
+	if !enabled || r.GrafanaDashboardAPIAvailable {
+		k8sutils.TagObjectToDelete(configMap)
+	}
+	err = r.ReconcileResource(ctx, &v1.ConfigMap{}, configMap, reconcilers.GrafanaDashboardConfigMapMutator)
+	if err != nil {
+		return err
+	}
+
+	if !r.GrafanaDashboardAPIAvailable {
+		return nil
+	}
+
+	dashboard, err := apicastFactory.GrafanaDashboard()
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		k8sutils.TagObjectToDelete(dashboard)
+	}
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(apicast.GrafanaDashboardGVK)
+	return r.ReconcileResource(ctx, existing, dashboard, reconcilers.GrafanaDashboardMutator)
+}
+
 func (r *APIcastLogicReconciler) validateAPicastCR(ctx context.Context) error {
 	logger, err := logr.FromContext(ctx)
 	if err != nil {
@@ -299,6 +862,7 @@ func (r *APIcastLogicReconciler) validateAPicastCR(ctx context.Context) error {
 		return nil
 	}
 
+	r.RecordEventf(v1.EventTypeWarning, EventReasonInvalidSpec, "Invalid spec: %v", errors.ToAggregate())
 	return errors.ToAggregate()
 }
 
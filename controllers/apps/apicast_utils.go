@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
 
 	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
 )
@@ -44,3 +47,23 @@ func replaceAPIcastSecretLabels(apicast *appsv1alpha1.APIcast, desiredSecretUIDs
 
 	return !reflect.DeepEqual(existingSecretLabels, desiredSecretLabels)
 }
+
+// isDeploymentAvailable returns true when all the pods of the deployment run
+// the latest template and are available
+func isDeploymentAvailable(deployment *appsv1.Deployment, replicas int32) bool {
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas &&
+		deployment.Status.Replicas == replicas
+}
+
+// minRequeueAfter returns the shortest positive duration, zero when none is positive
+func minRequeueAfter(durations ...time.Duration) time.Duration {
+	var result time.Duration
+	for _, d := range durations {
+		if d > 0 && (result == 0 || d < result) {
+			result = d
+		}
+	}
+	return result
+}
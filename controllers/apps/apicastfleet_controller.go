@@ -0,0 +1,357 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
+	"github.com/3scale/apicast-operator/pkg/reconcilers"
+)
+
+// APIcastFleetReconciler reconciles a APIcastFleet object
+type APIcastFleetReconciler struct {
+	reconcilers.BaseControllerReconciler
+	Log               logr.Logger
+	WatchedNamespaces k8sutils.WatchedNamespaces
+}
+
+// blank assignment to verify that APIcastFleetReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &APIcastFleetReconciler{}
+
+// +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apicastfleets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apicastfleets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apicastfleets/finalizers,verbs=update
+
+func (r *APIcastFleetReconciler) Reconcile(eventCtx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("apicastfleet", req.NamespacedName)
+	ctx := logr.NewContext(eventCtx, log)
+
+	log.Info("Reconciling APIcastFleet")
+
+	fleet := &appsv1alpha1.APIcastFleet{}
+	err := r.Client().Get(ctx, req.NamespacedName, fleet)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("APIcastFleet not found")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Error getting APIcastFleet")
+		return ctrl.Result{}, err
+	}
+
+	baseReconciler := reconcilers.NewBaseReconciler(r.Client(), r.APIClientReader(), r.Scheme(), log, r.EventRecorder(), fleet)
+
+	if !fleet.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, r.deleteMembers(ctx, &baseReconciler, fleet)
+	}
+
+	if controllerutil.AddFinalizer(fleet, appsv1alpha1.APIcastFleetFinalizer) {
+		// the update triggers a new reconciliation
+		return ctrl.Result{}, r.Client().Update(ctx, fleet)
+	}
+
+	fieldErrors := fleet.Validate()
+	if len(fieldErrors) > 0 {
+		// invalid specs are reported in the status, retrying does not fix them
+		baseReconciler.RecordEventf(corev1.EventTypeWarning, EventReasonInvalidSpec, "Invalid spec: %v", fieldErrors.ToAggregate())
+		return r.statusResult(r.reconcileStatus(ctx, fleet, nil, fleetInvalidSpecCondition(fieldErrors)))
+	}
+
+	memberMessages, specErr := r.reconcileMembers(ctx, &baseReconciler, fleet)
+
+	var specErrCond *metav1.Condition
+	if specErr != nil {
+		specErrCond = &metav1.Condition{Reason: "ReconcilliationError", Message: specErr.Error()}
+	}
+	statusErr := r.reconcileStatus(ctx, fleet, memberMessages, specErrCond)
+
+	if specErr != nil {
+		// Ignore conflicts, resource might just be outdated.
+		if errors.IsConflict(specErr) {
+			log.Info("Resource update conflict error. Requeuing...", "error", specErr)
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		return ctrl.Result{}, specErr
+	}
+
+	log.Info("Successfully reconciled")
+	return r.statusResult(statusErr)
+}
+
+func (r *APIcastFleetReconciler) statusResult(statusErr error) (ctrl.Result, error) {
+	if statusErr != nil {
+		// Ignore conflicts, resource might just be outdated.
+		if errors.IsConflict(statusErr) {
+			r.Log.Info("Failed to update status: resource might just be outdated")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, statusErr
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcileMembers creates or updates the APIcast members and deletes the
+// APIcasts removed from the fleet. It returns the reason why a member could
+// not be reconciled, by member.
+func (r *APIcastFleetReconciler) reconcileMembers(ctx context.Context, baseReconciler *reconcilers.BaseReconciler, fleet *appsv1alpha1.APIcastFleet) (map[types.NamespacedName]string, error) {
+	memberMessages := map[types.NamespacedName]string{}
+	desiredMembers := map[types.NamespacedName]bool{}
+
+	for _, member := range fleet.Spec.Members {
+		desired, err := fleet.MemberAPIcast(member)
+		if err != nil {
+			return nil, err
+		}
+
+		key := client.ObjectKeyFromObject(desired)
+		desiredMembers[key] = true
+
+		if !r.WatchedNamespaces.Contains(desired.Namespace) {
+			memberMessages[key] = fmt.Sprintf("namespace %s is not watched by the operator", desired.Namespace)
+			continue
+		}
+
+		existing := &appsv1alpha1.APIcast{}
+		err = r.Client().Get(ctx, key, existing)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+		// APIcasts created outside of the fleet are never taken over
+		if err == nil && !isFleetMember(fleet, existing) {
+			memberMessages[key] = fmt.Sprintf("APIcast %s already exists and is not a member of the fleet", key)
+			continue
+		}
+
+		err = baseReconciler.ReconcileResource(ctx, &appsv1alpha1.APIcast{}, desired, reconcilers.APIcastFleetMemberMutator)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	members, err := r.listMembers(ctx, fleet)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range members {
+		if desiredMembers[client.ObjectKeyFromObject(&members[idx])] {
+			continue
+		}
+
+		if err := r.deleteMember(ctx, baseReconciler, &members[idx]); err != nil {
+			return nil, err
+		}
+	}
+
+	return memberMessages, nil
+}
+
+// deleteMembers deletes all the APIcast members of a deleted fleet and
+// releases the fleet
+func (r *APIcastFleetReconciler) deleteMembers(ctx context.Context, baseReconciler *reconcilers.BaseReconciler, fleet *appsv1alpha1.APIcastFleet) error {
+	if !controllerutil.ContainsFinalizer(fleet, appsv1alpha1.APIcastFleetFinalizer) {
+		return nil
+	}
+
+	members, err := r.listMembers(ctx, fleet)
+	if err != nil {
+		return err
+	}
+
+	for idx := range members {
+		if err := r.deleteMember(ctx, baseReconciler, &members[idx]); err != nil {
+			return err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(fleet, appsv1alpha1.APIcastFleetFinalizer)
+	return r.Client().Update(ctx, fleet)
+}
+
+func (r *APIcastFleetReconciler) deleteMember(ctx context.Context, baseReconciler *reconcilers.BaseReconciler, member *appsv1alpha1.APIcast) error {
+	k8sutils.TagObjectToDelete(member)
+	return baseReconciler.ReconcileResource(ctx, &appsv1alpha1.APIcast{}, member, reconcilers.CreateOnlyMutator)
+}
+
+// listMembers returns the APIcasts labelled as members of the fleet
+func (r *APIcastFleetReconciler) listMembers(ctx context.Context, fleet *appsv1alpha1.APIcastFleet) ([]appsv1alpha1.APIcast, error) {
+	opts := []client.ListOption{client.MatchingLabels(fleet.MemberLabels())}
+
+	// Support namespace scope, multiple namespaces or cluster scoped
+	opts = append(opts, r.WatchedNamespaces.ListOptions()...)
+
+	apicastList := &appsv1alpha1.APIcastList{}
+	if err := r.Client().List(ctx, apicastList, opts...); err != nil {
+		return nil, err
+	}
+
+	return apicastList.Items, nil
+}
+
+func isFleetMember(fleet *appsv1alpha1.APIcastFleet, apicast *appsv1alpha1.APIcast) bool {
+	for key, value := range fleet.MemberLabels() {
+		if apicast.Labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// reconcileStatus aggregates the readiness of the APIcast members. The error
+// condition, when set, reports why the members could not be reconciled.
+func (r *APIcastFleetReconciler) reconcileStatus(ctx context.Context, fleet *appsv1alpha1.APIcastFleet, memberMessages map[types.NamespacedName]string, errCond *metav1.Condition) error {
+	logger, _ := logr.FromContext(ctx)
+
+	newStatus := &appsv1alpha1.APIcastFleetStatus{
+		// Copy initial conditions. Otherwise, status will always be updated
+		Conditions:         k8sutils.CopyConditions(fleet.Status.Conditions),
+		ObservedGeneration: fleet.Status.ObservedGeneration,
+		DesiredMembers:     int32(len(fleet.Spec.Members)),
+	}
+
+	for _, member := range fleet.Spec.Members {
+		memberStatus, err := r.memberStatus(ctx, fleet, member, memberMessages)
+		if err != nil {
+			return err
+		}
+		if memberStatus.Ready {
+			newStatus.ReadyMembers++
+		}
+		newStatus.Members = append(newStatus.Members, *memberStatus)
+	}
+
+	meta.SetStatusCondition(&newStatus.Conditions, fleetReadyCondition(newStatus, errCond))
+
+	equalStatus := equality.Semantic.DeepEqual(&fleet.Status, newStatus)
+	if equalStatus && fleet.Generation == fleet.Status.ObservedGeneration {
+		// Steady state
+		logger.V(1).Info("Status was not updated")
+		return nil
+	}
+
+	newStatus.ObservedGeneration = fleet.Generation
+
+	fleet.Status = *newStatus
+	if err := r.Client().Status().Update(ctx, fleet); err != nil {
+		if errors.IsConflict(err) {
+			return err
+		}
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+	return nil
+}
+
+func (r *APIcastFleetReconciler) memberStatus(ctx context.Context, fleet *appsv1alpha1.APIcastFleet, member appsv1alpha1.APIcastFleetMember, memberMessages map[types.NamespacedName]string) (*appsv1alpha1.APIcastFleetMemberStatus, error) {
+	key := types.NamespacedName{Name: member.Name, Namespace: fleet.MemberNamespace(member)}
+	memberStatus := &appsv1alpha1.APIcastFleetMemberStatus{Name: key.Name, Namespace: key.Namespace}
+
+	if message, ok := memberMessages[key]; ok {
+		memberStatus.Message = message
+		return memberStatus, nil
+	}
+
+	if !r.WatchedNamespaces.Contains(key.Namespace) {
+		memberStatus.Message = fmt.Sprintf("namespace %s is not watched by the operator", key.Namespace)
+		return memberStatus, nil
+	}
+
+	apicast := &appsv1alpha1.APIcast{}
+	err := r.Client().Get(ctx, key, apicast)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			memberStatus.Message = "APIcast not found"
+			return memberStatus, nil
+		}
+		return nil, err
+	}
+
+	memberStatus.Ready = apicast.Status.IsReady()
+	if !memberStatus.Ready {
+		memberStatus.Message = "APIcast is not ready"
+		if readyCondition := meta.FindStatusCondition(apicast.Status.Conditions, appsv1alpha1.ReadyConditionType); readyCondition != nil && readyCondition.Message != "" {
+			memberStatus.Message = readyCondition.Message
+		}
+	}
+
+	return memberStatus, nil
+}
+
+func fleetInvalidSpecCondition(fieldErrors field.ErrorList) *metav1.Condition {
+	return &metav1.Condition{Reason: EventReasonInvalidSpec, Message: fieldErrors.ToAggregate().Error()}
+}
+
+func fleetReadyCondition(status *appsv1alpha1.APIcastFleetStatus, errCond *metav1.Condition) metav1.Condition {
+	cond := metav1.Condition{
+		Type:    appsv1alpha1.ReadyConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Ready",
+		Message: "All the APIcast members are ready",
+	}
+
+	if errCond != nil {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = errCond.Reason
+		cond.Message = errCond.Message
+		return cond
+	}
+
+	if status.ReadyMembers != status.DesiredMembers {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "MembersNotReady"
+		cond.Message = fmt.Sprintf("%d of %d APIcast members are ready", status.ReadyMembers, status.DesiredMembers)
+	}
+
+	return cond
+}
+
+// apicastToFleet maps the APIcast members to their fleet
+func apicastToFleet(_ context.Context, obj client.Object) []reconcile.Request {
+	name, ok := obj.GetLabels()[appsv1alpha1.APIcastFleetNameLabel]
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Name:      name,
+		Namespace: obj.GetLabels()[appsv1alpha1.APIcastFleetNamespaceLabel],
+	}}}
+}
+
+func (r *APIcastFleetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1alpha1.APIcastFleet{}).
+		Watches(&appsv1alpha1.APIcast{}, handler.EnqueueRequestsFromMapFunc(apicastToFleet)).
+		Complete(r)
+}
@@ -0,0 +1,203 @@
+//go:build integration
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+)
+
+var _ = Describe("APIcastFleet controller", func() {
+	const (
+		retryInterval = time.Second * 5
+	)
+	var testNamespace string
+	fleetName := "example-fleet"
+
+	BeforeEach(CreateNamespaceCallback(&testNamespace))
+	AfterEach(DeleteNamespaceCallback(&testNamespace))
+
+	fleetKey := func() types.NamespacedName {
+		return types.NamespacedName{Name: fleetName, Namespace: testNamespace}
+	}
+	memberKey := func(name string) types.NamespacedName {
+		return types.NamespacedName{Name: name, Namespace: testNamespace}
+	}
+
+	// createFleet creates a fleet with the given members, all of them in the
+	// namespace of the fleet
+	createFleet := func(ctx context.Context, members ...appsv1alpha1.APIcastFleetMember) {
+		fleet := &appsv1alpha1.APIcastFleet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fleetName,
+				Namespace: testNamespace,
+			},
+			Spec: appsv1alpha1.APIcastFleetSpec{
+				Template: appsv1alpha1.APIcastFleetTemplate{
+					Labels: map[string]string{"team": "gateway"},
+					Spec: appsv1alpha1.APIcastSpec{
+						EmbeddedConfigurationSecretRef: &v1.LocalObjectReference{
+							Name: testAPIcastEmbeddedConfigurationSecretName,
+						},
+						LogLevel: ptr.To("info"),
+					},
+				},
+				Members: members,
+			},
+		}
+		Expect(testClient().Create(ctx, fleet)).To(Succeed())
+	}
+
+	updateFleet := func(ctx context.Context, mutate func(*appsv1alpha1.APIcastFleet)) {
+		Eventually(func(g Gomega) {
+			fleet := &appsv1alpha1.APIcastFleet{}
+			g.Expect(testClient().Get(ctx, fleetKey(), fleet)).To(Succeed())
+			mutate(fleet)
+			g.Expect(testClient().Update(ctx, fleet)).To(Succeed())
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+	}
+
+	// expectMember waits for the APIcast member running the given log level
+	expectMember := func(ctx context.Context, name, logLevel string) {
+		Eventually(func(g Gomega) {
+			apicast := &appsv1alpha1.APIcast{}
+			g.Expect(testClient().Get(ctx, memberKey(name), apicast)).To(Succeed())
+			g.Expect(apicast.Labels).To(HaveKeyWithValue(appsv1alpha1.APIcastFleetNameLabel, fleetName))
+			g.Expect(apicast.Labels).To(HaveKeyWithValue(appsv1alpha1.APIcastFleetNamespaceLabel, testNamespace))
+			g.Expect(apicast.Labels).To(HaveKeyWithValue("team", "gateway"))
+			g.Expect(apicast.Spec.LogLevel).To(Equal(ptr.To(logLevel)))
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+	}
+
+	logLevelOverlay := func(logLevel string) *runtime.RawExtension {
+		return &runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"logLevel":%q}`, logLevel))}
+	}
+
+	BeforeEach(func(ctx SpecContext) {
+		err := testCreateAPIcastEmbeddedConfigurationSecret(ctx, testNamespace)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Should create the APIcast members", func(ctx SpecContext) {
+		createFleet(ctx,
+			appsv1alpha1.APIcastFleetMember{Name: "apicast-a"},
+			appsv1alpha1.APIcastFleetMember{Name: "apicast-b", Overlay: logLevelOverlay("debug")},
+		)
+
+		expectMember(ctx, "apicast-a", "info")
+		expectMember(ctx, "apicast-b", "debug")
+
+		Eventually(func(g Gomega) {
+			fleet := &appsv1alpha1.APIcastFleet{}
+			g.Expect(testClient().Get(ctx, fleetKey(), fleet)).To(Succeed())
+			g.Expect(fleet.Finalizers).To(ContainElement(appsv1alpha1.APIcastFleetFinalizer))
+			g.Expect(fleet.Status.DesiredMembers).To(Equal(int32(2)))
+			g.Expect(fleet.Status.Members).To(HaveLen(2))
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+	})
+
+	It("Should propagate an overlay change to the APIcast member", func(ctx SpecContext) {
+		createFleet(ctx, appsv1alpha1.APIcastFleetMember{Name: "apicast-a"})
+		expectMember(ctx, "apicast-a", "info")
+
+		updateFleet(ctx, func(fleet *appsv1alpha1.APIcastFleet) {
+			fleet.Spec.Members[0].Overlay = logLevelOverlay("warn")
+		})
+		expectMember(ctx, "apicast-a", "warn")
+
+		// the namespace overlay applies below the member overlay
+		updateFleet(ctx, func(fleet *appsv1alpha1.APIcastFleet) {
+			fleet.Spec.Members[0].Overlay = nil
+			fleet.Spec.NamespaceOverlays = []appsv1alpha1.APIcastFleetNamespaceOverlay{{
+				Namespace: testNamespace,
+				Overlay:   *logLevelOverlay("error"),
+			}}
+		})
+		expectMember(ctx, "apicast-a", "error")
+	})
+
+	It("Should delete the APIcasts removed from the fleet", func(ctx SpecContext) {
+		createFleet(ctx,
+			appsv1alpha1.APIcastFleetMember{Name: "apicast-a"},
+			appsv1alpha1.APIcastFleetMember{Name: "apicast-b"},
+		)
+		expectMember(ctx, "apicast-a", "info")
+		expectMember(ctx, "apicast-b", "info")
+
+		updateFleet(ctx, func(fleet *appsv1alpha1.APIcastFleet) {
+			fleet.Spec.Members = fleet.Spec.Members[:1]
+		})
+
+		Eventually(func(g Gomega) {
+			err := testClient().Get(ctx, memberKey("apicast-b"), &appsv1alpha1.APIcast{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+		Expect(testClient().Get(ctx, memberKey("apicast-a"), &appsv1alpha1.APIcast{})).To(Succeed())
+
+		// the remaining members are deleted with the fleet
+		fleet := &appsv1alpha1.APIcastFleet{}
+		Expect(testClient().Get(ctx, fleetKey(), fleet)).To(Succeed())
+		Expect(testClient().Delete(ctx, fleet)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			err := testClient().Get(ctx, memberKey("apicast-a"), &appsv1alpha1.APIcast{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			err = testClient().Get(ctx, fleetKey(), &appsv1alpha1.APIcastFleet{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+	})
+
+	It("Should not take over an APIcast that is not a member of the fleet", func(ctx SpecContext) {
+		apicast := &appsv1alpha1.APIcast{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "apicast-a",
+				Namespace: testNamespace,
+			},
+			Spec: appsv1alpha1.APIcastSpec{
+				EmbeddedConfigurationSecretRef: &v1.LocalObjectReference{
+					Name: testAPIcastEmbeddedConfigurationSecretName,
+				},
+				LogLevel: ptr.To("notice"),
+			},
+		}
+		Expect(testClient().Create(ctx, apicast)).To(Succeed())
+
+		createFleet(ctx, appsv1alpha1.APIcastFleetMember{Name: "apicast-a"})
+
+		Eventually(func(g Gomega) {
+			fleet := &appsv1alpha1.APIcastFleet{}
+			g.Expect(testClient().Get(ctx, fleetKey(), fleet)).To(Succeed())
+			g.Expect(fleet.Status.Members).To(HaveLen(1))
+			g.Expect(fleet.Status.Members[0].Ready).To(BeFalse())
+			g.Expect(fleet.Status.Members[0].Message).To(Equal(
+				fmt.Sprintf("APIcast %s already exists and is not a member of the fleet", memberKey("apicast-a"))))
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+
+		existing := &appsv1alpha1.APIcast{}
+		Expect(testClient().Get(ctx, memberKey("apicast-a"), existing)).To(Succeed())
+		Expect(existing.Labels).ToNot(HaveKey(appsv1alpha1.APIcastFleetNameLabel))
+		Expect(existing.Spec.LogLevel).To(Equal(ptr.To("notice")))
+
+		// deleting the fleet leaves the APIcast alone
+		fleet := &appsv1alpha1.APIcastFleet{}
+		Expect(testClient().Get(ctx, fleetKey(), fleet)).To(Succeed())
+		Expect(testClient().Delete(ctx, fleet)).To(Succeed())
+		Eventually(func(g Gomega) {
+			err := testClient().Get(ctx, fleetKey(), &appsv1alpha1.APIcastFleet{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}, 5*time.Minute, retryInterval).Should(Succeed())
+		Expect(testClient().Get(ctx, memberKey("apicast-a"), &appsv1alpha1.APIcast{})).To(Succeed())
+	})
+})
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -57,3 +58,28 @@ func DeleteNamespaceCallback(namespace *string) func() {
 		}, time.Minute, 5*time.Second).Should(BeTrue())
 	}
 }
+
+// UpdateDeploymentStatus sets the status of a deployment. There is no
+// deployment controller in the test environment running the pods.
+func UpdateDeploymentStatus(ctx context.Context, key types.NamespacedName, mutate func(*appsv1.DeploymentStatus)) {
+	Eventually(func(g Gomega) {
+		deployment := &appsv1.Deployment{}
+		g.Expect(testClient().Get(ctx, key, deployment)).To(Succeed())
+		deployment.Status.ObservedGeneration = deployment.Generation
+		mutate(&deployment.Status)
+		g.Expect(testClient().Status().Update(ctx, deployment)).To(Succeed())
+	}, 5*time.Minute, 5*time.Second).Should(Succeed())
+}
+
+// DeploymentAvailable reports all the replicas of the deployment as available
+func DeploymentAvailable(replicas int32) func(*appsv1.DeploymentStatus) {
+	return func(status *appsv1.DeploymentStatus) {
+		status.Replicas = replicas
+		status.UpdatedReplicas = replicas
+		status.ReadyReplicas = replicas
+		status.AvailableReplicas = replicas
+		status.Conditions = []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue, Reason: "MinimumReplicasAvailable"},
+		}
+	}
+}
@@ -9,14 +9,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
 )
 
 // SecretToApicastEventMapper is an EventHandler that maps secret object to apicast CR's
 type SecretToApicastEventMapper struct {
-	Context   context.Context
-	K8sClient client.Client
-	Logger    logr.Logger
-	Namespace string
+	Context    context.Context
+	K8sClient  client.Client
+	Logger     logr.Logger
+	Namespaces k8sutils.WatchedNamespaces
 }
 
 func (s *SecretToApicastEventMapper) Map(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -25,10 +26,8 @@ func (s *SecretToApicastEventMapper) Map(ctx context.Context, obj client.Object)
 	// filter by Secret UID
 	opts := []client.ListOption{client.HasLabels{apicastSecretLabelKey(string(obj.GetUID()))}}
 
-	// Support namespace scope or cluster scoped
-	if s.Namespace != "" {
-		opts = append(opts, client.InNamespace(s.Namespace))
-	}
+	// Support namespace scope, multiple namespaces or cluster scoped
+	opts = append(opts, s.Namespaces.ListOptions()...)
 
 	err := s.K8sClient.List(ctx, apicastList, opts...)
 	if err != nil {
@@ -19,9 +19,12 @@ package main
 import (
 	"flag"
 	"fmt"
+	routev1 "github.com/openshift/api/route/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	apimachinerymetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"os"
@@ -30,9 +33,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/3scale/apicast-operator/apis/apps/v1beta1"
 	appscontroller "github.com/3scale/apicast-operator/controllers/apps"
+	"github.com/3scale/apicast-operator/pkg/apicast"
 	"github.com/3scale/apicast-operator/pkg/k8sutils"
 	"github.com/3scale/apicast-operator/pkg/reconcilers"
 	"github.com/3scale/apicast-operator/version"
@@ -47,6 +54,11 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(routev1.Install(scheme))
+	utilruntime.Must(gatewayapiv1.Install(scheme))
+	utilruntime.Must(gatewayapiv1alpha2.Install(scheme))
+	utilruntime.Must(monitoringv1.AddToScheme(scheme))
 
 	// +kubebuilder:scaffold:scheme
 }
@@ -54,6 +66,7 @@ func init() {
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var operatorConfigFile string
 
 	// https://v1-2-x.sdk.operatorframework.io/docs/building-operators/golang/references/logging/#a-simple-example
 	// Add the zap logger flag set to the CLI. The flag set must
@@ -65,28 +78,87 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&operatorConfigFile, "config", "",
+		"Path of the operator configuration file with the organization-wide defaults of the APIcast resources.")
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&loggerOpts)))
 
 	printVersion()
 
-	namespace, err := getWatchNamespace()
+	operatorConfig, err := loadOperatorConfig(operatorConfigFile)
 	if err != nil {
-		setupLog.Error(err, "Failed to get watch namespace")
+		setupLog.Error(err, "unable to load the operator config")
 		os.Exit(1)
 	}
 
-	// If a watch namespace is detected (i.e. operator is namespace scoped), then pass the NS to cache.Options.DefaultNamespaces
+	namespaces, err := getWatchNamespaces()
+	if err != nil {
+		setupLog.Error(err, "Failed to get watch namespaces")
+		os.Exit(1)
+	}
+	setupLog.Info("Watching", "namespaces", namespaces.String())
+
+	// If watch namespaces are detected (i.e. operator is namespace scoped), then pass them to cache.Options.DefaultNamespaces
 	// If no watch namespace is detected (i.e. operator is cluster scoped), then pass an empty Cache object
-	var managerCache = cache.Options{}
-	if namespace != "" {
-		managerCache = cache.Options{
-			DefaultNamespaces: map[string]cache.Config{
-				namespace: {},
-			},
-		}
+	var managerCache = cache.Options{
+		DefaultNamespaces: namespaces.CacheNamespaces(),
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(ctrl.GetConfigOrDie())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+
+	routeAPIAvailable, err := k8sutils.HasKind(discoveryClient, routev1.GroupVersion.WithKind("Route"))
+	if err != nil {
+		setupLog.Error(err, "unable to check Route API availability")
+		os.Exit(1)
+	}
+	setupLog.Info("OpenShift Route API", "available", routeAPIAvailable)
+
+	httpRouteAPIAvailable, err := k8sutils.HasKind(discoveryClient, gatewayapiv1.SchemeGroupVersion.WithKind("HTTPRoute"))
+	if err != nil {
+		setupLog.Error(err, "unable to check HTTPRoute API availability")
+		os.Exit(1)
+	}
+	setupLog.Info("Gateway API HTTPRoute", "available", httpRouteAPIAvailable)
+
+	tlsRouteAPIAvailable, err := k8sutils.HasKind(discoveryClient, gatewayapiv1alpha2.SchemeGroupVersion.WithKind("TLSRoute"))
+	if err != nil {
+		setupLog.Error(err, "unable to check TLSRoute API availability")
+		os.Exit(1)
 	}
+	setupLog.Info("Gateway API TLSRoute", "available", tlsRouteAPIAvailable)
+
+	serviceMonitorAPIAvailable, err := k8sutils.HasKind(discoveryClient, monitoringv1.SchemeGroupVersion.WithKind(monitoringv1.ServiceMonitorsKind))
+	if err != nil {
+		setupLog.Error(err, "unable to check ServiceMonitor API availability")
+		os.Exit(1)
+	}
+	setupLog.Info("Prometheus Operator ServiceMonitor", "available", serviceMonitorAPIAvailable)
+
+	podMonitorAPIAvailable, err := k8sutils.HasKind(discoveryClient, monitoringv1.SchemeGroupVersion.WithKind(monitoringv1.PodMonitorsKind))
+	if err != nil {
+		setupLog.Error(err, "unable to check PodMonitor API availability")
+		os.Exit(1)
+	}
+	setupLog.Info("Prometheus Operator PodMonitor", "available", podMonitorAPIAvailable)
+
+	prometheusRuleAPIAvailable, err := k8sutils.HasKind(discoveryClient, monitoringv1.SchemeGroupVersion.WithKind(monitoringv1.PrometheusRuleKind))
+	if err != nil {
+		setupLog.Error(err, "unable to check PrometheusRule API availability")
+		os.Exit(1)
+	}
+	setupLog.Info("Prometheus Operator PrometheusRule", "available", prometheusRuleAPIAvailable)
+
+	grafanaDashboardAPIAvailable, err := k8sutils.HasKind(discoveryClient, apicast.GrafanaDashboardGVK)
+	if err != nil {
+		setupLog.Error(err, "unable to check GrafanaDashboard API availability")
+		os.Exit(1)
+	}
+	setupLog.Info("Grafana Operator GrafanaDashboard", "available", grafanaDashboardAPIAvailable)
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Cache:            managerCache,
@@ -112,14 +184,38 @@ func main() {
 	}
 
 	if err = (&appscontroller.APIcastReconciler{
-		BaseControllerReconciler: reconcilers.NewBaseControllerReconciler(mgr.GetClient(), mgr.GetAPIReader(), mgr.GetScheme()),
-		Log:                      ctrl.Log.WithName("controllers").WithName("APIcast"),
-		SecretLabelSelector:      *secretLabelSelector,
-		WatchedNamespace:         namespace,
+		BaseControllerReconciler:     reconcilers.NewBaseControllerReconciler(mgr.GetClient(), mgr.GetAPIReader(), mgr.GetScheme(), mgr.GetEventRecorderFor("apicast-operator")),
+		Log:                          ctrl.Log.WithName("controllers").WithName("APIcast"),
+		SecretLabelSelector:          *secretLabelSelector,
+		WatchedNamespaces:            namespaces,
+		RouteAPIAvailable:            routeAPIAvailable,
+		HTTPRouteAPIAvailable:        httpRouteAPIAvailable,
+		TLSRouteAPIAvailable:         tlsRouteAPIAvailable,
+		ServiceMonitorAPIAvailable:   serviceMonitorAPIAvailable,
+		PodMonitorAPIAvailable:       podMonitorAPIAvailable,
+		PrometheusRuleAPIAvailable:   prometheusRuleAPIAvailable,
+		GrafanaDashboardAPIAvailable: grafanaDashboardAPIAvailable,
+		OperatorConfig:               operatorConfig,
+		ImageResolver:                apicast.NewRegistryImageResolver(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "APIcast")
 		os.Exit(1)
 	}
+	if err = (&appscontroller.APIcastFleetReconciler{
+		BaseControllerReconciler: reconcilers.NewBaseControllerReconciler(mgr.GetClient(), mgr.GetAPIReader(), mgr.GetScheme(), mgr.GetEventRecorderFor("apicast-operator")),
+		Log:                      ctrl.Log.WithName("controllers").WithName("APIcastFleet"),
+		WatchedNamespaces:        namespaces,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "APIcastFleet")
+		os.Exit(1)
+	}
+	// Webhooks can be disabled when running the operator locally, without certificates
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&appsv1alpha1.APIcast{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "APIcast")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")
@@ -129,18 +225,24 @@ func main() {
 	}
 }
 
-// getWatchNamespace returns the Namespace the operator should be watching for changes
-func getWatchNamespace() (string, error) {
-	// WatchNamespaceEnvVar is the constant for env variable WATCH_NAMESPACE
-	// which specifies the Namespace to watch.
-	// An empty value means the operator is running with cluster scope.
-	var watchNamespaceEnvVar = "WATCH_NAMESPACE"
-
-	ns, found := os.LookupEnv(watchNamespaceEnvVar)
+// getWatchNamespaces returns the Namespaces the operator should be watching for changes
+func getWatchNamespaces() (k8sutils.WatchedNamespaces, error) {
+	ns, found := os.LookupEnv(k8sutils.WatchNamespaceEnvVar)
 	if !found {
-		return "", fmt.Errorf("%s must be set", watchNamespaceEnvVar)
+		return nil, fmt.Errorf("%s must be set", k8sutils.WatchNamespaceEnvVar)
 	}
-	return ns, nil
+	return k8sutils.ParseWatchNamespaces(ns), nil
+}
+
+// loadOperatorConfig returns the organization-wide defaults of the APIcast
+// resources. No file means no defaults.
+func loadOperatorConfig(path string) (*apicast.OperatorConfig, error) {
+	if path == "" {
+		return &apicast.OperatorConfig{}, nil
+	}
+
+	setupLog.Info("Loading operator config", "file", path)
+	return apicast.LoadOperatorConfig(path)
 }
 
 func printVersion() {
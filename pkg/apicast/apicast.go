@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"path"
 	"reflect"
@@ -16,6 +17,8 @@ import (
 	"github.com/3scale/apicast-operator/pkg/k8sutils"
 
 	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -23,15 +26,54 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 const (
-	AdminPortalURLAttributeName       = "AdminPortalURL"
-	DefaultManagementPort       int32 = 8090
-	DefaultMetricsPort          int32 = 9421
-	DefaultTracingLibrary             = "jaeger"
-	TracingConfigSecretKey            = "config"
+	AdminPortalURLAttributeName          = "AdminPortalURL"
+	DefaultManagementPort          int32 = 8090
+	DefaultMetricsPort             int32 = 9421
+	DefaultTracingLibrary                = "jaeger"
+	TracingConfigSecretKey               = "config"
+	DefaultProgressDeadlineSeconds int32 = 600
+	DefaultRevisionHistoryLimit    int32 = 10
+	// DefaultPodTerminationGracePeriodSeconds matches the Kubernetes default
+	DefaultPodTerminationGracePeriodSeconds int64 = 30
+)
+
+var (
+	DefaultMaxSurge       = intstr.FromString("25%")
+	DefaultMaxUnavailable = intstr.FromString("25%")
+)
+
+var (
+	DefaultLivenessProbe = ProbeOptions{
+		InitialDelaySeconds: 10,
+		TimeoutSeconds:      5,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+	}
+	DefaultReadinessProbe = ProbeOptions{
+		InitialDelaySeconds: 15,
+		TimeoutSeconds:      5,
+		PeriodSeconds:       30,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+	}
+	// DefaultStartupProbe allows APIcast up to 5 minutes to boot, when the
+	// startup probe is enabled
+	DefaultStartupProbe = ProbeOptions{
+		InitialDelaySeconds: 0,
+		TimeoutSeconds:      5,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    30,
+	}
 )
 
 const (
@@ -71,8 +113,10 @@ func NewAPIcast(opts *APIcastOptions) *APIcast {
 	return &APIcast{options: opts}
 }
 
-func Factory(ctx context.Context, cr *appsv1alpha1.APIcast, cl client.Client) (*APIcast, error) {
+func Factory(ctx context.Context, cr *appsv1alpha1.APIcast, cl client.Client, operatorConfig *OperatorConfig, imageResolver ImageResolver) (*APIcast, error) {
 	optsProvider := NewApicastOptionsProvider(cr, cl)
+	optsProvider.OperatorConfig = operatorConfig
+	optsProvider.ImageResolver = imageResolver
 	opts, err := optsProvider.GetApicastOptions(ctx)
 	if err != nil {
 		return nil, err
@@ -80,6 +124,12 @@ func Factory(ctx context.Context, cr *appsv1alpha1.APIcast, cl client.Client) (*
 	return NewAPIcast(opts), nil
 }
 
+// ResolvedImage returns the digest the image was resolved to, nil when image
+// digest pinning is disabled
+func (a *APIcast) ResolvedImage() *appsv1alpha1.ResolvedImageStatus {
+	return a.options.ResolvedImage
+}
+
 func (a *APIcast) deploymentVolumeMounts() []v1.VolumeMount {
 	var volumeMounts []v1.VolumeMount
 	if a.options.GatewayConfigurationSecret != nil {
@@ -138,6 +188,8 @@ func (a *APIcast) deploymentVolumeMounts() []v1.VolumeMount {
 		})
 	}
 
+	volumeMounts = append(volumeMounts, a.options.ExtraVolumeMounts...)
+
 	return volumeMounts
 }
 
@@ -153,6 +205,25 @@ func tracingConfigVolumeName(tracingLibrary, secretName string) string {
 	return fmt.Sprintf("tracing-config-%s-%s", tracingLibrary, secretName)
 }
 
+// podVolumes returns the volumes managed by the operator followed by the extra volumes
+func (a *APIcast) podVolumes() ([]v1.Volume, error) {
+	volumes := a.deploymentVolumes()
+
+	managedVolumes := map[string]bool{}
+	for _, volume := range volumes {
+		managedVolumes[volume.Name] = true
+	}
+
+	for _, volume := range a.options.ExtraVolumes {
+		if managedVolumes[volume.Name] {
+			return nil, fmt.Errorf("extra volume %q clashes with a volume managed by the operator", volume.Name)
+		}
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, nil
+}
+
 func (a *APIcast) deploymentVolumes() []v1.Volume {
 	var volumes []v1.Volume
 	if a.options.GatewayConfigurationSecret != nil {
@@ -417,12 +488,27 @@ func (a *APIcast) deploymentEnv() []v1.EnvVar {
 	return env
 }
 
+// Deployment returns the stable deployment. Its pods carry the stable track
+// label, so the deployment does not select the canary pods.
 func (a *APIcast) Deployment(ctx context.Context, k8sclient client.Client) (*appsv1.Deployment, error) {
+	stableOptions := *a.options
+	stableOptions.PodLabelSelector = trackLabels(a.options.PodLabelSelector, StableTrackLabelValue)
+	stableOptions.PodTemplateLabels = trackLabels(a.options.PodTemplateLabels, StableTrackLabelValue)
+
+	return NewAPIcast(&stableOptions).deployment(ctx, k8sclient)
+}
+
+func (a *APIcast) deployment(ctx context.Context, k8sclient client.Client) (*appsv1.Deployment, error) {
 	watchedSecretAnnotations, err := a.computeWatchedSecretAnnotations(ctx, k8sclient)
 	if err != nil {
 		return nil, err
 	}
 
+	volumes, err := a.podVolumes()
+	if err != nil {
+		return nil, err
+	}
+
 	deployment := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "apps/v1",
@@ -437,9 +523,10 @@ func (a *APIcast) Deployment(ctx context.Context, k8sclient client.Client) (*app
 			Selector: &metav1.LabelSelector{
 				MatchLabels: a.options.PodLabelSelector,
 			},
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
-			},
+			Strategy:                *a.options.Rollout.Strategy.DeepCopy(),
+			MinReadySeconds:         a.options.Rollout.MinReadySeconds,
+			ProgressDeadlineSeconds: ptr.To(a.options.Rollout.ProgressDeadlineSeconds),
+			RevisionHistoryLimit:    ptr.To(a.options.Rollout.RevisionHistoryLimit),
 			Template: v1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      a.options.PodTemplateLabels,
@@ -449,29 +536,42 @@ func (a *APIcast) Deployment(ctx context.Context, k8sclient client.Client) (*app
 					Affinity:           a.options.Affinity,
 					Tolerations:        a.options.Tolerations,
 					ServiceAccountName: a.options.ServiceAccountName,
-					Volumes:            a.deploymentVolumes(),
+					ImagePullSecrets:   a.options.ImagePullSecrets,
+					Volumes:            volumes,
+					InitContainers:     a.options.InitContainers,
 					Containers: []v1.Container{
 						{
 							Name:            a.options.DeploymentName,
 							Ports:           a.containerPorts(),
 							Image:           a.options.Image,
-							ImagePullPolicy: v1.PullAlways, // This is different than the currently used which is IfNotPresent
+							ImagePullPolicy: a.options.ImagePullPolicy,
 							Resources:       a.options.ResourceRequirements,
 							LivenessProbe:   a.livenessProbe(),
 							ReadinessProbe:  a.readinessProbe(),
+							StartupProbe:    a.startupProbe(),
+							Lifecycle:       a.lifecycle(),
 							VolumeMounts:    a.deploymentVolumeMounts(),
 							// Env takes precedence with respect to EnvFrom on duplicated
 							// var values
 							Env: a.deploymentEnv(),
 						},
 					},
-					TopologySpreadConstraints: a.options.TopologySpreadConstraints,
-					PriorityClassName:         a.options.PriorityClassName,
+					TopologySpreadConstraints:     a.options.TopologySpreadConstraints,
+					PriorityClassName:             a.options.PriorityClassName,
+					TerminationGracePeriodSeconds: ptr.To(a.options.GracefulShutdown.TerminationGracePeriodSeconds),
 				},
 			},
 		},
 	}
 
+	// The APIcast container is always the first one, mutators rely on it
+	deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers, a.options.Sidecars...)
+
+	err = a.applyPodTemplateOverride(deployment)
+	if err != nil {
+		return nil, err
+	}
+
 	// Only configure deployment replicas when HPA is disabled, otherwise, HPA is in charge of replicas count
 	if !a.options.Hpa {
 		deployment.Spec.Replicas = &a.options.Replicas
@@ -481,6 +581,41 @@ func (a *APIcast) Deployment(ctx context.Context, k8sclient client.Client) (*app
 	return deployment, nil
 }
 
+// applyPodTemplateOverride applies the pod template override strategic merge patch
+// on top of the generated pod template. The hash of the override is kept in a pod
+// template annotation, so the deployment mutator can detect override changes.
+func (a *APIcast) applyPodTemplateOverride(deployment *appsv1.Deployment) error {
+	if len(a.options.PodTemplateOverride) == 0 {
+		return nil
+	}
+
+	original, err := json.Marshal(deployment.Spec.Template)
+	if err != nil {
+		return err
+	}
+
+	patched, err := strategicpatch.StrategicMergePatch(original, a.options.PodTemplateOverride, v1.PodTemplateSpec{})
+	if err != nil {
+		return fmt.Errorf("applying podTemplateOverride: %w", err)
+	}
+
+	template := v1.PodTemplateSpec{}
+	err = json.Unmarshal(patched, &template)
+	if err != nil {
+		return fmt.Errorf("applying podTemplateOverride: %w", err)
+	}
+
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	hash := sha256.Sum256(a.options.PodTemplateOverride)
+	template.Annotations[k8sutils.PodTemplateOverrideHashAnnotation] = hex.EncodeToString(hash[:])
+
+	deployment.Spec.Template = template
+
+	return nil
+}
+
 func (a *APIcast) computeWatchedSecretAnnotations(ctx context.Context, k8sclient client.Client) (map[string]string, error) {
 	// First get the initial annotations
 	uncheckedAnnotations, err := a.getWatchedSecretAnnotations(ctx, k8sclient)
@@ -604,11 +739,14 @@ func (a *APIcast) getWatchedSecretAnnotations(ctx context.Context, k8sclient cli
 }
 
 func (a *APIcast) podAnnotations(watchedSecretAnnotations map[string]string) map[string]string {
-	annotations := map[string]string{
-		"prometheus.io/scrape": "true",
-		"prometheus.io/port":   "9421",
+	annotations := map[string]string{}
+	for key, val := range a.options.PodTemplateAnnotations {
+		annotations[key] = val
 	}
 
+	annotations["prometheus.io/scrape"] = "true"
+	annotations["prometheus.io/port"] = "9421"
+
 	for key, val := range watchedSecretAnnotations {
 		annotations[key] = val
 	}
@@ -625,7 +763,7 @@ func (a *APIcast) Service() *v1.Service {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      a.options.ServiceName,
 			Namespace: a.options.Namespace,
-			Labels:    a.options.CommonLabels,
+			Labels:    a.serviceLabels(),
 		},
 		Spec: v1.ServiceSpec{
 			Ports:    a.servicePorts(),
@@ -637,6 +775,19 @@ func (a *APIcast) Service() *v1.Service {
 	return service
 }
 
+// serviceLabels adds the pod label selector to the common labels, so the
+// service can be selected by the ServiceMonitor of its APIcast only
+func (a *APIcast) serviceLabels() map[string]string {
+	labels := map[string]string{}
+	for k, v := range a.options.CommonLabels {
+		labels[k] = v
+	}
+	for k, v := range a.options.PodLabelSelector {
+		labels[k] = v
+	}
+	return labels
+}
+
 func (a *APIcast) servicePorts() []v1.ServicePort {
 	servicePorts := []v1.ServicePort{
 		{Name: "proxy", Port: appsv1alpha1.DefaultHTTPPort, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromString("proxy")},
@@ -648,6 +799,11 @@ func (a *APIcast) servicePorts() []v1.ServicePort {
 			v1.ServicePort{Name: "httpsproxy", Port: *a.options.HTTPSPort, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromString("httpsproxy")})
 	}
 
+	if a.options.Monitoring.Enabled {
+		servicePorts = append(servicePorts,
+			v1.ServicePort{Name: "metrics", Port: DefaultMetricsPort, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromString("metrics")})
+	}
+
 	return servicePorts
 }
 
@@ -667,30 +823,55 @@ func (a *APIcast) containerPorts() []v1.ContainerPort {
 }
 
 func (a *APIcast) livenessProbe() *v1.Probe {
-	return &v1.Probe{
-		ProbeHandler: v1.ProbeHandler{
-			HTTPGet: &v1.HTTPGetAction{
-				Path: "/status/live",
-				Port: intstr.FromInt32(8090),
-			},
+	return managementProbe("/status/live", a.options.Probes.Liveness)
+}
+
+func (a *APIcast) readinessProbe() *v1.Probe {
+	return managementProbe("/status/ready", a.options.Probes.Readiness)
+}
+
+// startupProbe holds off the liveness and readiness probes until APIcast has
+// booted, which might take long with large embedded configurations
+func (a *APIcast) startupProbe() *v1.Probe {
+	if a.options.Probes.Startup == nil {
+		return nil
+	}
+	return managementProbe("/status/live", *a.options.Probes.Startup)
+}
+
+// lifecycle returns the APIcast container preStop hook when graceful shutdown
+// is enabled. The hook keeps APIcast serving while the terminating pod is removed
+// from the service endpoints, then makes nginx stop accepting connections and
+// waits for the in-flight requests to finish.
+func (a *APIcast) lifecycle() *v1.Lifecycle {
+	if !a.options.GracefulShutdown.Enabled {
+		return nil
+	}
+
+	drainCommand := fmt.Sprintf("sleep %d; kill -QUIT 1; while kill -0 1 2>/dev/null; do sleep 1; done", a.options.GracefulShutdown.PreStopDelaySeconds)
+	return &v1.Lifecycle{
+		PreStop: &v1.LifecycleHandler{
+			Exec: &v1.ExecAction{Command: []string{"/bin/sh", "-c", drainCommand}},
 		},
-		InitialDelaySeconds: 10,
-		TimeoutSeconds:      5,
-		PeriodSeconds:       10,
 	}
 }
 
-func (a *APIcast) readinessProbe() *v1.Probe {
+// managementProbe returns a probe on the management port. Every field is set
+// explicitly, so the probe matches the one defaulted by the API server.
+func managementProbe(path string, opts ProbeOptions) *v1.Probe {
 	return &v1.Probe{
 		ProbeHandler: v1.ProbeHandler{
 			HTTPGet: &v1.HTTPGetAction{
-				Path: "/status/ready",
-				Port: intstr.FromInt32(8090),
+				Path:   path,
+				Port:   intstr.FromString("management"),
+				Scheme: v1.URISchemeHTTP,
 			},
 		},
-		InitialDelaySeconds: 15,
-		TimeoutSeconds:      5,
-		PeriodSeconds:       30,
+		InitialDelaySeconds: opts.InitialDelaySeconds,
+		TimeoutSeconds:      opts.TimeoutSeconds,
+		PeriodSeconds:       opts.PeriodSeconds,
+		SuccessThreshold:    opts.SuccessThreshold,
+		FailureThreshold:    opts.FailureThreshold,
 	}
 }
 
@@ -738,6 +919,178 @@ func (a *APIcast) Ingress() *networkingv1.Ingress {
 	return ingress
 }
 
+func (a *APIcast) Route() *routev1.Route {
+	targetPort := "proxy"
+	var tls *routev1.TLSConfig
+
+	if route := a.options.ExposedHost.Route; route != nil && route.Termination != nil {
+		tls = &routev1.TLSConfig{Termination: *route.Termination}
+		if route.InsecureEdgeTerminationPolicy != nil {
+			tls.InsecureEdgeTerminationPolicy = *route.InsecureEdgeTerminationPolicy
+		}
+
+		// edge terminated traffic reaches APIcast as plain HTTP
+		if *route.Termination != routev1.TLSTerminationEdge {
+			targetPort = "httpsproxy"
+		}
+	}
+
+	wildcardPolicy := routev1.WildcardPolicyNone
+	if a.options.ExposedHost.Route != nil {
+		wildcardPolicy = a.options.ExposedHost.Route.WildcardPolicy
+	}
+
+	weight := int32(100)
+
+	route := &routev1.Route{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "route.openshift.io/v1",
+			Kind:       "Route",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.options.DeploymentName,
+			Namespace: a.options.Namespace,
+			Labels:    a.options.CommonLabels,
+		},
+		Spec: routev1.RouteSpec{
+			Host: a.options.ExposedHost.Host,
+			To: routev1.RouteTargetReference{
+				Kind:   "Service",
+				Name:   a.options.ServiceName,
+				Weight: &weight,
+			},
+			Port: &routev1.RoutePort{
+				TargetPort: intstr.FromString(targetPort),
+			},
+			TLS:            tls,
+			WildcardPolicy: wildcardPolicy,
+		},
+	}
+
+	addOwnerRefToObject(route, *a.options.Owner)
+	return route
+}
+
+func (a *APIcast) HTTPRoute() *gatewayapiv1.HTTPRoute {
+	var gateway ExposedHostGateway
+	if a.options.ExposedHost.Gateway != nil {
+		gateway = *a.options.ExposedHost.Gateway
+	}
+
+	pathMatches := gateway.PathMatches
+	if len(pathMatches) == 0 {
+		pathMatches = []gatewayapiv1.HTTPPathMatch{{}}
+	}
+
+	matches := make([]gatewayapiv1.HTTPRouteMatch, 0, len(pathMatches))
+	for idx := range pathMatches {
+		// Fields defaulted by the API server are set to avoid endless updates
+		pathMatch := pathMatches[idx].DeepCopy()
+		if pathMatch.Type == nil {
+			pathType := gatewayapiv1.PathMatchPathPrefix
+			pathMatch.Type = &pathType
+		}
+		if pathMatch.Value == nil {
+			pathMatch.Value = ptr.To("/")
+		}
+		matches = append(matches, gatewayapiv1.HTTPRouteMatch{Path: pathMatch})
+	}
+
+	httpRoute := &gatewayapiv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gatewayapiv1.GroupVersion.String(),
+			Kind:       "HTTPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.options.DeploymentName,
+			Namespace: a.options.Namespace,
+			Labels:    a.options.CommonLabels,
+		},
+		Spec: gatewayapiv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayapiv1.CommonRouteSpec{
+				ParentRefs: gatewayParentRefs(gateway.ParentRefs),
+			},
+			Hostnames: gateway.Hostnames,
+			Rules: []gatewayapiv1.HTTPRouteRule{
+				{
+					Matches: matches,
+					BackendRefs: []gatewayapiv1.HTTPBackendRef{
+						{BackendRef: a.gatewayBackendRef(appsv1alpha1.DefaultHTTPPort)},
+					},
+				},
+			},
+		},
+	}
+
+	addOwnerRefToObject(httpRoute, *a.options.Owner)
+	return httpRoute
+}
+
+func (a *APIcast) TLSRoute() *gatewayapiv1alpha2.TLSRoute {
+	var gateway ExposedHostGateway
+	if a.options.ExposedHost.Gateway != nil {
+		gateway = *a.options.ExposedHost.Gateway
+	}
+
+	var backendRefs []gatewayapiv1.BackendRef
+	if a.options.HTTPSPort != nil {
+		backendRefs = append(backendRefs, a.gatewayBackendRef(*a.options.HTTPSPort))
+	}
+
+	tlsRoute := &gatewayapiv1alpha2.TLSRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gatewayapiv1alpha2.GroupVersion.String(),
+			Kind:       "TLSRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.options.DeploymentName,
+			Namespace: a.options.Namespace,
+			Labels:    a.options.CommonLabels,
+		},
+		Spec: gatewayapiv1alpha2.TLSRouteSpec{
+			CommonRouteSpec: gatewayapiv1.CommonRouteSpec{
+				ParentRefs: gatewayParentRefs(gateway.TLSRouteParentRefs),
+			},
+			Hostnames: gateway.Hostnames,
+			Rules: []gatewayapiv1alpha2.TLSRouteRule{
+				{BackendRefs: backendRefs},
+			},
+		},
+	}
+
+	addOwnerRefToObject(tlsRoute, *a.options.Owner)
+	return tlsRoute
+}
+
+func (a *APIcast) gatewayBackendRef(port int32) gatewayapiv1.BackendRef {
+	return gatewayapiv1.BackendRef{
+		BackendObjectReference: gatewayapiv1.BackendObjectReference{
+			Group: ptr.To(gatewayapiv1.Group("")),
+			Kind:  ptr.To(gatewayapiv1.Kind("Service")),
+			Name:  gatewayapiv1.ObjectName(a.options.ServiceName),
+			Port:  ptr.To(gatewayapiv1.PortNumber(port)),
+		},
+		Weight: ptr.To(int32(1)),
+	}
+}
+
+// gatewayParentRefs returns the parent references with the fields defaulted
+// by the API server set, to avoid endless updates
+func gatewayParentRefs(parentRefs []gatewayapiv1.ParentReference) []gatewayapiv1.ParentReference {
+	res := make([]gatewayapiv1.ParentReference, 0, len(parentRefs))
+	for idx := range parentRefs {
+		parentRef := parentRefs[idx].DeepCopy()
+		if parentRef.Group == nil {
+			parentRef.Group = ptr.To(gatewayapiv1.Group(gatewayapiv1.GroupName))
+		}
+		if parentRef.Kind == nil {
+			parentRef.Kind = ptr.To(gatewayapiv1.Kind("Gateway"))
+		}
+		res = append(res, *parentRef)
+	}
+	return res
+}
+
 func (a *APIcast) HashedSecret(ctx context.Context, k8sclient client.Client, secretRefs []*v1.LocalObjectReference) (*v1.Secret, error) {
 	hashedSecretData, err := a.computeHashedSecretData(ctx, k8sclient, secretRefs)
 	if err != nil {
@@ -860,14 +1213,96 @@ func (a *APIcast) PodDisruptionBudget() *policyv1.PodDisruptionBudget {
 		},
 
 		Spec: policyv1.PodDisruptionBudgetSpec{
+			// The canary pods are left out, the canary deployment is
+			// short lived and must not block the eviction of stable pods
 			Selector: &metav1.LabelSelector{
 				MatchLabels: a.options.PodLabelSelector,
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: TrackLabel, Operator: metav1.LabelSelectorOpNotIn, Values: []string{CanaryTrackLabelValue}},
+				},
 			},
 			MaxUnavailable: &intstr.IntOrString{IntVal: PDB_MAX_UNAVAILABLE_POD_NUMBER},
 		},
 	}
 }
 
+func (a *APIcast) ServiceMonitor() *monitoringv1.ServiceMonitor {
+	serviceMonitor := &monitoringv1.ServiceMonitor{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: monitoringv1.SchemeGroupVersion.String(),
+			Kind:       monitoringv1.ServiceMonitorsKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.options.DeploymentName,
+			Namespace: a.options.Namespace,
+			Labels:    a.monitorLabels(),
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Endpoints: []monitoringv1.Endpoint{
+				{
+					Port:           "metrics",
+					Path:           "/metrics",
+					Scheme:         "http",
+					Interval:       a.options.Monitoring.Interval,
+					ScrapeTimeout:  a.options.Monitoring.ScrapeTimeout,
+					RelabelConfigs: a.options.Monitoring.Relabelings,
+				},
+			},
+			Selector: metav1.LabelSelector{
+				MatchLabels: a.options.PodLabelSelector,
+			},
+		},
+	}
+
+	addOwnerRefToObject(serviceMonitor, *a.options.Owner)
+	return serviceMonitor
+}
+
+func (a *APIcast) PodMonitor() *monitoringv1.PodMonitor {
+	podMonitor := &monitoringv1.PodMonitor{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: monitoringv1.SchemeGroupVersion.String(),
+			Kind:       monitoringv1.PodMonitorsKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.options.DeploymentName,
+			Namespace: a.options.Namespace,
+			Labels:    a.monitorLabels(),
+		},
+		Spec: monitoringv1.PodMonitorSpec{
+			PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{
+				{
+					Port:           "metrics",
+					Path:           "/metrics",
+					Scheme:         "http",
+					Interval:       a.options.Monitoring.Interval,
+					ScrapeTimeout:  a.options.Monitoring.ScrapeTimeout,
+					RelabelConfigs: a.options.Monitoring.Relabelings,
+				},
+			},
+			Selector: metav1.LabelSelector{
+				MatchLabels: a.options.PodLabelSelector,
+			},
+		},
+	}
+
+	addOwnerRefToObject(podMonitor, *a.options.Owner)
+	return podMonitor
+}
+
+// monitorLabels adds the user provided labels, used by the Prometheus
+// instance to select the monitors, to the common labels
+func (a *APIcast) monitorLabels() map[string]string {
+	labels := map[string]string{}
+	for k, v := range a.options.Monitoring.Labels {
+		labels[k] = v
+	}
+	for k, v := range a.options.CommonLabels {
+		labels[k] = v
+	}
+	return labels
+}
+
 func addOwnerRefToObject(o metav1.Object, owner metav1.OwnerReference) {
 	o.SetOwnerReferences(append(o.GetOwnerReferences(), owner))
 }
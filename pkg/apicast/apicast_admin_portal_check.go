@@ -0,0 +1,184 @@
+package apicast
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	// DefaultDeploymentEnvironment is the 3scale environment APIcast loads when
+	// the deployment environment is not set
+	DefaultDeploymentEnvironment = "production"
+	adminPortalCheckTimeout      = 10 * time.Second
+)
+
+const (
+	AdminPortalReachableReason        = "Reachable"
+	AdminPortalUnauthorizedReason     = "Unauthorized"
+	AdminPortalUnexpectedStatusReason = "UnexpectedStatus"
+	AdminPortalTLSErrorReason         = "TLSError"
+	AdminPortalConnectionErrorReason  = "ConnectionError"
+	AdminPortalInvalidSettingsReason  = "InvalidSettings"
+)
+
+// AdminPortalCheckResult is the outcome of the admin portal connectivity check
+type AdminPortalCheckResult struct {
+	Reachable bool
+	Reason    string
+	Message   string
+}
+
+// AdminPortalCheck returns the admin portal connectivity check options
+func (a *APIcast) AdminPortalCheck() AdminPortalCheckOptions {
+	return a.options.AdminPortalCheck
+}
+
+// CheckAdminPortal requests the proxy configs endpoint of the admin portal
+// the way APIcast does: with the access token of the admin portal URL, through
+// the configured proxies and trusting the configured CA bundle. TLS peers are
+// only verified when OpenSSL peer verification is enabled.
+func (a *APIcast) CheckAdminPortal(ctx context.Context) AdminPortalCheckResult {
+	if a.options.AdminPortalCredentialsSecret == nil {
+		return AdminPortalCheckResult{Reason: AdminPortalInvalidSettingsReason, Message: "admin portal credentials not set"}
+	}
+
+	endpoint, err := a.adminPortalProxyConfigsURL()
+	if err != nil {
+		return AdminPortalCheckResult{Reason: AdminPortalInvalidSettingsReason, Message: err.Error()}
+	}
+
+	transport, err := a.adminPortalTransport()
+	if err != nil {
+		return AdminPortalCheckResult{Reason: AdminPortalInvalidSettingsReason, Message: err.Error()}
+	}
+	// the transport is built for each check, its connections are not reused
+	defer transport.CloseIdleConnections()
+
+	ctx, cancel := context.WithTimeout(ctx, adminPortalCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return AdminPortalCheckResult{Reason: AdminPortalInvalidSettingsReason, Message: err.Error()}
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		// the request URL carries the access token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		if isTLSError(err) {
+			return AdminPortalCheckResult{Reason: AdminPortalTLSErrorReason, Message: err.Error()}
+		}
+		return AdminPortalCheckResult{Reason: AdminPortalConnectionErrorReason, Message: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	message := fmt.Sprintf("HTTP %s from %s", resp.Status, endpoint.Host)
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return AdminPortalCheckResult{Reachable: true, Reason: AdminPortalReachableReason, Message: message}
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return AdminPortalCheckResult{Reason: AdminPortalUnauthorizedReason, Message: message}
+	default:
+		return AdminPortalCheckResult{Reason: AdminPortalUnexpectedStatusReason, Message: message}
+	}
+}
+
+// adminPortalProxyConfigsURL returns the proxy configs endpoint of the
+// deployment environment, authenticated with the access token of the admin
+// portal URL
+func (a *APIcast) adminPortalProxyConfigsURL() (*url.URL, error) {
+	adminPortalURL, ok := a.options.AdminPortalCredentialsSecret.Data[AdminPortalURLAttributeName]
+	if !ok {
+		return nil, fmt.Errorf("required key '%s' not found in secret '%s'", AdminPortalURLAttributeName, a.options.AdminPortalCredentialsSecret.Name)
+	}
+
+	endpoint, err := url.Parse(string(adminPortalURL))
+	if err != nil {
+		// the parse error includes the access token
+		return nil, fmt.Errorf("invalid %s URL", AdminPortalURLAttributeName)
+	}
+
+	environment := DefaultDeploymentEnvironment
+	if a.options.DeploymentEnvironment != nil {
+		environment = *a.options.DeploymentEnvironment
+	}
+
+	query := url.Values{}
+	query.Set("access_token", endpoint.User.Username())
+	query.Set("per_page", strconv.Itoa(1))
+
+	endpoint.User = nil
+	endpoint.Path = path.Join("/", endpoint.Path, "admin/api/account/proxy_configs", environment+".json")
+	endpoint.RawQuery = query.Encode()
+
+	return endpoint, nil
+}
+
+func (a *APIcast) adminPortalTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	proxyConfig := httpproxy.Config{}
+	if a.options.AllProxy != nil {
+		proxyConfig.HTTPProxy = *a.options.AllProxy
+		proxyConfig.HTTPSProxy = *a.options.AllProxy
+	}
+	if a.options.HTTPProxy != nil {
+		proxyConfig.HTTPProxy = *a.options.HTTPProxy
+	}
+	if a.options.HTTPSProxy != nil {
+		proxyConfig.HTTPSProxy = *a.options.HTTPSProxy
+	}
+	if a.options.NoProxy != nil {
+		proxyConfig.NoProxy = *a.options.NoProxy
+	}
+	proxyFunc := proxyConfig.ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: a.options.OpenSSLPeerVerificationEnabled == nil || !*a.options.OpenSSLPeerVerificationEnabled,
+	}
+	if a.options.CACertificateSecret != nil {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(a.options.CACertificateSecret.Data[CACertificatesSecretKey]) {
+			return nil, fmt.Errorf("no certificates found in key '%s' of secret '%s'", CACertificatesSecretKey, a.options.CACertificateSecret.Name)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+func isTLSError(err error) bool {
+	var verificationErr *tls.CertificateVerificationError
+	var recordHeaderErr tls.RecordHeaderError
+	var alertErr tls.AlertError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certificateInvalidErr x509.CertificateInvalidError
+	return errors.As(err, &verificationErr) ||
+		errors.As(err, &recordHeaderErr) ||
+		errors.As(err, &alertErr) ||
+		errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &certificateInvalidErr)
+}
@@ -0,0 +1,172 @@
+//go:build unit
+
+package apicast
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8s.io/utils/ptr"
+)
+
+func testAdminPortalCheckOpts(adminPortalURL string) *APIcastOptions {
+	opts := testDefaultOpts()
+	opts.AdminPortalCredentialsSecret = GetTestSecret(opts.Namespace, "my-secret", map[string]string{AdminPortalURLAttributeName: adminPortalURL})
+	opts.AdminPortalCheck = AdminPortalCheckOptions{Enabled: true, IntervalSeconds: 60}
+	return opts
+}
+
+func TestAPIcastCheckAdminPortal(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Query().Get("access_token") != "valid-token":
+			w.WriteHeader(http.StatusForbidden)
+		case r.URL.Path != "/admin/api/account/proxy_configs/staging.json":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Write([]byte(`{"proxy_configs":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	cases := []struct {
+		testName       string
+		adminPortalURL string
+		expectedReason string
+	}{
+		{"Reachable", strings.Replace(srv.URL, "http://", "http://valid-token@", 1), AdminPortalReachableReason},
+		{"InvalidToken", strings.Replace(srv.URL, "http://", "http://invalid-token@", 1), AdminPortalUnauthorizedReason},
+		{"UnexpectedStatus", strings.Replace(srv.URL, "http://", "http://valid-token@", 1) + "/unknown", AdminPortalUnexpectedStatusReason},
+		{"ConnectionError", "http://valid-token@127.0.0.1:1", AdminPortalConnectionErrorReason},
+		{"InvalidURL", "http://valid-token@%zz", AdminPortalInvalidSettingsReason},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			opts := testAdminPortalCheckOpts(tc.adminPortalURL)
+			opts.DeploymentEnvironment = ptr.To("staging")
+
+			result := NewAPIcast(opts).CheckAdminPortal(context.TODO())
+			if result.Reason != tc.expectedReason {
+				subT.Fatalf("expected reason %s, got %+v", tc.expectedReason, result)
+			}
+			if result.Reachable != (tc.expectedReason == AdminPortalReachableReason) {
+				subT.Errorf("unexpected reachable value: %+v", result)
+			}
+			if strings.Contains(result.Message, "valid-token") {
+				subT.Errorf("the message must not include the access token: %s", result.Message)
+			}
+		})
+	}
+}
+
+func TestAPIcastCheckAdminPortalTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"proxy_configs":[]}`))
+	}))
+	defer srv.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	adminPortalURL := strings.Replace(srv.URL, "https://", "https://token@", 1)
+
+	cases := []struct {
+		testName       string
+		peerVerify     *bool
+		caBundle       string
+		expectedReason string
+	}{
+		{"VerificationDisabled", nil, "", AdminPortalReachableReason},
+		{"UnknownAuthority", ptr.To(true), "", AdminPortalTLSErrorReason},
+		{"TrustedCABundle", ptr.To(true), string(caBundle), AdminPortalReachableReason},
+		{"InvalidCABundle", ptr.To(true), "not a certificate", AdminPortalInvalidSettingsReason},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			opts := testAdminPortalCheckOpts(adminPortalURL)
+			opts.OpenSSLPeerVerificationEnabled = tc.peerVerify
+			if tc.caBundle != "" {
+				opts.CACertificateSecret = GetTestSecret(opts.Namespace, "my-ca", map[string]string{CACertificatesSecretKey: tc.caBundle})
+			}
+
+			result := NewAPIcast(opts).CheckAdminPortal(context.TODO())
+			if result.Reason != tc.expectedReason {
+				subT.Fatalf("expected reason %s, got %+v", tc.expectedReason, result)
+			}
+		})
+	}
+}
+
+func TestAPIcastCheckAdminPortalProxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		w.Write([]byte(`{"proxy_configs":[]}`))
+	}))
+	defer proxy.Close()
+
+	// loopback addresses are never proxied
+	opts := testAdminPortalCheckOpts("http://token@3scale-admin.example.com")
+	opts.HTTPProxy = ptr.To(proxy.URL)
+
+	result := NewAPIcast(opts).CheckAdminPortal(context.TODO())
+	if !result.Reachable {
+		t.Fatalf("expected the admin portal to be reachable through the proxy, got %+v", result)
+	}
+	if proxiedHost != "3scale-admin.example.com" {
+		t.Errorf("unexpected proxied host: %s", proxiedHost)
+	}
+}
+
+func TestAPIcastAdminPortalTransportProxy(t *testing.T) {
+	cases := []struct {
+		testName      string
+		allProxy      *string
+		httpProxy     *string
+		httpsProxy    *string
+		noProxy       *string
+		expectedProxy string
+	}{
+		{"NoProxy", nil, nil, nil, nil, ""},
+		{"HTTPSProxy", nil, ptr.To("http://http-proxy:8080"), ptr.To("http://https-proxy:8443"), nil, "https-proxy:8443"},
+		{"AllProxy", ptr.To("http://all-proxy:3128"), nil, nil, nil, "all-proxy:3128"},
+		{"HTTPSProxyOverridesAllProxy", ptr.To("http://all-proxy:3128"), nil, ptr.To("http://https-proxy:8443"), nil, "https-proxy:8443"},
+		{"Excluded", nil, nil, ptr.To("http://https-proxy:8443"), ptr.To("example.com"), ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			opts := testAdminPortalCheckOpts("https://token@3scale-admin.example.com")
+			opts.AllProxy = tc.allProxy
+			opts.HTTPProxy = tc.httpProxy
+			opts.HTTPSProxy = tc.httpsProxy
+			opts.NoProxy = tc.noProxy
+
+			transport, err := NewAPIcast(opts).adminPortalTransport()
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "https://3scale-admin.example.com/admin/api/account/proxy_configs/production.json", nil)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			proxyURL, err := transport.Proxy(req)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			proxyHost := ""
+			if proxyURL != nil {
+				proxyHost = proxyURL.Host
+			}
+			if proxyHost != tc.expectedProxy {
+				subT.Errorf("expected proxy %q, got %q", tc.expectedProxy, proxyHost)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package apicast
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// BlueGreenColorLabel is added to the blue/green pods on top of the
+	// stable pod labels. The APIcast service selects the pods of the active
+	// color only.
+	BlueGreenColorLabel = "color"
+	// BlueGreenConfigurationHashAnnotation records the hash of the embedded
+	// configuration served by the pods of a blue/green deployment
+	BlueGreenConfigurationHashAnnotation = "apicast.apps.3scale.net/configuration-hash"
+)
+
+// BlueGreen returns the blue/green rollout options
+func (a *APIcast) BlueGreen() BlueGreenOptions {
+	return a.options.BlueGreen
+}
+
+// BlueGreenConfigurationHash returns the hash of the current embedded configuration
+func (a *APIcast) BlueGreenConfigurationHash() string {
+	if a.options.GatewayConfigurationSecret == nil {
+		return ""
+	}
+
+	return HashSecret(map[string][]byte{
+		EmbeddedConfigurationSecretKey: a.options.GatewayConfigurationSecret.Data[EmbeddedConfigurationSecretKey],
+	})
+}
+
+// BlueGreenConfigurationSecret returns the copy of the current embedded
+// configuration mounted by the deployment of the given color. Copies are not
+// watched, so the configuration of a running color never changes under its pods.
+func (a *APIcast) BlueGreenConfigurationSecret(color string) *v1.Secret {
+	secret := &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.blueGreenConfigurationSecretName(color),
+			Namespace: a.options.Namespace,
+			Labels:    a.options.CommonLabels,
+			Annotations: map[string]string{
+				BlueGreenConfigurationHashAnnotation: a.BlueGreenConfigurationHash(),
+			},
+		},
+		Data: map[string][]byte{},
+		Type: v1.SecretTypeOpaque,
+	}
+
+	if a.options.GatewayConfigurationSecret != nil {
+		secret.Data[EmbeddedConfigurationSecretKey] = append([]byte(nil), a.options.GatewayConfigurationSecret.Data[EmbeddedConfigurationSecretKey]...)
+	}
+
+	addOwnerRefToObject(secret, *a.options.Owner)
+	return secret
+}
+
+// BlueGreenDeployment returns the deployment of the given color. It is the
+// stable deployment with a different name, the color pod label, and the
+// embedded configuration read from the copy of the color. The configuration
+// hash annotation rolls out the pods when the copy is replaced.
+func (a *APIcast) BlueGreenDeployment(ctx context.Context, k8sclient client.Client, color, configurationHash string) (*appsv1.Deployment, error) {
+	blueGreenOptions := *a.options
+	blueGreenOptions.GatewayConfigurationSecret = &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.blueGreenConfigurationSecretName(color),
+			Namespace: a.options.Namespace,
+		},
+	}
+	blueGreenOptions.PodLabelSelector = BlueGreenLabels(a.options.PodLabelSelector, color)
+	blueGreenOptions.PodTemplateLabels = BlueGreenLabels(a.options.PodTemplateLabels, color)
+
+	deployment, err := NewAPIcast(&blueGreenOptions).deployment(ctx, k8sclient)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment.Name = a.blueGreenDeploymentName(color)
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.Annotations[BlueGreenConfigurationHashAnnotation] = configurationHash
+	return deployment, nil
+}
+
+// BlueGreenLabels returns the labels of the pods of the given color
+func BlueGreenLabels(labels map[string]string, color string) map[string]string {
+	result := map[string]string{}
+	for k, v := range labels {
+		result[k] = v
+	}
+	result[BlueGreenColorLabel] = color
+	return result
+}
+
+func (a *APIcast) blueGreenDeploymentName(color string) string {
+	return fmt.Sprintf("%s-%s", a.options.DeploymentName, color)
+}
+
+func (a *APIcast) blueGreenConfigurationSecretName(color string) string {
+	return fmt.Sprintf("%s-%s-configuration", a.options.DeploymentName, color)
+}
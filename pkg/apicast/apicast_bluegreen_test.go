@@ -0,0 +1,104 @@
+//go:build unit
+
+package apicast
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAPIcastBlueGreenDeployment(t *testing.T) {
+	opts := testDefaultOpts()
+	opts.Replicas = 2
+	opts.PodLabelSelector = map[string]string{"deployment": "apicast-apicast1"}
+	opts.PodTemplateLabels = map[string]string{"deployment": "apicast-apicast1", "app": "apicast"}
+	opts.GatewayConfigurationSecret = GetTestSecret(opts.Namespace, "my-config", map[string]string{EmbeddedConfigurationSecretKey: "{}"})
+	opts.BlueGreen = BlueGreenOptions{Enabled: true}
+	apicastFactory := NewAPIcast(opts)
+
+	green, err := apicastFactory.BlueGreenDeployment(context.TODO(), fake.NewFakeClient(), "green", "abc")
+	if err != nil {
+		t.Fatalf("error getting blue/green deployment: %v", err)
+	}
+
+	if green.Name != "apicast-apicast1-green" {
+		t.Errorf("unexpected blue/green deployment name: %s", green.Name)
+	}
+	if green.Spec.Replicas == nil || *green.Spec.Replicas != 2 {
+		t.Errorf("unexpected blue/green replicas: %v", green.Spec.Replicas)
+	}
+	if green.Spec.Template.Annotations[BlueGreenConfigurationHashAnnotation] != "abc" {
+		t.Errorf("unexpected configuration hash annotation: %v", green.Spec.Template.Annotations)
+	}
+	if name := green.Spec.Template.Spec.Containers[0].Name; name != "apicast-apicast1" {
+		t.Errorf("the APIcast container must keep its name, got %s", name)
+	}
+
+	expectedSelector := map[string]string{"deployment": "apicast-apicast1", BlueGreenColorLabel: "green"}
+	if !reflect.DeepEqual(green.Spec.Selector.MatchLabels, expectedSelector) {
+		t.Errorf("unexpected blue/green selector: %v", green.Spec.Selector.MatchLabels)
+	}
+	if green.Spec.Template.Labels[BlueGreenColorLabel] != "green" || green.Spec.Template.Labels["app"] != "apicast" {
+		t.Errorf("unexpected blue/green pod labels: %v", green.Spec.Template.Labels)
+	}
+
+	// the configuration copy of the color is mounted
+	found := false
+	for _, volume := range green.Spec.Template.Spec.Volumes {
+		if volume.Name == EmbeddedConfigurationVolumeName {
+			found = true
+			if volume.Secret == nil || volume.Secret.SecretName != "apicast-apicast1-green-configuration" {
+				t.Errorf("unexpected embedded configuration volume: %v", volume)
+			}
+		}
+	}
+	if !found {
+		t.Error("embedded configuration volume not found")
+	}
+
+	// the stable options are not modified
+	if _, ok := opts.PodLabelSelector[BlueGreenColorLabel]; ok {
+		t.Error("the stable pod selector was modified")
+	}
+	if opts.GatewayConfigurationSecret.Name != "my-config" {
+		t.Error("the stable embedded configuration was modified")
+	}
+}
+
+func TestAPIcastBlueGreenConfigurationSecret(t *testing.T) {
+	opts := testDefaultOpts()
+	opts.GatewayConfigurationSecret = GetTestSecret(opts.Namespace, "my-config", map[string]string{
+		EmbeddedConfigurationSecretKey: `{"services":[]}`,
+		"unrelated":                    "value",
+	})
+	apicastFactory := NewAPIcast(opts)
+
+	secret := apicastFactory.BlueGreenConfigurationSecret("blue")
+	if secret.Name != "apicast-apicast1-blue-configuration" || secret.Namespace != opts.Namespace {
+		t.Errorf("unexpected configuration copy key: %s/%s", secret.Namespace, secret.Name)
+	}
+	expectedData := map[string][]byte{EmbeddedConfigurationSecretKey: []byte(`{"services":[]}`)}
+	if !reflect.DeepEqual(secret.Data, expectedData) {
+		t.Errorf("unexpected configuration copy data: %v", secret.Data)
+	}
+	hash := apicastFactory.BlueGreenConfigurationHash()
+	if hash == "" || secret.Annotations[BlueGreenConfigurationHashAnnotation] != hash {
+		t.Errorf("unexpected configuration copy hash annotation: %v", secret.Annotations)
+	}
+	if len(secret.OwnerReferences) != 1 {
+		t.Errorf("the configuration copy must be owned by the APIcast: %v", secret.OwnerReferences)
+	}
+
+	// keys not mounted by APIcast do not change the hash
+	opts.GatewayConfigurationSecret.Data["unrelated"] = []byte("changed")
+	if apicastFactory.BlueGreenConfigurationHash() != hash {
+		t.Error("the configuration hash changed with an unrelated key")
+	}
+	opts.GatewayConfigurationSecret.Data[EmbeddedConfigurationSecretKey] = []byte(`{"services":[{}]}`)
+	if apicastFactory.BlueGreenConfigurationHash() == hash {
+		t.Error("the configuration hash did not change with the configuration")
+	}
+}
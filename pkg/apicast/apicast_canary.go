@@ -0,0 +1,83 @@
+package apicast
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// TrackLabel is added to the stable and canary pods on top of the pod
+	// label selector, so the APIcast service keeps selecting both deployments
+	// while each deployment only selects its own pods
+	TrackLabel            = "track"
+	StableTrackLabelValue = "stable"
+	CanaryTrackLabelValue = "canary"
+	// canaryMinErrorRateWindowSeconds keeps the error rate window above the
+	// usual Prometheus scrape intervals
+	canaryMinErrorRateWindowSeconds int32 = 60
+)
+
+// Canary returns the canary release options
+func (a *APIcast) Canary() CanaryOptions {
+	return a.options.Canary
+}
+
+// CanaryReplicas returns the number of canary pods for the given number of
+// stable pods, rounded up to at least one pod
+func (a *APIcast) CanaryReplicas(stableReplicas int32) int32 {
+	replicas := (stableReplicas*a.options.Canary.ReplicasPercentage + 99) / 100
+	if replicas < 1 {
+		return 1
+	}
+	return replicas
+}
+
+// CanaryDeployment returns the deployment running the given image next to the
+// stable deployment. It is the stable deployment with a different name and
+// the canary track pod label.
+func (a *APIcast) CanaryDeployment(ctx context.Context, k8sclient client.Client, image string, replicas int32) (*appsv1.Deployment, error) {
+	canaryOptions := *a.options
+	canaryOptions.Image = image
+	canaryOptions.Replicas = replicas
+	canaryOptions.Hpa = false
+	canaryOptions.PodLabelSelector = trackLabels(a.options.PodLabelSelector, CanaryTrackLabelValue)
+	canaryOptions.PodTemplateLabels = trackLabels(a.options.PodTemplateLabels, CanaryTrackLabelValue)
+
+	deployment, err := NewAPIcast(&canaryOptions).deployment(ctx, k8sclient)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment.Name = a.canaryDeploymentName()
+	return deployment, nil
+}
+
+// CanaryErrorRateQuery returns the PromQL query of the percentage of 5xx
+// responses returned by the canary pods over the analysis period
+func (a *APIcast) CanaryErrorRateQuery() string {
+	window := a.options.Canary.AnalysisSeconds
+	if window < canaryMinErrorRateWindowSeconds {
+		window = canaryMinErrorRateWindowSeconds
+	}
+
+	selector := fmt.Sprintf(`namespace="%s",pod=~"%s-.*"`, a.options.Namespace, a.canaryDeploymentName())
+	return fmt.Sprintf(
+		`sum(rate(apicast_status{%[1]s,status=~"5.."}[%[2]ds])) / sum(rate(apicast_status{%[1]s}[%[2]ds])) * 100`,
+		selector, window)
+}
+
+func (a *APIcast) canaryDeploymentName() string {
+	return fmt.Sprintf("%s-canary", a.options.DeploymentName)
+}
+
+func trackLabels(labels map[string]string, track string) map[string]string {
+	result := map[string]string{}
+	for k, v := range labels {
+		result[k] = v
+	}
+	result[TrackLabel] = track
+	return result
+}
@@ -0,0 +1,125 @@
+//go:build unit
+
+package apicast
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAPIcastCanaryReplicas(t *testing.T) {
+	cases := []struct {
+		testName       string
+		percentage     int32
+		stableReplicas int32
+		expected       int32
+	}{
+		{"AtLeastOne", 10, 2, 1},
+		{"NoStableReplicas", 10, 0, 1},
+		{"RoundedUp", 25, 10, 3},
+		{"Exact", 50, 4, 2},
+		{"Full", 100, 3, 3},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			opts := testDefaultOpts()
+			opts.Canary = CanaryOptions{Enabled: true, ReplicasPercentage: tc.percentage}
+			if replicas := NewAPIcast(opts).CanaryReplicas(tc.stableReplicas); replicas != tc.expected {
+				subT.Fatalf("expected %d canary replicas, got %d", tc.expected, replicas)
+			}
+		})
+	}
+}
+
+func TestAPIcastCanaryDeployment(t *testing.T) {
+	opts := testDefaultOpts()
+	opts.Replicas = 4
+	opts.Hpa = true
+	opts.PodLabelSelector = map[string]string{"deployment": "apicast-apicast1"}
+	opts.PodTemplateLabels = map[string]string{"deployment": "apicast-apicast1", "app": "apicast"}
+	opts.Canary = CanaryOptions{Enabled: true, ReplicasPercentage: 25}
+	apicastFactory := NewAPIcast(opts)
+
+	canary, err := apicastFactory.CanaryDeployment(context.TODO(), fake.NewFakeClient(), "quay.io/3scale/apicast:next", 1)
+	if err != nil {
+		t.Fatalf("error getting canary deployment: %v", err)
+	}
+
+	if canary.Name != "apicast-apicast1-canary" {
+		t.Errorf("unexpected canary deployment name: %s", canary.Name)
+	}
+	if canary.Spec.Replicas == nil || *canary.Spec.Replicas != 1 {
+		t.Errorf("unexpected canary replicas: %v", canary.Spec.Replicas)
+	}
+
+	container := canary.Spec.Template.Spec.Containers[0]
+	if container.Image != "quay.io/3scale/apicast:next" {
+		t.Errorf("unexpected canary image: %s", container.Image)
+	}
+	if container.Name != "apicast-apicast1" {
+		t.Errorf("the APIcast container must keep its name, got %s", container.Name)
+	}
+
+	expectedSelector := map[string]string{"deployment": "apicast-apicast1", TrackLabel: CanaryTrackLabelValue}
+	if !reflect.DeepEqual(canary.Spec.Selector.MatchLabels, expectedSelector) {
+		t.Errorf("unexpected canary selector: %v", canary.Spec.Selector.MatchLabels)
+	}
+	for k, v := range apicastFactory.Service().Spec.Selector {
+		if canary.Spec.Template.Labels[k] != v {
+			t.Errorf("canary pods are not selected by the service: missing %s=%s", k, v)
+		}
+	}
+
+	// the stable options are not modified
+	if _, ok := opts.PodLabelSelector[TrackLabel]; ok {
+		t.Error("the stable pod selector was modified")
+	}
+	stable, err := apicastFactory.Deployment(context.TODO(), fake.NewFakeClient())
+	if err != nil {
+		t.Fatalf("error getting deployment: %v", err)
+	}
+	if stable.Spec.Template.Spec.Containers[0].Image != opts.Image || stable.Spec.Replicas != nil {
+		t.Errorf("unexpected stable deployment image %s or replicas %v", stable.Spec.Template.Spec.Containers[0].Image, stable.Spec.Replicas)
+	}
+
+	// the stable deployment and the PDB do not select the canary pods
+	stableSelector, err := metav1.LabelSelectorAsSelector(stable.Spec.Selector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stableSelector.Matches(labels.Set(canary.Spec.Template.Labels)) {
+		t.Errorf("the stable selector %s selects the canary pods", stableSelector)
+	}
+	if !stableSelector.Matches(labels.Set(stable.Spec.Template.Labels)) {
+		t.Errorf("the stable selector %s does not select the stable pods", stableSelector)
+	}
+	pdbSelector, err := metav1.LabelSelectorAsSelector(apicastFactory.PodDisruptionBudget().Spec.Selector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pdbSelector.Matches(labels.Set(canary.Spec.Template.Labels)) || !pdbSelector.Matches(labels.Set(stable.Spec.Template.Labels)) {
+		t.Errorf("the PDB selector %s must select the stable pods only", pdbSelector)
+	}
+}
+
+func TestAPIcastCanaryErrorRateQuery(t *testing.T) {
+	opts := testDefaultOpts()
+	opts.Canary = CanaryOptions{Enabled: true, AnalysisSeconds: 300}
+
+	expected := `sum(rate(apicast_status{namespace="my-namespace",pod=~"apicast-apicast1-canary-.*",status=~"5.."}[300s])) / sum(rate(apicast_status{namespace="my-namespace",pod=~"apicast-apicast1-canary-.*"}[300s])) * 100`
+	if query := NewAPIcast(opts).CanaryErrorRateQuery(); query != expected {
+		t.Errorf("unexpected query:\n%s\nexpected:\n%s", query, expected)
+	}
+
+	opts.Canary.AnalysisSeconds = 10
+	if query := NewAPIcast(opts).CanaryErrorRateQuery(); !strings.HasSuffix(query, "[60s])) * 100") {
+		t.Errorf("short analysis periods must use the minimum window: %s", query)
+	}
+}
@@ -0,0 +1,226 @@
+package apicast
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/3scale/apicast-operator/pkg/helper"
+)
+
+const (
+	// BuiltinPolicyVersion is the version of the policies shipped with APIcast
+	BuiltinPolicyVersion = "builtin"
+	// BuiltinPolicyModulePrefix is the prefix of the module name of the policies shipped with APIcast
+	BuiltinPolicyModulePrefix = "apicast.policy."
+)
+
+// BuiltinPolicyNames are the policies shipped with APIcast. The list may lag
+// behind the APIcast image, so unknown policy names are only warnings.
+var BuiltinPolicyNames = []string{
+	"3scale_batcher",
+	"3scale_referrer",
+	"apicast",
+	"caching",
+	"camel",
+	"conditional",
+	"content_caching",
+	"cors",
+	"custom_metrics",
+	"default_credentials",
+	"echo",
+	"grpc",
+	"headers",
+	"http_proxy",
+	"ip_check",
+	"jwt_claim_check",
+	"jwt_parser",
+	"keycloak_role_check",
+	"liquid_context_debug",
+	"logging",
+	"maintenance_mode",
+	"nginx_filters",
+	"oauth_mtls",
+	"on_failed",
+	"payload_limits",
+	"rate_limit",
+	"rate_limit_headers",
+	"request_unbuffered",
+	"response_request_content_limits",
+	"retry",
+	"rewrite_url_captures",
+	"routing",
+	"soap",
+	"tls",
+	"tls_validation",
+	"token_introspection",
+	"upstream",
+	"upstream_connection",
+	"upstream_mtls",
+	"url_rewriting",
+	"websocket",
+}
+
+// ValidateEmbeddedConfiguration parses the embedded configuration and checks
+// the services, proxy and policy chains required by APIcast. It returns no
+// errors when the configuration is provided by the admin portal.
+func (a *APIcast) ValidateEmbeddedConfiguration() (field.ErrorList, []string) {
+	if a.options.GatewayConfigurationSecret == nil {
+		return nil, nil
+	}
+
+	return ValidateGatewayConfiguration(a.options.GatewayConfigurationSecret.Data[EmbeddedConfigurationSecretKey], a.options.CustomPolicies)
+}
+
+// ValidateGatewayConfiguration validates the APIcast configuration JSON.
+// Policies of the policy chains must be either builtin or declared in the
+// custom policies. The returned warnings list the policies neither declared
+// nor known to be builtin.
+func ValidateGatewayConfiguration(data []byte, customPolicies []CustomPolicy) (field.ErrorList, []string) {
+	var errors field.ErrorList
+	var warnings []string
+
+	config := map[string]interface{}{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return append(errors, field.Invalid(field.NewPath(EmbeddedConfigurationSecretKey), "", fmt.Sprintf("invalid JSON: %v", err))), nil
+	}
+
+	servicesFldPath := field.NewPath("services")
+	rawServices, ok := config["services"]
+	if !ok {
+		return append(errors, field.Required(servicesFldPath, "")), nil
+	}
+	services, ok := rawServices.([]interface{})
+	if !ok {
+		return append(errors, field.Invalid(servicesFldPath, rawServices, "must be an array")), nil
+	}
+
+	policyVersions := map[string]map[string]bool{}
+	for _, name := range BuiltinPolicyNames {
+		policyVersions[name] = map[string]bool{BuiltinPolicyVersion: true}
+	}
+	for _, customPolicy := range customPolicies {
+		if policyVersions[customPolicy.Name] == nil {
+			policyVersions[customPolicy.Name] = map[string]bool{}
+		}
+		policyVersions[customPolicy.Name][customPolicy.Version] = true
+	}
+
+	for idx, rawService := range services {
+		serviceErrors, serviceWarnings := validateGatewayConfigurationService(servicesFldPath.Index(idx), rawService, policyVersions)
+		errors = append(errors, serviceErrors...)
+		warnings = append(warnings, serviceWarnings...)
+	}
+
+	return errors, warnings
+}
+
+func validateGatewayConfigurationService(fldPath *field.Path, rawService interface{}, policyVersions map[string]map[string]bool) (field.ErrorList, []string) {
+	var errors field.ErrorList
+	var warnings []string
+
+	service, ok := rawService.(map[string]interface{})
+	if !ok {
+		return append(errors, field.Invalid(fldPath, rawService, "must be an object")), nil
+	}
+
+	switch id := service["id"].(type) {
+	case nil, float64, string:
+	default:
+		errors = append(errors, field.Invalid(fldPath.Child("id"), id, "must be a number or a string"))
+	}
+
+	proxyFldPath := fldPath.Child("proxy")
+	rawProxy, ok := service["proxy"]
+	if !ok {
+		return append(errors, field.Required(proxyFldPath, "")), nil
+	}
+	proxy, ok := rawProxy.(map[string]interface{})
+	if !ok {
+		return append(errors, field.Invalid(proxyFldPath, rawProxy, "must be an object")), nil
+	}
+
+	// services without hosts are only matched by path routing
+	hostsFldPath := proxyFldPath.Child("hosts")
+	switch hosts := proxy["hosts"].(type) {
+	case nil:
+	case []interface{}:
+		for idx, host := range hosts {
+			if name, ok := host.(string); !ok || name == "" {
+				errors = append(errors, field.Invalid(hostsFldPath.Index(idx), host, "must be a non-empty string"))
+			}
+		}
+	default:
+		errors = append(errors, field.Invalid(hostsFldPath, hosts, "must be an array"))
+	}
+
+	policyChainFldPath := proxyFldPath.Child("policy_chain")
+	rawPolicyChain, ok := proxy["policy_chain"]
+	if !ok || rawPolicyChain == nil {
+		return errors, nil
+	}
+	policyChain, ok := rawPolicyChain.([]interface{})
+	if !ok {
+		return append(errors, field.Invalid(policyChainFldPath, rawPolicyChain, "must be an array")), nil
+	}
+
+	for idx, rawPolicy := range policyChain {
+		policyErrors, warning := validateGatewayConfigurationPolicy(policyChainFldPath.Index(idx), rawPolicy, policyVersions)
+		errors = append(errors, policyErrors...)
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	return errors, warnings
+}
+
+func validateGatewayConfigurationPolicy(fldPath *field.Path, rawPolicy interface{}, policyVersions map[string]map[string]bool) (field.ErrorList, string) {
+	var errors field.ErrorList
+
+	policy, ok := rawPolicy.(map[string]interface{})
+	if !ok {
+		return append(errors, field.Invalid(fldPath, rawPolicy, "must be an object")), ""
+	}
+
+	if configuration, ok := policy["configuration"]; ok && configuration != nil {
+		if _, ok := configuration.(map[string]interface{}); !ok {
+			errors = append(errors, field.Invalid(fldPath.Child("configuration"), configuration, "must be an object"))
+		}
+	}
+
+	name, ok := policy["name"].(string)
+	if !ok || name == "" {
+		return append(errors, field.Required(fldPath.Child("name"), "")), ""
+	}
+
+	// builtin policies can also be referenced by their module name
+	versions, ok := policyVersions[strings.TrimPrefix(name, BuiltinPolicyModulePrefix)]
+	if !ok {
+		return errors, fmt.Sprintf("%s: %q is neither a known builtin policy nor a custom policy", fldPath.Child("name"), name)
+	}
+
+	rawVersion, ok := policy["version"]
+	if !ok {
+		// APIcast picks the builtin version
+		if !versions[BuiltinPolicyVersion] {
+			errors = append(errors, field.Required(fldPath.Child("version"), "required for custom policies"))
+		}
+		return errors, ""
+	}
+
+	version, ok := rawVersion.(string)
+	if !ok {
+		return append(errors, field.Invalid(fldPath.Child("version"), rawVersion, "must be a string")), ""
+	}
+	if !versions[version] {
+		supportedVersions := helper.MapKeys(versions)
+		sort.Strings(supportedVersions)
+		errors = append(errors, field.NotSupported(fldPath.Child("version"), version, supportedVersions))
+	}
+
+	return errors, ""
+}
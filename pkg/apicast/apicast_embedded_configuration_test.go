@@ -0,0 +1,104 @@
+//go:build unit
+
+package apicast
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateGatewayConfiguration(t *testing.T) {
+	customPolicies := []CustomPolicy{{Name: "my-policy", Version: "0.1"}}
+
+	cases := []struct {
+		testName string
+		config   string
+		// expected error fields, in order
+		expected []string
+		// expected number of warnings
+		expectedWarnings int
+	}{
+		{"Empty", `{"services":[]}`, nil, 0},
+		{
+			"Valid",
+			`{"services":[{"id":1,"proxy":{"hosts":["one.example.com"],"policy_chain":[
+				{"name":"headers","configuration":{}},
+				{"name":"apicast","version":"builtin"},
+				{"name":"my-policy","version":"0.1","configuration":{"key":"value"}}]}}]}`,
+			nil, 0,
+		},
+		{"StringID", `{"services":[{"id":"1","proxy":{"hosts":["one.example.com"]}}]}`, nil, 0},
+		{
+			"PathRoutingModuleName",
+			`{"services":[{"proxy":{"policy_chain":[{"name":"apicast.policy.upstream","configuration":{"rules":[]}}]}}]}`,
+			nil, 0,
+		},
+		{"InvalidJSON", `{"services":[`, []string{"config.json"}, 0},
+		{"MissingServices", `{}`, []string{"services"}, 0},
+		{"ServicesNotArray", `{"services":{}}`, []string{"services"}, 0},
+		{"ServiceNotObject", `{"services":[1]}`, []string{"services[0]"}, 0},
+		{"MissingProxy", `{"services":[{"id":1}]}`, []string{"services[0].proxy"}, 0},
+		{"InvalidID", `{"services":[{"id":true,"proxy":{}}]}`, []string{"services[0].id"}, 0},
+		{"ProxyNotObject", `{"services":[{"id":1,"proxy":[]}]}`, []string{"services[0].proxy"}, 0},
+		{"HostsNotArray", `{"services":[{"id":1,"proxy":{"hosts":"one.example.com"}}]}`, []string{"services[0].proxy.hosts"}, 0},
+		{"EmptyHost", `{"services":[{"id":1,"proxy":{"hosts":["one.example.com",""]}}]}`, []string{"services[0].proxy.hosts[1]"}, 0},
+		{
+			"UnknownPolicy",
+			`{"services":[{"id":1,"proxy":{"hosts":["a"]}},{"id":2,"proxy":{"hosts":["b"],"policy_chain":[{"name":"apicast"},{"name":"unknown"}]}}]}`,
+			nil, 1,
+		},
+		{
+			"UnknownPolicyModuleName",
+			`{"services":[{"id":1,"proxy":{"policy_chain":[{"name":"apicast.policy.unknown","version":"builtin"}]}}]}`,
+			nil, 1,
+		},
+		{
+			"UnknownPolicyAndInvalidHost",
+			`{"services":[{"id":1,"proxy":{"hosts":[""],"policy_chain":[{"name":"unknown"}]}}]}`,
+			[]string{"services[0].proxy.hosts[0]"}, 1,
+		},
+		{
+			"UnknownCustomPolicyVersion",
+			`{"services":[{"id":1,"proxy":{"hosts":["a"],"policy_chain":[{"name":"my-policy","version":"0.2"}]}}]}`,
+			[]string{"services[0].proxy.policy_chain[0].version"}, 0,
+		},
+		{
+			"CustomPolicyWithoutVersion",
+			`{"services":[{"id":1,"proxy":{"hosts":["a"],"policy_chain":[{"name":"my-policy"}]}}]}`,
+			[]string{"services[0].proxy.policy_chain[0].version"}, 0,
+		},
+		{
+			"InvalidPolicyConfiguration",
+			`{"services":[{"id":1,"proxy":{"hosts":["a"],"policy_chain":[{"name":"cors","configuration":[]}]}}]}`,
+			[]string{"services[0].proxy.policy_chain[0].configuration"}, 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			errs, warnings := ValidateGatewayConfiguration([]byte(tc.config), customPolicies)
+			fields := make([]string, 0, len(errs))
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tc.expected, ",") {
+				subT.Errorf("expected errors on %v, got %v", tc.expected, errs)
+			}
+			if len(warnings) != tc.expectedWarnings {
+				subT.Errorf("expected %d warnings, got %v", tc.expectedWarnings, warnings)
+			}
+		})
+	}
+}
+
+func TestAPIcastValidateEmbeddedConfiguration(t *testing.T) {
+	opts := testDefaultOpts()
+	if errs, _ := NewAPIcast(opts).ValidateEmbeddedConfiguration(); len(errs) > 0 {
+		t.Errorf("unexpected errors without embedded configuration: %v", errs)
+	}
+
+	opts.GatewayConfigurationSecret = GetTestSecret(opts.Namespace, "my-config", map[string]string{EmbeddedConfigurationSecretKey: `{"services":[{"id":1}]}`})
+	if errs, _ := NewAPIcast(opts).ValidateEmbeddedConfiguration(); len(errs) != 1 {
+		t.Errorf("expected one error, got %v", errs)
+	}
+}
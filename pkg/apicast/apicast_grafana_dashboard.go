@@ -0,0 +1,223 @@
+package apicast
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// GrafanaDashboardSidecarLabel is the default label watched by the
+	// Grafana dashboards sidecar
+	GrafanaDashboardSidecarLabel = "grafana_dashboard"
+)
+
+// GrafanaDashboardGVK is the Grafana Operator dashboard kind. The Grafana
+// Operator API types are not imported, the resource is managed as
+// unstructured content.
+var GrafanaDashboardGVK = schema.GroupVersionKind{
+	Group:   "grafana.integreatly.org",
+	Version: "v1beta1",
+	Kind:    "GrafanaDashboard",
+}
+
+func (a *APIcast) GrafanaDashboardName() string {
+	return fmt.Sprintf("%s-grafana-dashboard", a.options.DeploymentName)
+}
+
+func (a *APIcast) GrafanaDashboardConfigMap() (*v1.ConfigMap, error) {
+	dashboardJSON, err := a.grafanaDashboardJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	configMap := &v1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.GrafanaDashboardName(),
+			Namespace: a.options.Namespace,
+			Labels:    a.dashboardLabels(),
+		},
+		Data: map[string]string{
+			fmt.Sprintf("%s.json", a.options.DeploymentName): dashboardJSON,
+		},
+	}
+
+	addOwnerRefToObject(configMap, *a.options.Owner)
+	return configMap, nil
+}
+
+func (a *APIcast) GrafanaDashboard() (*unstructured.Unstructured, error) {
+	dashboardJSON, err := a.grafanaDashboardJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	instanceSelector, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&a.options.Monitoring.Dashboard.InstanceSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := &unstructured.Unstructured{}
+	dashboard.SetGroupVersionKind(GrafanaDashboardGVK)
+	dashboard.SetName(a.GrafanaDashboardName())
+	dashboard.SetNamespace(a.options.Namespace)
+	dashboard.SetLabels(a.dashboardLabels())
+	dashboard.Object["spec"] = map[string]interface{}{
+		"json":             dashboardJSON,
+		"instanceSelector": instanceSelector,
+	}
+
+	addOwnerRefToObject(dashboard, *a.options.Owner)
+	return dashboard, nil
+}
+
+func (a *APIcast) dashboardLabels() map[string]string {
+	labels := map[string]string{}
+	for k, v := range a.options.Monitoring.Dashboard.Labels {
+		labels[k] = v
+	}
+	for k, v := range a.options.CommonLabels {
+		labels[k] = v
+	}
+	return labels
+}
+
+type grafanaPanel struct {
+	Title   string
+	Unit    string
+	Queries []grafanaQuery
+}
+
+type grafanaQuery struct {
+	Legend string
+	Expr   string
+}
+
+// grafanaDashboardJSON returns the dashboard model with the APIcast metrics
+// of the pods of the APIcast deployment
+func (a *APIcast) grafanaDashboardJSON() (string, error) {
+	selector := a.podMetricsSelector()
+
+	panels := []grafanaPanel{
+		{
+			Title: "Requests per second by status",
+			Unit:  "reqps",
+			Queries: []grafanaQuery{
+				{"{{status}}", fmt.Sprintf(`sum by (status) (rate(apicast_status{%s}[5m]))`, selector)},
+			},
+		},
+		{
+			Title: "5xx responses ratio",
+			Unit:  "percentunit",
+			Queries: []grafanaQuery{
+				{"5xx", fmt.Sprintf(`sum(rate(apicast_status{%[1]s,status=~"5.."}[5m])) / sum(rate(apicast_status{%[1]s}[5m]))`, selector)},
+			},
+		},
+		{
+			Title: "Request latency",
+			Unit:  "s",
+			Queries: []grafanaQuery{
+				{"p50", fmt.Sprintf(`histogram_quantile(0.50, sum by (le) (rate(total_response_time_seconds_bucket{%s}[5m])))`, selector)},
+				{"p99", fmt.Sprintf(`histogram_quantile(0.99, sum by (le) (rate(total_response_time_seconds_bucket{%s}[5m])))`, selector)},
+			},
+		},
+		{
+			Title: "Upstream latency",
+			Unit:  "s",
+			Queries: []grafanaQuery{
+				{"p50", fmt.Sprintf(`histogram_quantile(0.50, sum by (le) (rate(upstream_response_time_seconds_bucket{%s}[5m])))`, selector)},
+				{"p99", fmt.Sprintf(`histogram_quantile(0.99, sum by (le) (rate(upstream_response_time_seconds_bucket{%s}[5m])))`, selector)},
+			},
+		},
+		{
+			Title: "Connections by state",
+			Unit:  "short",
+			Queries: []grafanaQuery{
+				{"{{state}}", fmt.Sprintf(`sum by (state) (nginx_http_connections{%s})`, selector)},
+			},
+		},
+		{
+			Title: "Error logs",
+			Unit:  "short",
+			Queries: []grafanaQuery{
+				{"{{level}}", fmt.Sprintf(`sum by (level) (increase(nginx_error_log{%s}[5m]))`, selector)},
+			},
+		},
+		{
+			Title: "Worker restarts",
+			Unit:  "short",
+			Queries: []grafanaQuery{
+				{"{{pod}}", fmt.Sprintf(`sum by (pod) (increase(worker_process{%s}[15m]))`, selector)},
+			},
+		},
+	}
+
+	datasource := map[string]interface{}{"type": "prometheus", "uid": "${datasource}"}
+
+	panelModels := make([]interface{}, 0, len(panels))
+	for idx, panel := range panels {
+		targets := make([]interface{}, 0, len(panel.Queries))
+		for queryIdx, query := range panel.Queries {
+			targets = append(targets, map[string]interface{}{
+				"datasource":   datasource,
+				"expr":         query.Expr,
+				"legendFormat": query.Legend,
+				"refId":        string(rune('A' + queryIdx)),
+			})
+		}
+
+		panelModels = append(panelModels, map[string]interface{}{
+			"id":         idx + 1,
+			"type":       "timeseries",
+			"title":      panel.Title,
+			"datasource": datasource,
+			"gridPos":    map[string]interface{}{"h": 8, "w": 12, "x": (idx % 2) * 12, "y": (idx / 2) * 8},
+			"fieldConfig": map[string]interface{}{
+				"defaults":  map[string]interface{}{"unit": panel.Unit},
+				"overrides": []interface{}{},
+			},
+			"targets": targets,
+		})
+	}
+
+	uid := sha256.Sum256([]byte(fmt.Sprintf("%s/%s", a.options.Namespace, a.options.DeploymentName)))
+
+	dashboard := map[string]interface{}{
+		"uid":           hex.EncodeToString(uid[:])[:16],
+		"title":         fmt.Sprintf("APIcast / %s / %s", a.options.Namespace, a.options.DeploymentName),
+		"tags":          []string{"apicast", "3scale"},
+		"editable":      false,
+		"schemaVersion": 39,
+		"refresh":       "30s",
+		"time":          map[string]interface{}{"from": "now-1h", "to": "now"},
+		"templating": map[string]interface{}{
+			"list": []interface{}{
+				map[string]interface{}{
+					"name":  "datasource",
+					"label": "Data source",
+					"type":  "datasource",
+					"query": "prometheus",
+				},
+			},
+		},
+		"panels": panelModels,
+	}
+
+	data, err := json.Marshal(dashboard)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -7,11 +7,16 @@ import (
 	"net/url"
 	"path"
 	"sort"
+	"time"
 
+	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
 	"github.com/3scale/apicast-operator/pkg/helper"
@@ -33,6 +38,10 @@ type APIcastOptionsProvider struct {
 	APIcastCR      *appsv1alpha1.APIcast
 	APIcastOptions *APIcastOptions
 	Client         client.Client
+	// OperatorConfig are the defaults applied before the APIcast CR values
+	OperatorConfig *OperatorConfig
+	// ImageResolver resolves the image tag when image digest pinning is enabled
+	ImageResolver ImageResolver
 }
 
 func APIcastDeploymentName(cr *appsv1alpha1.APIcast) string {
@@ -43,6 +52,36 @@ func APIcastDeploymentName(cr *appsv1alpha1.APIcast) string {
 	return fmt.Sprintf("apicast-%s", cr.Name)
 }
 
+// CanaryDeploymentName returns the name of the deployment running the canary
+// pods of the APIcast
+func CanaryDeploymentName(cr *appsv1alpha1.APIcast) string {
+	if cr == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s-canary", APIcastDeploymentName(cr))
+}
+
+// BlueGreenDeploymentName returns the name of the blue/green deployment of
+// the given color
+func BlueGreenDeploymentName(cr *appsv1alpha1.APIcast, color string) string {
+	if cr == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s-%s", APIcastDeploymentName(cr), color)
+}
+
+// BlueGreenConfigurationSecretName returns the name of the copy of the
+// embedded configuration mounted by the blue/green deployment of the given color
+func BlueGreenConfigurationSecretName(cr *appsv1alpha1.APIcast, color string) string {
+	if cr == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s-%s-configuration", APIcastDeploymentName(cr), color)
+}
+
 func NewApicastOptionsProvider(cr *appsv1alpha1.APIcast, cl client.Client) *APIcastOptionsProvider {
 	return &APIcastOptionsProvider{
 		APIcastCR:      cr,
@@ -52,38 +91,63 @@ func NewApicastOptionsProvider(cr *appsv1alpha1.APIcast, cl client.Client) *APIc
 }
 
 func (a *APIcastOptionsProvider) GetApicastOptions(ctx context.Context) (*APIcastOptions, error) {
+	operatorConfig := a.OperatorConfig
+	if operatorConfig == nil {
+		operatorConfig = &OperatorConfig{}
+	}
+
 	a.APIcastOptions.Namespace = a.APIcastCR.Namespace
 	a.APIcastOptions.Owner = a.APIcastCR.GetOwnerReference()
 
 	a.APIcastOptions.DeploymentName = APIcastDeploymentName(a.APIcastCR)
 	a.APIcastOptions.ServiceName = APIcastDeploymentName(a.APIcastCR)
 
-	a.APIcastOptions.Replicas = 1
+	a.APIcastOptions.Replicas = int32(appsv1alpha1.DefaultReplicas)
 	if a.APIcastCR.Spec.Replicas != nil {
 		a.APIcastOptions.Replicas = int32(*a.APIcastCR.Spec.Replicas)
 	}
 
-	a.APIcastOptions.Hpa = a.APIcastCR.Spec.Hpa
+	a.APIcastOptions.Hpa = a.APIcastCR.IsHPAEnabled()
 
-	a.APIcastOptions.ServiceAccountName = "default"
+	a.APIcastOptions.ServiceAccountName = appsv1alpha1.DefaultServiceAccount
 	if a.APIcastCR.Spec.ServiceAccount != nil {
 		a.APIcastOptions.ServiceAccountName = *a.APIcastCR.Spec.ServiceAccount
 	}
 
-	a.APIcastOptions.Image = GetDefaultImageVersion()
 	if a.APIcastCR.Spec.Image != nil {
 		a.APIcastOptions.Image = *a.APIcastCR.Spec.Image
+	} else {
+		a.APIcastOptions.Upgrade = a.upgradeOptions(operatorConfig.Image(GetDefaultImageVersion()), time.Now())
+		a.APIcastOptions.Image = a.APIcastOptions.Upgrade.Status.Image
+	}
+
+	if ptr.Deref(a.APIcastCR.Spec.ImageDigestPinning, false) {
+		resolvedImage, err := a.resolveImage(ctx, a.APIcastOptions.Image)
+		if err != nil {
+			return nil, err
+		}
+		a.APIcastOptions.Image = resolvedImage.Digest
+		a.APIcastOptions.ResolvedImage = resolvedImage
+	}
+
+	a.APIcastOptions.ImagePullPolicy = v1.PullAlways
+	if a.APIcastCR.Spec.ImagePullPolicy != nil {
+		a.APIcastOptions.ImagePullPolicy = *a.APIcastCR.Spec.ImagePullPolicy
 	}
+	a.APIcastOptions.ImagePullSecrets = a.APIcastCR.Spec.ImagePullSecrets
 
 	a.APIcastOptions.PodLabelSelector = a.podLabelSelector(a.APIcastOptions.DeploymentName)
 	a.APIcastOptions.CommonLabels = a.commonLabels()
-	a.APIcastOptions.PodTemplateLabels = a.podTemplateLabels(a.APIcastOptions.PodLabelSelector)
+	a.APIcastOptions.PodTemplateLabels = a.podTemplateLabels(operatorConfig.Labels, a.APIcastOptions.PodLabelSelector)
+	a.APIcastOptions.PodTemplateAnnotations = operatorConfig.Annotations
 
 	a.APIcastOptions.ExposedHost = ExposedHost{}
 	if a.APIcastCR.Spec.ExposedHost != nil {
 		a.APIcastOptions.ExposedHost.Host = a.APIcastCR.Spec.ExposedHost.Host
 		a.APIcastOptions.ExposedHost.IngressClassName = a.APIcastCR.Spec.ExposedHost.IngressClassName
 		a.APIcastOptions.ExposedHost.TLS = a.APIcastCR.Spec.ExposedHost.TLS
+		a.APIcastOptions.ExposedHost.Route = a.exposedHostRoute()
+		a.APIcastOptions.ExposedHost.Gateway = a.exposedHostGateway()
 	}
 
 	adminPortalCredentialsSecret, err := a.getAdminPortalCredentialsSecret(ctx)
@@ -107,6 +171,9 @@ func (a *APIcastOptionsProvider) GetApicastOptions(ctx context.Context) (*APIcas
 	a.APIcastOptions.EnabledServices = a.APIcastCR.Spec.EnabledServices
 	a.APIcastOptions.ConfigurationLoadMode = a.APIcastCR.Spec.ConfigurationLoadMode
 	a.APIcastOptions.LogLevel = a.APIcastCR.Spec.LogLevel
+	if a.APIcastOptions.LogLevel == nil {
+		a.APIcastOptions.LogLevel = operatorConfig.LogLevel
+	}
 	a.APIcastOptions.PathRoutingEnabled = a.APIcastCR.Spec.PathRoutingEnabled
 	a.APIcastOptions.ResponseCodesIncluded = a.APIcastCR.Spec.ResponseCodesIncluded
 	a.APIcastOptions.CacheConfigurationSeconds = a.APIcastCR.Spec.CacheConfigurationSeconds
@@ -144,7 +211,10 @@ func (a *APIcastOptionsProvider) GetApicastOptions(ctx context.Context) (*APIcas
 	a.APIcastOptions.CACertificateSecret = caCertificateSecret
 
 	// Resource requirements
-	resourceRequirements := DefaultResourceRequirements(a.APIcastCR.Spec.Hpa)
+	resourceRequirements := DefaultResourceRequirements(a.APIcastCR.IsHPAEnabled())
+	if operatorConfig.Resources != nil {
+		resourceRequirements = *operatorConfig.Resources.DeepCopy()
+	}
 
 	// Apply Resources configuration from APICast CR if available
 	if a.APIcastCR.Spec.Resources != nil {
@@ -153,18 +223,28 @@ func (a *APIcastOptionsProvider) GetApicastOptions(ctx context.Context) (*APIcas
 	a.APIcastOptions.ResourceRequirements = resourceRequirements
 	a.APIcastOptions.Affinity = a.APIcastCR.Spec.Affinity
 	a.APIcastOptions.Tolerations = a.APIcastCR.Spec.Tolerations
+	if a.APIcastOptions.Tolerations == nil {
+		a.APIcastOptions.Tolerations = operatorConfig.Tolerations
+	}
 	a.APIcastOptions.TopologySpreadConstraints = a.APIcastCR.Spec.TopologySpreadConstraints
 	if a.APIcastCR.Spec.PriorityClassName != nil {
 		a.APIcastOptions.PriorityClassName = *a.APIcastCR.Spec.PriorityClassName
 	}
+	a.APIcastOptions.Sidecars = a.APIcastCR.Spec.Sidecars
+	a.APIcastOptions.InitContainers = a.APIcastCR.Spec.InitContainers
+	a.APIcastOptions.ExtraVolumes = a.APIcastCR.Spec.ExtraVolumes
+	a.APIcastOptions.ExtraVolumeMounts = a.APIcastCR.Spec.ExtraVolumeMounts
+	if a.APIcastCR.Spec.PodTemplateOverride != nil {
+		a.APIcastOptions.PodTemplateOverride = a.APIcastCR.Spec.PodTemplateOverride.Raw
+	}
 
 	a.APIcastOptions.Workers = a.APIcastCR.Spec.Workers
 	a.APIcastOptions.Timezone = a.APIcastCR.Spec.Timezone
 
-	a.APIcastOptions.AllProxy = a.APIcastCR.Spec.AllProxy
-	a.APIcastOptions.HTTPProxy = a.APIcastCR.Spec.HTTPProxy
-	a.APIcastOptions.HTTPSProxy = a.APIcastCR.Spec.HTTPSProxy
-	a.APIcastOptions.NoProxy = a.APIcastCR.Spec.NoProxy
+	a.APIcastOptions.AllProxy = valueOrDefault(a.APIcastCR.Spec.AllProxy, operatorConfig.AllProxy)
+	a.APIcastOptions.HTTPProxy = valueOrDefault(a.APIcastCR.Spec.HTTPProxy, operatorConfig.HTTPProxy)
+	a.APIcastOptions.HTTPSProxy = valueOrDefault(a.APIcastCR.Spec.HTTPSProxy, operatorConfig.HTTPSProxy)
+	a.APIcastOptions.NoProxy = valueOrDefault(a.APIcastCR.Spec.NoProxy, operatorConfig.NoProxy)
 
 	for idx, customPolicySpec := range a.APIcastCR.Spec.CustomPolicies {
 		namespacedName := types.NamespacedName{
@@ -218,9 +298,284 @@ func (a *APIcastOptionsProvider) GetApicastOptions(ctx context.Context) (*APIcas
 	}
 	a.APIcastOptions.Opentelemetry = otelConfig
 
+	a.APIcastOptions.Monitoring = a.monitoringOptions()
+
+	a.APIcastOptions.Probes = a.probesOptions()
+
+	a.APIcastOptions.Rollout = a.rolloutOptions()
+
+	a.APIcastOptions.GracefulShutdown = a.gracefulShutdownOptions()
+
+	a.APIcastOptions.Canary = a.canaryOptions()
+	a.APIcastOptions.BlueGreen = a.blueGreenOptions()
+	a.APIcastOptions.AdminPortalCheck = a.adminPortalCheckOptions()
+
 	return a.APIcastOptions, a.APIcastOptions.Validate()
 }
 
+func (a *APIcastOptionsProvider) monitoringOptions() MonitoringOptions {
+	monitoring := MonitoringOptions{
+		Enabled: a.APIcastCR.IsMonitoringEnabled(),
+		Kind:    a.APIcastCR.MonitoringKind(),
+	}
+
+	spec := a.APIcastCR.Spec.Monitoring
+	if spec == nil {
+		return monitoring
+	}
+
+	if spec.Interval != nil {
+		monitoring.Interval = *spec.Interval
+	}
+
+	if spec.ScrapeTimeout != nil {
+		monitoring.ScrapeTimeout = *spec.ScrapeTimeout
+	}
+
+	for idx := range spec.Relabelings {
+		monitoring.Relabelings = append(monitoring.Relabelings, spec.Relabelings[idx].DeepCopy())
+	}
+
+	monitoring.Labels = spec.Labels
+	monitoring.Alerts = a.alertsOptions()
+	monitoring.Dashboard = a.dashboardOptions()
+
+	return monitoring
+}
+
+func (a *APIcastOptionsProvider) probesOptions() ProbesOptions {
+	probes := ProbesOptions{
+		Liveness:  DefaultLivenessProbe,
+		Readiness: DefaultReadinessProbe,
+	}
+
+	spec := a.APIcastCR.Spec.Probes
+	if spec == nil {
+		return probes
+	}
+
+	probes.Liveness = probeOptions(probes.Liveness, spec.Liveness)
+	probes.Readiness = probeOptions(probes.Readiness, spec.Readiness)
+	// the startup probe is opt-in, adding it rolls out the pods
+	if spec.Startup != nil {
+		startup := probeOptions(DefaultStartupProbe, spec.Startup)
+		probes.Startup = &startup
+	}
+
+	return probes
+}
+
+// probeOptions overrides the probe defaults with the settings of the probe spec
+func probeOptions(defaults ProbeOptions, spec *appsv1alpha1.ProbeSpec) ProbeOptions {
+	probe := defaults
+	if spec == nil {
+		return probe
+	}
+
+	if spec.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *spec.InitialDelaySeconds
+	}
+	if spec.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *spec.TimeoutSeconds
+	}
+	if spec.PeriodSeconds != nil {
+		probe.PeriodSeconds = *spec.PeriodSeconds
+	}
+	if spec.SuccessThreshold != nil {
+		probe.SuccessThreshold = *spec.SuccessThreshold
+	}
+	if spec.FailureThreshold != nil {
+		probe.FailureThreshold = *spec.FailureThreshold
+	}
+
+	return probe
+}
+
+func (a *APIcastOptionsProvider) rolloutOptions() RolloutOptions {
+	rollout := RolloutOptions{
+		Strategy: appsv1.DeploymentStrategy{
+			Type: appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDeployment{
+				MaxSurge:       ptr.To(DefaultMaxSurge),
+				MaxUnavailable: ptr.To(DefaultMaxUnavailable),
+			},
+		},
+		ProgressDeadlineSeconds: DefaultProgressDeadlineSeconds,
+		RevisionHistoryLimit:    DefaultRevisionHistoryLimit,
+	}
+
+	spec := a.APIcastCR.Spec
+	if spec.DeploymentStrategy != nil {
+		if spec.DeploymentStrategy.Type != nil && *spec.DeploymentStrategy.Type == appsv1alpha1.RecreateDeploymentStrategyType {
+			rollout.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+		} else {
+			if spec.DeploymentStrategy.MaxSurge != nil {
+				rollout.Strategy.RollingUpdate.MaxSurge = ptr.To(*spec.DeploymentStrategy.MaxSurge)
+			}
+			if spec.DeploymentStrategy.MaxUnavailable != nil {
+				rollout.Strategy.RollingUpdate.MaxUnavailable = ptr.To(*spec.DeploymentStrategy.MaxUnavailable)
+			}
+		}
+	}
+
+	if spec.MinReadySeconds != nil {
+		rollout.MinReadySeconds = *spec.MinReadySeconds
+	}
+	if spec.ProgressDeadlineSeconds != nil {
+		rollout.ProgressDeadlineSeconds = *spec.ProgressDeadlineSeconds
+	}
+	if spec.RevisionHistoryLimit != nil {
+		rollout.RevisionHistoryLimit = *spec.RevisionHistoryLimit
+	}
+
+	return rollout
+}
+
+func (a *APIcastOptionsProvider) gracefulShutdownOptions() GracefulShutdownOptions {
+	gracefulShutdown := GracefulShutdownOptions{
+		TerminationGracePeriodSeconds: DefaultPodTerminationGracePeriodSeconds,
+	}
+
+	spec := a.APIcastCR.Spec.GracefulShutdown
+	if spec == nil {
+		return gracefulShutdown
+	}
+
+	gracefulShutdown.Enabled = true
+	gracefulShutdown.PreStopDelaySeconds = appsv1alpha1.DefaultPreStopDelaySeconds
+	gracefulShutdown.TerminationGracePeriodSeconds = appsv1alpha1.DefaultTerminationGracePeriodSeconds
+	if spec.PreStopDelaySeconds != nil {
+		gracefulShutdown.PreStopDelaySeconds = *spec.PreStopDelaySeconds
+	}
+	if spec.TerminationGracePeriodSeconds != nil {
+		gracefulShutdown.TerminationGracePeriodSeconds = *spec.TerminationGracePeriodSeconds
+	}
+
+	return gracefulShutdown
+}
+
+func (a *APIcastOptionsProvider) adminPortalCheckOptions() AdminPortalCheckOptions {
+	spec := a.APIcastCR.Spec.AdminPortalCheck
+	if spec == nil {
+		return AdminPortalCheckOptions{}
+	}
+
+	adminPortalCheck := AdminPortalCheckOptions{
+		Enabled:         true,
+		IntervalSeconds: appsv1alpha1.DefaultAdminPortalCheckIntervalSeconds,
+	}
+	if spec.IntervalSeconds != nil {
+		adminPortalCheck.IntervalSeconds = *spec.IntervalSeconds
+	}
+
+	return adminPortalCheck
+}
+
+func (a *APIcastOptionsProvider) blueGreenOptions() BlueGreenOptions {
+	spec := a.APIcastCR.Spec.BlueGreen
+	if spec == nil {
+		return BlueGreenOptions{}
+	}
+
+	blueGreen := BlueGreenOptions{Enabled: true}
+	if spec.ActiveColor != nil {
+		blueGreen.ActiveColor = string(*spec.ActiveColor)
+	}
+
+	return blueGreen
+}
+
+func (a *APIcastOptionsProvider) canaryOptions() CanaryOptions {
+	spec := a.APIcastCR.Spec.Canary
+	if spec == nil {
+		return CanaryOptions{}
+	}
+
+	canary := CanaryOptions{
+		Enabled:            true,
+		ReplicasPercentage: appsv1alpha1.DefaultCanaryReplicasPercentage,
+		AnalysisSeconds:    appsv1alpha1.DefaultCanaryAnalysisSeconds,
+	}
+	if spec.ReplicasPercentage != nil {
+		canary.ReplicasPercentage = *spec.ReplicasPercentage
+	}
+	if spec.AnalysisSeconds != nil {
+		canary.AnalysisSeconds = *spec.AnalysisSeconds
+	}
+
+	if spec.ErrorRate != nil {
+		canary.ErrorRate = &CanaryErrorRateOptions{
+			PrometheusURL: spec.ErrorRate.PrometheusURL,
+			MaxPercentage: appsv1alpha1.DefaultCanaryErrorRateMaxPercentage,
+		}
+		if spec.ErrorRate.MaxPercentage != nil {
+			canary.ErrorRate.MaxPercentage = *spec.ErrorRate.MaxPercentage
+		}
+	}
+
+	return canary
+}
+
+func (a *APIcastOptionsProvider) dashboardOptions() DashboardOptions {
+	dashboard := DashboardOptions{
+		Enabled: a.APIcastCR.IsMonitoringDashboardEnabled(),
+		Labels:  map[string]string{GrafanaDashboardSidecarLabel: "1"},
+	}
+
+	spec := a.APIcastCR.Spec.Monitoring.Dashboard
+	if spec == nil {
+		return dashboard
+	}
+
+	if spec.Labels != nil {
+		dashboard.Labels = spec.Labels
+	}
+
+	if spec.InstanceSelector != nil {
+		dashboard.InstanceSelector = *spec.InstanceSelector
+	}
+
+	return dashboard
+}
+
+func (a *APIcastOptionsProvider) alertsOptions() AlertsOptions {
+	alerts := AlertsOptions{
+		Enabled:                 a.APIcastCR.IsMonitoringAlertsEnabled(),
+		PodsNotReadyFor:         DefaultAlertPodsNotReadyFor,
+		HTTP5xxRatioThreshold:   DefaultAlertHTTP5xxRatioThreshold,
+		WorkerRestartsThreshold: DefaultAlertWorkerRestartsThreshold,
+		ErrorLogThreshold:       DefaultAlertErrorLogThreshold,
+		Severity:                DefaultAlertSeverity,
+	}
+
+	spec := a.APIcastCR.Spec.Monitoring.Alerts
+	if spec == nil {
+		return alerts
+	}
+
+	if spec.PodsNotReadyFor != nil {
+		alerts.PodsNotReadyFor = *spec.PodsNotReadyFor
+	}
+
+	if spec.HTTP5xxRatioThreshold != nil {
+		alerts.HTTP5xxRatioThreshold = *spec.HTTP5xxRatioThreshold
+	}
+
+	if spec.WorkerRestartsThreshold != nil {
+		alerts.WorkerRestartsThreshold = *spec.WorkerRestartsThreshold
+	}
+
+	if spec.ErrorLogThreshold != nil {
+		alerts.ErrorLogThreshold = *spec.ErrorLogThreshold
+	}
+
+	if spec.Severity != nil {
+		alerts.Severity = *spec.Severity
+	}
+
+	return alerts
+}
+
 func (a *APIcastOptionsProvider) getTracingConfigOptions(ctx context.Context) (TracingConfig, error) {
 	tracingIsEnabled := a.APIcastCR.OpenTracingIsEnabled()
 	res := TracingConfig{
@@ -258,6 +613,36 @@ func (a *APIcastOptionsProvider) getTracingConfigOptions(ctx context.Context) (T
 	return res, nil
 }
 
+// resolveImage returns the digest of the image. The digest resolved by a
+// previous reconciliation is kept while the image does not change, so moving
+// the tag in the registry does not roll out the pods.
+func (a *APIcastOptionsProvider) resolveImage(ctx context.Context, image string) (*appsv1alpha1.ResolvedImageStatus, error) {
+	if resolved := a.APIcastCR.Status.ResolvedImage; resolved != nil && resolved.Image == image {
+		return resolved.DeepCopy(), nil
+	}
+
+	if a.ImageResolver == nil {
+		return nil, fmt.Errorf("image digest pinning is not supported by the operator")
+	}
+
+	pullSecrets := []v1.Secret{}
+	for _, ref := range a.APIcastCR.Spec.ImagePullSecrets {
+		secret := v1.Secret{}
+		err := a.Client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: a.APIcastCR.Namespace}, &secret)
+		if err != nil {
+			return nil, fmt.Errorf("getting image pull secret %s: %w", ref.Name, err)
+		}
+		pullSecrets = append(pullSecrets, secret)
+	}
+
+	digest, err := a.ImageResolver.Resolve(ctx, image, pullSecrets)
+	if err != nil {
+		return nil, err
+	}
+
+	return &appsv1alpha1.ResolvedImageStatus{Image: image, Digest: digest}, nil
+}
+
 func (a *APIcastOptionsProvider) getGatewayEmbeddedConfigSecret(ctx context.Context) (*v1.Secret, error) {
 	if a.APIcastCR.Spec.EmbeddedConfigurationSecretRef == nil {
 		return nil, nil
@@ -471,13 +856,17 @@ func (a *APIcastOptionsProvider) commonLabels() map[string]string {
 	}
 }
 
-func (a *APIcastOptionsProvider) podTemplateLabels(labelSelector map[string]string) map[string]string {
+func (a *APIcastOptionsProvider) podTemplateLabels(extraLabels, labelSelector map[string]string) map[string]string {
 	meteringLabels := helper.MeteringLabels(helper.ApplicationType)
 
 	// merge maps
 
 	result := make(map[string]string)
 
+	for k, v := range extraLabels {
+		result[k] = v
+	}
+
 	for k, v := range meteringLabels {
 		result[k] = v
 	}
@@ -550,3 +939,56 @@ func (a *APIcastOptionsProvider) getOpenTelemetryConfig(ctx context.Context) (Op
 
 	return res, nil
 }
+
+func (a *APIcastOptionsProvider) exposedHostRoute() *ExposedHostRoute {
+	if !a.APIcastCR.IsRouteEnabled() {
+		return nil
+	}
+
+	route := a.APIcastCR.Spec.ExposedHost.Route
+	res := &ExposedHostRoute{WildcardPolicy: routev1.WildcardPolicyNone}
+
+	if route.Termination != nil {
+		termination := routev1.TLSTerminationType(*route.Termination)
+		res.Termination = &termination
+	}
+
+	if route.InsecureEdgeTerminationPolicy != nil {
+		policy := routev1.InsecureEdgeTerminationPolicyType(*route.InsecureEdgeTerminationPolicy)
+		res.InsecureEdgeTerminationPolicy = &policy
+	}
+
+	if route.WildcardPolicy != nil {
+		res.WildcardPolicy = routev1.WildcardPolicyType(*route.WildcardPolicy)
+	}
+
+	return res
+}
+
+func (a *APIcastOptionsProvider) exposedHostGateway() *ExposedHostGateway {
+	if !a.APIcastCR.IsGatewayEnabled() {
+		return nil
+	}
+
+	gateway := a.APIcastCR.Spec.ExposedHost.Gateway
+	res := &ExposedHostGateway{
+		ParentRefs:         gateway.ParentRefs,
+		Hostnames:          gateway.Hostnames,
+		PathMatches:        gateway.PathMatches,
+		TLSRouteParentRefs: gateway.TLSRouteParentRefs,
+	}
+
+	if len(res.Hostnames) == 0 {
+		res.Hostnames = []gatewayapiv1.Hostname{gatewayapiv1.Hostname(a.APIcastCR.Spec.ExposedHost.Host)}
+	}
+
+	return res
+}
+
+// valueOrDefault returns the value of the APIcast CR, or the operator default when not set
+func valueOrDefault(value, defaultValue *string) *string {
+	if value != nil {
+		return value
+	}
+	return defaultValue
+}
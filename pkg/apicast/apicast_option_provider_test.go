@@ -10,12 +10,16 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
 )
@@ -347,6 +351,22 @@ func TestExposedHostOption(t *testing.T) {
 				},
 			},
 		},
+		{
+			"with gateway",
+			&appsv1alpha1.APIcastExposedHost{
+				Host: "example",
+				Gateway: &appsv1alpha1.APIcastExposedHostGateway{
+					ParentRefs: []gatewayapiv1.ParentReference{{Name: "gw"}},
+				},
+			},
+			ExposedHost{
+				Host: "example",
+				Gateway: &ExposedHostGateway{
+					ParentRefs: []gatewayapiv1.ParentReference{{Name: "gw"}},
+					Hostnames:  []gatewayapiv1.Hostname{"example"},
+				},
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.testName, func(subT *testing.T) {
@@ -381,3 +401,517 @@ func TestExposedHostOption(t *testing.T) {
 		})
 	}
 }
+
+func TestProbesOptions(t *testing.T) {
+	namespace := "my-ns"
+	embeddedConfigSecret := GetTestSecret(namespace, "my-secret", map[string]string{"config.json": "{}"})
+
+	cases := []struct {
+		testName string
+		probes   *appsv1alpha1.ProbesSpec
+		expected ProbesOptions
+	}{
+		{
+			"Defaults", nil,
+			ProbesOptions{Liveness: DefaultLivenessProbe, Readiness: DefaultReadinessProbe},
+		},
+		{
+			"StartupEnabled",
+			&appsv1alpha1.ProbesSpec{Startup: &appsv1alpha1.ProbeSpec{}},
+			ProbesOptions{Liveness: DefaultLivenessProbe, Readiness: DefaultReadinessProbe, Startup: &DefaultStartupProbe},
+		},
+		{
+			"StartupOverride",
+			&appsv1alpha1.ProbesSpec{Startup: &appsv1alpha1.ProbeSpec{FailureThreshold: ptr.To(int32(60)), PeriodSeconds: ptr.To(int32(5))}},
+			ProbesOptions{
+				Liveness:  DefaultLivenessProbe,
+				Readiness: DefaultReadinessProbe,
+				Startup: &ProbeOptions{
+					InitialDelaySeconds: DefaultStartupProbe.InitialDelaySeconds,
+					TimeoutSeconds:      DefaultStartupProbe.TimeoutSeconds,
+					PeriodSeconds:       5,
+					SuccessThreshold:    DefaultStartupProbe.SuccessThreshold,
+					FailureThreshold:    60,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apicastCR := &appsv1alpha1.APIcast{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance1", Namespace: namespace},
+				Spec: appsv1alpha1.APIcastSpec{
+					EmbeddedConfigurationSecretRef: &v1.LocalObjectReference{Name: "my-secret"},
+					Probes:                         tc.probes,
+				},
+			}
+
+			cl := fake.NewClientBuilder().WithRuntimeObjects(embeddedConfigSecret).Build()
+			opts, err := NewApicastOptionsProvider(apicastCR, cl).GetApicastOptions(context.TODO())
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if !reflect.DeepEqual(tc.expected, opts.Probes) {
+				subT.Fatal(cmp.Diff(tc.expected, opts.Probes))
+			}
+		})
+	}
+}
+
+func TestRolloutOptions(t *testing.T) {
+	namespace := "my-ns"
+	embeddedConfigSecret := GetTestSecret(namespace, "my-secret", map[string]string{"config.json": "{}"})
+	recreate := appsv1alpha1.RecreateDeploymentStrategyType
+
+	cases := []struct {
+		testName string
+		spec     appsv1alpha1.APIcastSpec
+		expected RolloutOptions
+	}{
+		{
+			"Defaults", appsv1alpha1.APIcastSpec{},
+			RolloutOptions{
+				Strategy: appsv1.DeploymentStrategy{
+					Type: appsv1.RollingUpdateDeploymentStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateDeployment{
+						MaxSurge:       ptr.To(DefaultMaxSurge),
+						MaxUnavailable: ptr.To(DefaultMaxUnavailable),
+					},
+				},
+				ProgressDeadlineSeconds: DefaultProgressDeadlineSeconds,
+				RevisionHistoryLimit:    DefaultRevisionHistoryLimit,
+			},
+		},
+		{
+			"ZeroDowntimeRollingUpdate",
+			appsv1alpha1.APIcastSpec{
+				DeploymentStrategy:      &appsv1alpha1.DeploymentStrategySpec{MaxUnavailable: ptr.To(intstr.FromInt32(0))},
+				MinReadySeconds:         ptr.To(int32(10)),
+				ProgressDeadlineSeconds: ptr.To(int32(300)),
+				RevisionHistoryLimit:    ptr.To(int32(3)),
+			},
+			RolloutOptions{
+				Strategy: appsv1.DeploymentStrategy{
+					Type: appsv1.RollingUpdateDeploymentStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateDeployment{
+						MaxSurge:       ptr.To(DefaultMaxSurge),
+						MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+					},
+				},
+				MinReadySeconds:         10,
+				ProgressDeadlineSeconds: 300,
+				RevisionHistoryLimit:    3,
+			},
+		},
+		{
+			"Recreate",
+			appsv1alpha1.APIcastSpec{DeploymentStrategy: &appsv1alpha1.DeploymentStrategySpec{Type: &recreate}},
+			RolloutOptions{
+				Strategy:                appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+				ProgressDeadlineSeconds: DefaultProgressDeadlineSeconds,
+				RevisionHistoryLimit:    DefaultRevisionHistoryLimit,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apicastCR := &appsv1alpha1.APIcast{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance1", Namespace: namespace},
+				Spec:       tc.spec,
+			}
+			apicastCR.Spec.EmbeddedConfigurationSecretRef = &v1.LocalObjectReference{Name: "my-secret"}
+
+			cl := fake.NewClientBuilder().WithRuntimeObjects(embeddedConfigSecret).Build()
+			opts, err := NewApicastOptionsProvider(apicastCR, cl).GetApicastOptions(context.TODO())
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if !reflect.DeepEqual(tc.expected, opts.Rollout) {
+				subT.Fatal(cmp.Diff(tc.expected, opts.Rollout))
+			}
+		})
+	}
+}
+
+func TestGracefulShutdownOptions(t *testing.T) {
+	namespace := "my-ns"
+	embeddedConfigSecret := GetTestSecret(namespace, "my-secret", map[string]string{"config.json": "{}"})
+
+	cases := []struct {
+		testName         string
+		gracefulShutdown *appsv1alpha1.GracefulShutdownSpec
+		expected         GracefulShutdownOptions
+	}{
+		{
+			"Disabled", nil,
+			GracefulShutdownOptions{TerminationGracePeriodSeconds: DefaultPodTerminationGracePeriodSeconds},
+		},
+		{
+			"Defaults", &appsv1alpha1.GracefulShutdownSpec{},
+			GracefulShutdownOptions{
+				Enabled:                       true,
+				PreStopDelaySeconds:           appsv1alpha1.DefaultPreStopDelaySeconds,
+				TerminationGracePeriodSeconds: appsv1alpha1.DefaultTerminationGracePeriodSeconds,
+			},
+		},
+		{
+			"Custom",
+			&appsv1alpha1.GracefulShutdownSpec{PreStopDelaySeconds: ptr.To(int32(5)), TerminationGracePeriodSeconds: ptr.To(int64(120))},
+			GracefulShutdownOptions{Enabled: true, PreStopDelaySeconds: 5, TerminationGracePeriodSeconds: 120},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apicastCR := &appsv1alpha1.APIcast{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance1", Namespace: namespace},
+				Spec: appsv1alpha1.APIcastSpec{
+					EmbeddedConfigurationSecretRef: &v1.LocalObjectReference{Name: "my-secret"},
+					GracefulShutdown:               tc.gracefulShutdown,
+				},
+			}
+
+			cl := fake.NewClientBuilder().WithRuntimeObjects(embeddedConfigSecret).Build()
+			opts, err := NewApicastOptionsProvider(apicastCR, cl).GetApicastOptions(context.TODO())
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if !reflect.DeepEqual(tc.expected, opts.GracefulShutdown) {
+				subT.Fatal(cmp.Diff(tc.expected, opts.GracefulShutdown))
+			}
+		})
+	}
+}
+
+func TestCanaryOptions(t *testing.T) {
+	namespace := "my-ns"
+	embeddedConfigSecret := GetTestSecret(namespace, "my-secret", map[string]string{"config.json": "{}"})
+
+	cases := []struct {
+		testName string
+		canary   *appsv1alpha1.CanarySpec
+		expected CanaryOptions
+	}{
+		{"Disabled", nil, CanaryOptions{}},
+		{
+			"Defaults", &appsv1alpha1.CanarySpec{},
+			CanaryOptions{
+				Enabled:            true,
+				ReplicasPercentage: appsv1alpha1.DefaultCanaryReplicasPercentage,
+				AnalysisSeconds:    appsv1alpha1.DefaultCanaryAnalysisSeconds,
+			},
+		},
+		{
+			"ErrorRate",
+			&appsv1alpha1.CanarySpec{
+				ReplicasPercentage: ptr.To(int32(50)),
+				ErrorRate:          &appsv1alpha1.CanaryErrorRateSpec{PrometheusURL: "http://prometheus:9090"},
+			},
+			CanaryOptions{
+				Enabled:            true,
+				ReplicasPercentage: 50,
+				AnalysisSeconds:    appsv1alpha1.DefaultCanaryAnalysisSeconds,
+				ErrorRate: &CanaryErrorRateOptions{
+					PrometheusURL: "http://prometheus:9090",
+					MaxPercentage: appsv1alpha1.DefaultCanaryErrorRateMaxPercentage,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apicastCR := &appsv1alpha1.APIcast{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance1", Namespace: namespace},
+				Spec: appsv1alpha1.APIcastSpec{
+					EmbeddedConfigurationSecretRef: &v1.LocalObjectReference{Name: "my-secret"},
+					Canary:                         tc.canary,
+				},
+			}
+
+			cl := fake.NewClientBuilder().WithRuntimeObjects(embeddedConfigSecret).Build()
+			opts, err := NewApicastOptionsProvider(apicastCR, cl).GetApicastOptions(context.TODO())
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if !reflect.DeepEqual(tc.expected, opts.Canary) {
+				subT.Fatal(cmp.Diff(tc.expected, opts.Canary))
+			}
+		})
+	}
+}
+
+func TestBlueGreenOptions(t *testing.T) {
+	namespace := "my-ns"
+	embeddedConfigSecret := GetTestSecret(namespace, "my-secret", map[string]string{"config.json": "{}"})
+	green := appsv1alpha1.BlueGreenColorGreen
+
+	cases := []struct {
+		testName  string
+		blueGreen *appsv1alpha1.BlueGreenSpec
+		expected  BlueGreenOptions
+	}{
+		{"Disabled", nil, BlueGreenOptions{}},
+		{"Enabled", &appsv1alpha1.BlueGreenSpec{}, BlueGreenOptions{Enabled: true}},
+		{"Pinned", &appsv1alpha1.BlueGreenSpec{ActiveColor: &green}, BlueGreenOptions{Enabled: true, ActiveColor: "green"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apicastCR := &appsv1alpha1.APIcast{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance1", Namespace: namespace},
+				Spec: appsv1alpha1.APIcastSpec{
+					EmbeddedConfigurationSecretRef: &v1.LocalObjectReference{Name: "my-secret"},
+					BlueGreen:                      tc.blueGreen,
+				},
+			}
+
+			cl := fake.NewClientBuilder().WithRuntimeObjects(embeddedConfigSecret).Build()
+			opts, err := NewApicastOptionsProvider(apicastCR, cl).GetApicastOptions(context.TODO())
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if !reflect.DeepEqual(tc.expected, opts.BlueGreen) {
+				subT.Fatal(cmp.Diff(tc.expected, opts.BlueGreen))
+			}
+		})
+	}
+}
+
+func TestAdminPortalCheckOptions(t *testing.T) {
+	namespace := "my-ns"
+	adminPortalSecret := GetTestSecret(namespace, "my-secret", map[string]string{AdminPortalURLAttributeName: "https://token@3scale-admin.example.com"})
+
+	cases := []struct {
+		testName         string
+		adminPortalCheck *appsv1alpha1.AdminPortalCheckSpec
+		expected         AdminPortalCheckOptions
+	}{
+		{"Disabled", nil, AdminPortalCheckOptions{}},
+		{"DefaultInterval", &appsv1alpha1.AdminPortalCheckSpec{}, AdminPortalCheckOptions{Enabled: true, IntervalSeconds: appsv1alpha1.DefaultAdminPortalCheckIntervalSeconds}},
+		{"CustomInterval", &appsv1alpha1.AdminPortalCheckSpec{IntervalSeconds: ptr.To(int32(60))}, AdminPortalCheckOptions{Enabled: true, IntervalSeconds: 60}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apicastCR := &appsv1alpha1.APIcast{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance1", Namespace: namespace},
+				Spec: appsv1alpha1.APIcastSpec{
+					AdminPortalCredentialsRef: &v1.LocalObjectReference{Name: "my-secret"},
+					AdminPortalCheck:          tc.adminPortalCheck,
+				},
+			}
+
+			cl := fake.NewClientBuilder().WithRuntimeObjects(adminPortalSecret).Build()
+			opts, err := NewApicastOptionsProvider(apicastCR, cl).GetApicastOptions(context.TODO())
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if !reflect.DeepEqual(tc.expected, opts.AdminPortalCheck) {
+				subT.Fatal(cmp.Diff(tc.expected, opts.AdminPortalCheck))
+			}
+		})
+	}
+}
+
+func TestOperatorConfigDefaults(t *testing.T) {
+	namespace := "my-ns"
+	embeddedConfigSecret := GetTestSecret(namespace, "my-secret", map[string]string{"config.json": "{}"})
+
+	configResources := v1.ResourceRequirements{
+		Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("256Mi")},
+	}
+	crResources := v1.ResourceRequirements{
+		Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("512Mi")},
+	}
+	configTolerations := []v1.Toleration{{Key: "gateways", Operator: v1.TolerationOpExists}}
+	crTolerations := []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpExists}}
+
+	operatorConfig := &OperatorConfig{
+		ImageRegistry: "mirror.example.com",
+		Resources:     &configResources,
+		Tolerations:   configTolerations,
+		HTTPProxy:     ptr.To("http://proxy:8080"),
+		NoProxy:       ptr.To("example.com"),
+		Labels:        map[string]string{"cost-center": "api", "deployment": "overridden"},
+		Annotations:   map[string]string{"owner": "platform"},
+		LogLevel:      ptr.To("warn"),
+	}
+
+	t.Run("Defaults", func(subT *testing.T) {
+		apicastCR := &appsv1alpha1.APIcast{
+			ObjectMeta: metav1.ObjectMeta{Name: "instance1", Namespace: namespace},
+			Spec: appsv1alpha1.APIcastSpec{
+				EmbeddedConfigurationSecretRef: &v1.LocalObjectReference{Name: "my-secret"},
+			},
+		}
+
+		cl := fake.NewClientBuilder().WithRuntimeObjects(embeddedConfigSecret).Build()
+		optsProvider := NewApicastOptionsProvider(apicastCR, cl)
+		optsProvider.OperatorConfig = operatorConfig
+		opts, err := optsProvider.GetApicastOptions(context.TODO())
+		if err != nil {
+			subT.Fatal(err)
+		}
+
+		if opts.Image != ImageWithRegistry(GetDefaultImageVersion(), "mirror.example.com") {
+			subT.Errorf("unexpected image %s", opts.Image)
+		}
+		if !reflect.DeepEqual(opts.ResourceRequirements, configResources) {
+			subT.Error(cmp.Diff(configResources, opts.ResourceRequirements))
+		}
+		if !reflect.DeepEqual(opts.Tolerations, configTolerations) {
+			subT.Error(cmp.Diff(configTolerations, opts.Tolerations))
+		}
+		if ptr.Deref(opts.HTTPProxy, "") != "http://proxy:8080" || ptr.Deref(opts.NoProxy, "") != "example.com" || opts.HTTPSProxy != nil {
+			subT.Errorf("unexpected proxy settings %v %v %v", opts.HTTPProxy, opts.HTTPSProxy, opts.NoProxy)
+		}
+		if ptr.Deref(opts.LogLevel, "") != "warn" {
+			subT.Errorf("unexpected log level %v", opts.LogLevel)
+		}
+		// the pod label selector cannot be overridden
+		if opts.PodTemplateLabels["cost-center"] != "api" || opts.PodTemplateLabels["deployment"] != "apicast-instance1" {
+			subT.Errorf("unexpected pod labels %v", opts.PodTemplateLabels)
+		}
+		if opts.PodTemplateAnnotations["owner"] != "platform" {
+			subT.Errorf("unexpected pod annotations %v", opts.PodTemplateAnnotations)
+		}
+	})
+
+	t.Run("CRValuesTakePrecedence", func(subT *testing.T) {
+		apicastCR := &appsv1alpha1.APIcast{
+			ObjectMeta: metav1.ObjectMeta{Name: "instance1", Namespace: namespace},
+			Spec: appsv1alpha1.APIcastSpec{
+				EmbeddedConfigurationSecretRef: &v1.LocalObjectReference{Name: "my-secret"},
+				Image:                          ptr.To("registry.example.com/apicast:custom"),
+				Resources:                      &crResources,
+				Tolerations:                    crTolerations,
+				HTTPProxy:                      ptr.To("http://other-proxy:8080"),
+				LogLevel:                       ptr.To("debug"),
+			},
+		}
+
+		cl := fake.NewClientBuilder().WithRuntimeObjects(embeddedConfigSecret).Build()
+		optsProvider := NewApicastOptionsProvider(apicastCR, cl)
+		optsProvider.OperatorConfig = operatorConfig
+		opts, err := optsProvider.GetApicastOptions(context.TODO())
+		if err != nil {
+			subT.Fatal(err)
+		}
+
+		if opts.Image != "registry.example.com/apicast:custom" {
+			subT.Errorf("unexpected image %s", opts.Image)
+		}
+		if !reflect.DeepEqual(opts.ResourceRequirements, crResources) {
+			subT.Error(cmp.Diff(crResources, opts.ResourceRequirements))
+		}
+		if !reflect.DeepEqual(opts.Tolerations, crTolerations) {
+			subT.Error(cmp.Diff(crTolerations, opts.Tolerations))
+		}
+		if ptr.Deref(opts.HTTPProxy, "") != "http://other-proxy:8080" {
+			subT.Errorf("unexpected http proxy %v", opts.HTTPProxy)
+		}
+		if ptr.Deref(opts.LogLevel, "") != "debug" {
+			subT.Errorf("unexpected log level %v", opts.LogLevel)
+		}
+	})
+}
+
+// fakeImageResolver pins every image to the same digest and counts the resolutions
+type fakeImageResolver struct {
+	resolutions int
+}
+
+func (f *fakeImageResolver) Resolve(_ context.Context, image string, _ []v1.Secret) (string, error) {
+	f.resolutions++
+	return strings.SplitN(image, ":", 2)[0] + "@sha256:0123", nil
+}
+
+func TestImagePullOptions(t *testing.T) {
+	namespace := "my-ns"
+	embeddedConfigSecret := GetTestSecret(namespace, "my-secret", map[string]string{"config.json": "{}"})
+
+	apicastCR := func(status appsv1alpha1.APIcastStatus) *appsv1alpha1.APIcast {
+		return &appsv1alpha1.APIcast{
+			ObjectMeta: metav1.ObjectMeta{Name: "instance1", Namespace: namespace},
+			Spec: appsv1alpha1.APIcastSpec{
+				EmbeddedConfigurationSecretRef: &v1.LocalObjectReference{Name: "my-secret"},
+				Image:                          ptr.To("quay.io/3scale/apicast:3.15"),
+				ImagePullPolicy:                ptr.To(v1.PullIfNotPresent),
+				ImagePullSecrets:               []v1.LocalObjectReference{{Name: "registry"}},
+				ImageDigestPinning:             ptr.To(true),
+			},
+			Status: status,
+		}
+	}
+
+	cases := []struct {
+		testName            string
+		status              appsv1alpha1.APIcastStatus
+		expectedResolutions int
+	}{
+		{"NotResolved", appsv1alpha1.APIcastStatus{}, 1},
+		{"AlreadyResolved", appsv1alpha1.APIcastStatus{ResolvedImage: &appsv1alpha1.ResolvedImageStatus{
+			Image: "quay.io/3scale/apicast:3.15", Digest: "quay.io/3scale/apicast@sha256:0123",
+		}}, 0},
+		{"ImageChanged", appsv1alpha1.APIcastStatus{ResolvedImage: &appsv1alpha1.ResolvedImageStatus{
+			Image: "quay.io/3scale/apicast:3.14", Digest: "quay.io/3scale/apicast@sha256:4567",
+		}}, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			pullSecret := GetTestSecret(namespace, "registry", map[string]string{v1.DockerConfigJsonKey: "{}"})
+			cl := fake.NewClientBuilder().WithRuntimeObjects(embeddedConfigSecret, pullSecret).Build()
+			resolver := &fakeImageResolver{}
+			optsProvider := NewApicastOptionsProvider(apicastCR(tc.status), cl)
+			optsProvider.ImageResolver = resolver
+			opts, err := optsProvider.GetApicastOptions(context.TODO())
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			if opts.Image != "quay.io/3scale/apicast@sha256:0123" {
+				subT.Errorf("unexpected image %s", opts.Image)
+			}
+			expectedResolvedImage := &appsv1alpha1.ResolvedImageStatus{
+				Image: "quay.io/3scale/apicast:3.15", Digest: "quay.io/3scale/apicast@sha256:0123",
+			}
+			if !reflect.DeepEqual(opts.ResolvedImage, expectedResolvedImage) {
+				subT.Error(cmp.Diff(expectedResolvedImage, opts.ResolvedImage))
+			}
+			if resolver.resolutions != tc.expectedResolutions {
+				subT.Errorf("expected %d resolutions, got %d", tc.expectedResolutions, resolver.resolutions)
+			}
+			if opts.ImagePullPolicy != v1.PullIfNotPresent {
+				subT.Errorf("unexpected image pull policy %s", opts.ImagePullPolicy)
+			}
+			if len(opts.ImagePullSecrets) != 1 || opts.ImagePullSecrets[0].Name != "registry" {
+				subT.Errorf("unexpected image pull secrets %v", opts.ImagePullSecrets)
+			}
+		})
+	}
+
+	t.Run("Defaults", func(subT *testing.T) {
+		cr := apicastCR(appsv1alpha1.APIcastStatus{})
+		cr.Spec.Image = nil
+		cr.Spec.ImagePullPolicy = nil
+		cr.Spec.ImagePullSecrets = nil
+		cr.Spec.ImageDigestPinning = nil
+
+		cl := fake.NewClientBuilder().WithRuntimeObjects(embeddedConfigSecret).Build()
+		opts, err := NewApicastOptionsProvider(cr, cl).GetApicastOptions(context.TODO())
+		if err != nil {
+			subT.Fatal(err)
+		}
+
+		if opts.Image != GetDefaultImageVersion() || opts.ResolvedImage != nil {
+			subT.Errorf("unexpected image %s, resolved %v", opts.Image, opts.ResolvedImage)
+		}
+		if opts.ImagePullPolicy != v1.PullAlways || opts.ImagePullSecrets != nil {
+			subT.Errorf("unexpected image pull settings %s %v", opts.ImagePullPolicy, opts.ImagePullSecrets)
+		}
+	})
+}
@@ -2,16 +2,114 @@ package apicast
 
 import (
 	validator "github.com/go-playground/validator/v10"
+	routev1 "github.com/openshift/api/route/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
 )
 
 type ExposedHost struct {
 	Host             string
 	IngressClassName *string
 	TLS              []networkingv1.IngressTLS
+	Route            *ExposedHostRoute
+	Gateway          *ExposedHostGateway
+}
+
+type ExposedHostGateway struct {
+	ParentRefs         []gatewayapiv1.ParentReference
+	Hostnames          []gatewayapiv1.Hostname
+	PathMatches        []gatewayapiv1.HTTPPathMatch
+	TLSRouteParentRefs []gatewayapiv1.ParentReference
+}
+
+type ExposedHostRoute struct {
+	Termination                   *routev1.TLSTerminationType
+	InsecureEdgeTerminationPolicy *routev1.InsecureEdgeTerminationPolicyType
+	WildcardPolicy                routev1.WildcardPolicyType
+}
+
+type ProbeOptions struct {
+	InitialDelaySeconds int32
+	TimeoutSeconds      int32
+	PeriodSeconds       int32
+	SuccessThreshold    int32
+	FailureThreshold    int32
+}
+
+type ProbesOptions struct {
+	Liveness  ProbeOptions
+	Readiness ProbeOptions
+	// Startup is nil unless the startup probe is enabled in the spec
+	Startup *ProbeOptions
+}
+
+type RolloutOptions struct {
+	Strategy                appsv1.DeploymentStrategy
+	MinReadySeconds         int32
+	ProgressDeadlineSeconds int32
+	RevisionHistoryLimit    int32
+}
+
+type GracefulShutdownOptions struct {
+	Enabled                       bool
+	PreStopDelaySeconds           int32
+	TerminationGracePeriodSeconds int64
+}
+
+type CanaryOptions struct {
+	Enabled            bool
+	ReplicasPercentage int32
+	AnalysisSeconds    int32
+	ErrorRate          *CanaryErrorRateOptions
+}
+
+type CanaryErrorRateOptions struct {
+	PrometheusURL string
+	MaxPercentage int32
+}
+
+type AdminPortalCheckOptions struct {
+	Enabled         bool
+	IntervalSeconds int32
+}
+
+type BlueGreenOptions struct {
+	Enabled bool
+	// ActiveColor is the pinned color, empty when the traffic follows the latest configuration
+	ActiveColor string
+}
+
+type MonitoringOptions struct {
+	Enabled       bool
+	Kind          string
+	Interval      monitoringv1.Duration
+	ScrapeTimeout monitoringv1.Duration
+	Relabelings   []*monitoringv1.RelabelConfig
+	Labels        map[string]string
+	Alerts        AlertsOptions
+	Dashboard     DashboardOptions
+}
+
+type DashboardOptions struct {
+	Enabled          bool
+	Labels           map[string]string
+	InstanceSelector metav1.LabelSelector
+}
+
+type AlertsOptions struct {
+	Enabled                 bool
+	PodsNotReadyFor         monitoringv1.Duration
+	HTTP5xxRatioThreshold   int32
+	WorkerRestartsThreshold int32
+	ErrorLogThreshold       int32
+	Severity                string
 }
 
 type CustomPolicy struct {
@@ -40,6 +138,8 @@ type APIcastOptions struct {
 	Replicas                     int32
 	ServiceAccountName           string                        `validate:"required"`
 	Image                        string                        `validate:"required"`
+	ImagePullPolicy              v1.PullPolicy                 `validate:"-"`
+	ImagePullSecrets             []v1.LocalObjectReference     `validate:"-"`
 	ExposedHost                  ExposedHost                   `validate:"-"`
 	AdminPortalCredentialsSecret *v1.Secret                    `validate:"required_without=GatewayConfigurationSecret"`
 	GatewayConfigurationSecret   *v1.Secret                    `validate:"required_without=AdminPortalCredentialsSecret"`
@@ -48,6 +148,11 @@ type APIcastOptions struct {
 	TopologySpreadConstraints    []v1.TopologySpreadConstraint `validate:"-"`
 	PriorityClassName            string                        `validate:"-"`
 	ResourceRequirements         v1.ResourceRequirements       `validate:"-"`
+	Sidecars                     []v1.Container                `validate:"-"`
+	InitContainers               []v1.Container                `validate:"-"`
+	ExtraVolumes                 []v1.Volume                   `validate:"-"`
+	ExtraVolumeMounts            []v1.VolumeMount              `validate:"-"`
+	PodTemplateOverride          []byte                        `validate:"-"`
 	Hpa                          bool
 
 	DeploymentEnvironment               *string
@@ -86,8 +191,30 @@ type APIcastOptions struct {
 	CommonLabels      map[string]string `validate:"required"`
 	PodTemplateLabels map[string]string `validate:"required"`
 	PodLabelSelector  map[string]string `validate:"required"`
+	// PodTemplateAnnotations are the extra annotations of the pods
+	PodTemplateAnnotations map[string]string
+
+	// ResolvedImage is the image reference resolved to the digest of Image,
+	// nil when image digest pinning is disabled
+	ResolvedImage *appsv1alpha1.ResolvedImageStatus `validate:"-"`
 
 	Opentelemetry OpentelemetryConfig `validate:"-"`
+
+	Monitoring MonitoringOptions `validate:"-"`
+
+	Probes ProbesOptions `validate:"-"`
+
+	Rollout RolloutOptions `validate:"-"`
+
+	GracefulShutdown GracefulShutdownOptions `validate:"-"`
+
+	Canary CanaryOptions `validate:"-"`
+
+	BlueGreen BlueGreenOptions `validate:"-"`
+
+	AdminPortalCheck AdminPortalCheckOptions `validate:"-"`
+
+	Upgrade UpgradeOptions `validate:"-"`
 }
 
 func NewAPIcastOptions() *APIcastOptions {
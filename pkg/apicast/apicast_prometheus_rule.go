@@ -0,0 +1,111 @@
+package apicast
+
+import (
+	"fmt"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+)
+
+const (
+	DefaultAlertPodsNotReadyFor         monitoringv1.Duration = "5m"
+	DefaultAlertHTTP5xxRatioThreshold   int32                 = 5
+	DefaultAlertWorkerRestartsThreshold int32                 = 3
+	DefaultAlertErrorLogThreshold       int32                 = 10
+	DefaultAlertSeverity                                      = "warning"
+)
+
+// PrometheusRule returns the rule with the default APIcast alerts
+func (a *APIcast) PrometheusRule() *monitoringv1.PrometheusRule {
+	alerts := a.options.Monitoring.Alerts
+	forDuration := monitoringv1.Duration("5m")
+
+	podSelector := a.podMetricsSelector()
+	// the deployment is replaced by the blue and green deployments in blue/green mode
+	deploymentSelector := fmt.Sprintf(`namespace="%s",deployment=~"%s|%s|%s"`, a.options.Namespace, a.options.DeploymentName,
+		a.blueGreenDeploymentName(string(appsv1alpha1.BlueGreenColorBlue)), a.blueGreenDeploymentName(string(appsv1alpha1.BlueGreenColorGreen)))
+
+	rules := []monitoringv1.Rule{
+		{
+			Alert: "APIcastPodsNotReady",
+			Expr: intstr.FromString(fmt.Sprintf(
+				`kube_deployment_status_replicas_ready{%[1]s} < kube_deployment_spec_replicas{%[1]s}`,
+				deploymentSelector)),
+			For: &alerts.PodsNotReadyFor,
+			Annotations: map[string]string{
+				"summary":     "APIcast {{ $labels.namespace }}/{{ $labels.deployment }} has pods not ready",
+				"description": "The number of ready pods of the APIcast deployment has been below the desired replicas for more than " + string(alerts.PodsNotReadyFor),
+			},
+		},
+		{
+			Alert: "APIcastHigh5xxRatio",
+			Expr: intstr.FromString(fmt.Sprintf(
+				`sum(rate(apicast_status{%[1]s,status=~"5.."}[5m])) / sum(rate(apicast_status{%[1]s}[5m])) * 100 > %[2]d`,
+				podSelector, alerts.HTTP5xxRatioThreshold)),
+			For: &forDuration,
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("APIcast %s/%s is returning a high ratio of 5xx responses", a.options.Namespace, a.options.DeploymentName),
+				"description": fmt.Sprintf("More than %d%% of the APIcast responses over the last 5 minutes are 5xx errors", alerts.HTTP5xxRatioThreshold),
+			},
+		},
+		{
+			Alert: "APIcastWorkerRestarts",
+			Expr: intstr.FromString(fmt.Sprintf(
+				`sum by (pod) (increase(worker_process{%s}[15m])) > %d`,
+				podSelector, alerts.WorkerRestartsThreshold)),
+			Annotations: map[string]string{
+				"summary":     "APIcast pod {{ $labels.pod }} nginx workers are restarting",
+				"description": fmt.Sprintf("The nginx workers of the APIcast pod have been restarted more than %d times over the last 15 minutes, which usually means they are crashing", alerts.WorkerRestartsThreshold),
+			},
+		},
+		{
+			Alert: "APIcastErrorLogs",
+			Expr: intstr.FromString(fmt.Sprintf(
+				`sum by (pod) (increase(nginx_error_log{%s,level=~"error|crit|alert|emerg"}[5m])) > %d`,
+				podSelector, alerts.ErrorLogThreshold)),
+			Annotations: map[string]string{
+				"summary":     "APIcast pod {{ $labels.pod }} is logging errors",
+				"description": fmt.Sprintf("The APIcast pod logged more than %d errors over the last 5 minutes. Check the logs for configuration load or reload failures", alerts.ErrorLogThreshold),
+			},
+		},
+	}
+
+	for idx := range rules {
+		rules[idx].Labels = map[string]string{"severity": alerts.Severity}
+	}
+
+	prometheusRule := &monitoringv1.PrometheusRule{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: monitoringv1.SchemeGroupVersion.String(),
+			Kind:       monitoringv1.PrometheusRuleKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.options.DeploymentName,
+			Namespace: a.options.Namespace,
+			Labels:    a.monitorLabels(),
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name:  fmt.Sprintf("%s/%s.rules", a.options.Namespace, a.options.DeploymentName),
+					Rules: rules,
+				},
+			},
+		},
+	}
+
+	addOwnerRefToObject(prometheusRule, *a.options.Owner)
+	return prometheusRule
+}
+
+// podMetricsSelector returns the PromQL label matchers selecting the metrics
+// of the APIcast pods. The metrics are selected by namespace and pod name, so
+// they work with both the ServiceMonitor and the PodMonitor scrape jobs. The
+// canary pods are scraped for the canary analysis, but left out here.
+func (a *APIcast) podMetricsSelector() string {
+	return fmt.Sprintf(`namespace="%[1]s",pod=~"%[2]s-.*",pod!~"%[3]s-.*"`,
+		a.options.Namespace, a.options.DeploymentName, a.canaryDeploymentName())
+}
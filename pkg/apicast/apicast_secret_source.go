@@ -0,0 +1,49 @@
+package apicast
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+)
+
+const (
+	// SecretSourceCopyLabel is set on the managed secret copies, the value is the owner APIcast name
+	SecretSourceCopyLabel = "apicast.apps.3scale.net/secret-source-copy-of"
+	// SecretSourceAnnotation records the namespace/name of the secret a managed copy was taken from
+	SecretSourceAnnotation = "apicast.apps.3scale.net/secret-source"
+)
+
+// SecretSourceCopy returns the managed copy of the source secret in the APIcast namespace.
+// The copy is watched by the operator, so changes in the copy roll out the APIcast deployment.
+func SecretSourceCopy(cr *appsv1alpha1.APIcast, secretSource appsv1alpha1.SecretSourceSpec, source *v1.Secret) *v1.Secret {
+	secret := &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretSource.Name,
+			Namespace: cr.Namespace,
+			Labels: map[string]string{
+				"apicast.apps.3scale.net/watched-by": "apicast",
+				SecretSourceCopyLabel:                cr.Name,
+			},
+			Annotations: map[string]string{
+				SecretSourceAnnotation: fmt.Sprintf("%s/%s", source.Namespace, source.Name),
+			},
+		},
+		Type: source.Type,
+		Data: make(map[string][]byte, len(source.Data)),
+	}
+
+	for key, value := range source.Data {
+		secret.Data[key] = append([]byte(nil), value...)
+	}
+
+	addOwnerRefToObject(secret, *cr.GetOwnerReference())
+
+	return secret
+}
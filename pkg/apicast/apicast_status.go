@@ -0,0 +1,100 @@
+package apicast
+
+import (
+	"fmt"
+	"strings"
+
+	routev1 "github.com/openshift/api/route/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+)
+
+const (
+	ConfigurationLoadModeBoot = "boot"
+	ConfigurationLoadModeLazy = "lazy"
+
+	stagingDeploymentEnvironment = "staging"
+)
+
+// EffectiveConfigurationLoadMode returns the configuration load mode used by
+// APIcast. When not set, the staging environment loads the configuration
+// lazily and any other environment at boot.
+func EffectiveConfigurationLoadMode(cr *appsv1alpha1.APIcast) string {
+	if cr.Spec.ConfigurationLoadMode != nil {
+		return *cr.Spec.ConfigurationLoadMode
+	}
+
+	if cr.Spec.DeploymentEnvironment != nil && string(*cr.Spec.DeploymentEnvironment) == stagingDeploymentEnvironment {
+		return ConfigurationLoadModeLazy
+	}
+	return ConfigurationLoadModeBoot
+}
+
+// IngressURLs returns the URLs of the hosts of the ingress rules. Hosts
+// listed in the TLS section are served over https.
+func IngressURLs(ingress *networkingv1.Ingress) []string {
+	tlsHosts := map[string]bool{}
+	for _, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			tlsHosts[host] = true
+		}
+	}
+
+	var urls []string
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host == "" {
+			continue
+		}
+		scheme := "http"
+		if tlsHosts[rule.Host] {
+			scheme = "https"
+		}
+		urls = append(urls, fmt.Sprintf("%s://%s", scheme, rule.Host))
+	}
+
+	return urls
+}
+
+// RouteURLs returns the URL of the route, using the host generated by the
+// router when the route does not set one
+func RouteURLs(route *routev1.Route) []string {
+	host := route.Spec.Host
+	if host == "" && len(route.Status.Ingress) > 0 {
+		host = route.Status.Ingress[0].Host
+	}
+	if host == "" {
+		return nil
+	}
+
+	scheme := "http"
+	if route.Spec.TLS != nil {
+		scheme = "https"
+	}
+	return []string{fmt.Sprintf("%s://%s", scheme, host)}
+}
+
+// WatchedSecretAnnotationsChanged returns true when the desired pod template
+// rolls out the pods because a watched secret changed
+func WatchedSecretAnnotationsChanged(existing, desired map[string]string) bool {
+	for key, value := range desired {
+		if isWatchedSecretAnnotation(key) && existing[key] != value {
+			return true
+		}
+	}
+	return false
+}
+
+func isWatchedSecretAnnotation(key string) bool {
+	switch key {
+	case AdmPortalSecretResverAnnotation,
+		GatewayConfigurationSecretResverAnnotation,
+		HttpsCertSecretResverAnnotation,
+		OpenTracingSecretResverAnnotation,
+		OpenTelemetrySecretResverAnnotation:
+		return true
+	}
+
+	return strings.HasPrefix(key, CustomEnvSecretResverAnnotationPrefix) ||
+		strings.HasPrefix(key, CustomPoliciesSecretResverAnnotationPrefix)
+}
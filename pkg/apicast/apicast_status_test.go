@@ -0,0 +1,106 @@
+//go:build unit
+
+package apicast
+
+import (
+	"reflect"
+	"testing"
+
+	routev1 "github.com/openshift/api/route/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+)
+
+func TestEffectiveConfigurationLoadMode(t *testing.T) {
+	staging := appsv1alpha1.DeploymentEnvironmentType("staging")
+	production := appsv1alpha1.DeploymentEnvironmentType("production")
+
+	cases := []struct {
+		testName string
+		spec     appsv1alpha1.APIcastSpec
+		expected string
+	}{
+		{"Default", appsv1alpha1.APIcastSpec{}, ConfigurationLoadModeBoot},
+		{"Production", appsv1alpha1.APIcastSpec{DeploymentEnvironment: &production}, ConfigurationLoadModeBoot},
+		{"Staging", appsv1alpha1.APIcastSpec{DeploymentEnvironment: &staging}, ConfigurationLoadModeLazy},
+		{"Explicit", appsv1alpha1.APIcastSpec{DeploymentEnvironment: &staging, ConfigurationLoadMode: ptr.To("boot")}, ConfigurationLoadModeBoot},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			loadMode := EffectiveConfigurationLoadMode(&appsv1alpha1.APIcast{Spec: tc.spec})
+			if loadMode != tc.expected {
+				subT.Errorf("expected %s, got %s", tc.expected, loadMode)
+			}
+		})
+	}
+}
+
+func TestIngressURLs(t *testing.T) {
+	ingress := &networkingv1.Ingress{
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{Hosts: []string{"secure.example.com"}}},
+			Rules: []networkingv1.IngressRule{
+				{Host: "secure.example.com"},
+				{Host: "plain.example.com"},
+				{},
+			},
+		},
+	}
+
+	expected := []string{"https://secure.example.com", "http://plain.example.com"}
+	if urls := IngressURLs(ingress); !reflect.DeepEqual(urls, expected) {
+		t.Errorf("expected %v, got %v", expected, urls)
+	}
+}
+
+func TestRouteURLs(t *testing.T) {
+	cases := []struct {
+		testName string
+		route    *routev1.Route
+		expected []string
+	}{
+		{"Host", &routev1.Route{Spec: routev1.RouteSpec{Host: "api.example.com"}}, []string{"http://api.example.com"}},
+		{"TLS", &routev1.Route{Spec: routev1.RouteSpec{Host: "api.example.com", TLS: &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}}}, []string{"https://api.example.com"}},
+		{"GeneratedHost", &routev1.Route{Status: routev1.RouteStatus{Ingress: []routev1.RouteIngress{{Host: "apicast-example.apps.example.com"}}}}, []string{"http://apicast-example.apps.example.com"}},
+		{"NotAdmitted", &routev1.Route{}, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			if urls := RouteURLs(tc.route); !reflect.DeepEqual(urls, tc.expected) {
+				subT.Errorf("expected %v, got %v", tc.expected, urls)
+			}
+		})
+	}
+}
+
+func TestWatchedSecretAnnotationsChanged(t *testing.T) {
+	existing := map[string]string{
+		GatewayConfigurationSecretResverAnnotation:            "1",
+		CustomPoliciesSecretResverAnnotationPrefix + "policy": "1",
+		"custom-annotation": "a",
+	}
+
+	cases := []struct {
+		testName string
+		desired  map[string]string
+		expected bool
+	}{
+		{"Unchanged", map[string]string{GatewayConfigurationSecretResverAnnotation: "1", "custom-annotation": "a"}, false},
+		{"OtherAnnotation", map[string]string{GatewayConfigurationSecretResverAnnotation: "1", "custom-annotation": "b"}, false},
+		{"ConfigurationChanged", map[string]string{GatewayConfigurationSecretResverAnnotation: "2"}, true},
+		{"CustomPolicyChanged", map[string]string{CustomPoliciesSecretResverAnnotationPrefix + "policy": "2"}, true},
+		{"NewWatchedSecret", map[string]string{AdmPortalSecretResverAnnotation: "1"}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			if changed := WatchedSecretAnnotationsChanged(existing, tc.desired); changed != tc.expected {
+				subT.Errorf("expected %t, got %t", tc.expected, changed)
+			}
+		})
+	}
+}
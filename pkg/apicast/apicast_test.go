@@ -4,14 +4,22 @@ package apicast
 
 import (
 	"context"
+	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
 )
 
 const (
@@ -60,8 +68,9 @@ func TestAPIcastDeploymentSelector(t *testing.T) {
 		t.Error("deployment selector is nil")
 	}
 
-	if !reflect.DeepEqual(podLabelSelector, deployment.Spec.Selector.MatchLabels) {
-		t.Error("deployment selector does not match podlabelselector")
+	expectedSelector := map[string]string{"a": "a1", "b": "b1", TrackLabel: StableTrackLabelValue}
+	if !reflect.DeepEqual(expectedSelector, deployment.Spec.Selector.MatchLabels) {
+		t.Error("deployment selector does not match podlabelselector and the stable track")
 	}
 }
 
@@ -240,3 +249,398 @@ func testCustomEnvironmentSecret() *v1.Secret {
 
 	return &customEnvironmentSecret
 }
+
+func TestAPIcastDeploymentSidecars(t *testing.T) {
+	opts := testDefaultOpts()
+	opts.Sidecars = []v1.Container{{Name: "log-shipper", Image: "fluent-bit"}}
+	opts.InitContainers = []v1.Container{{Name: "policy-downloader", Image: "curl"}}
+	opts.ExtraVolumes = []v1.Volume{{Name: "logs", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+	opts.ExtraVolumeMounts = []v1.VolumeMount{{Name: "logs", MountPath: "/var/log/apicast"}}
+
+	deployment, err := NewAPIcast(opts).Deployment(context.TODO(), fake.NewFakeClient())
+	if err != nil {
+		t.Fatalf("error getting deployment: %v", err)
+	}
+
+	containers := deployment.Spec.Template.Spec.Containers
+	if len(containers) != 2 || containers[0].Name != opts.DeploymentName || containers[1].Name != "log-shipper" {
+		t.Errorf("unexpected containers: %v", containers)
+	}
+	if !reflect.DeepEqual(deployment.Spec.Template.Spec.InitContainers, opts.InitContainers) {
+		t.Errorf("unexpected init containers: %v", deployment.Spec.Template.Spec.InitContainers)
+	}
+	volumes := deployment.Spec.Template.Spec.Volumes
+	if len(volumes) == 0 || volumes[len(volumes)-1].Name != "logs" {
+		t.Errorf("extra volume not added: %v", volumes)
+	}
+	volumeMounts := containers[0].VolumeMounts
+	if len(volumeMounts) == 0 || volumeMounts[len(volumeMounts)-1].MountPath != "/var/log/apicast" {
+		t.Errorf("extra volume mount not added: %v", volumeMounts)
+	}
+
+	opts.ExtraVolumes = []v1.Volume{{Name: EmbeddedConfigurationVolumeName}}
+	opts.GatewayConfigurationSecret = &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: testAPIcastEmbeddedConfigurationSecretName}}
+	if _, err := NewAPIcast(opts).Deployment(context.TODO(), fake.NewFakeClient()); err == nil {
+		t.Error("expected error when an extra volume clashes with a managed volume")
+	}
+}
+
+func TestAPIcastDeploymentPodTemplateOverride(t *testing.T) {
+	opts := testDefaultOpts()
+	opts.PodLabelSelector = map[string]string{"deployment": opts.DeploymentName}
+	opts.PodTemplateLabels = map[string]string{"deployment": opts.DeploymentName}
+	opts.PodTemplateOverride = []byte(`{
+		"metadata": {"labels": {"team": "gateway"}},
+		"spec": {
+			"hostAliases": [{"ip": "10.0.0.1", "hostnames": ["backend.local"]}],
+			"nodeSelector": {"kubernetes.io/os": "linux"},
+			"containers": [{"name": "apicast-apicast1", "securityContext": {"readOnlyRootFilesystem": true}}]
+		}
+	}`)
+
+	deployment, err := NewAPIcast(opts).Deployment(context.TODO(), fake.NewFakeClient())
+	if err != nil {
+		t.Fatalf("error getting deployment: %v", err)
+	}
+
+	template := deployment.Spec.Template
+	if template.Labels["team"] != "gateway" || template.Labels["deployment"] != opts.DeploymentName {
+		t.Errorf("unexpected pod template labels: %v", template.Labels)
+	}
+	if len(template.Spec.HostAliases) != 1 || template.Spec.NodeSelector["kubernetes.io/os"] != "linux" {
+		t.Errorf("pod spec override not applied: %v", template.Spec)
+	}
+	container := template.Spec.Containers[0]
+	if container.Name != opts.DeploymentName || container.Image != opts.Image {
+		t.Errorf("APIcast container not kept: %v", container)
+	}
+	if container.SecurityContext == nil || container.SecurityContext.ReadOnlyRootFilesystem == nil || !*container.SecurityContext.ReadOnlyRootFilesystem {
+		t.Errorf("container override not applied: %v", container.SecurityContext)
+	}
+	if container.LivenessProbe == nil {
+		t.Error("generated container fields lost")
+	}
+	if template.Annotations[k8sutils.PodTemplateOverrideHashAnnotation] == "" {
+		t.Error("pod template override hash annotation not set")
+	}
+
+	opts.PodTemplateOverride = []byte(`{"spec":`)
+	if _, err := NewAPIcast(opts).Deployment(context.TODO(), fake.NewFakeClient()); err == nil {
+		t.Error("expected error with an invalid pod template override")
+	}
+}
+
+func TestAPIcastDeploymentProbes(t *testing.T) {
+	opts := testDefaultOpts()
+	startup := DefaultStartupProbe
+	startup.FailureThreshold = 60
+	opts.Probes = ProbesOptions{Liveness: DefaultLivenessProbe, Readiness: DefaultReadinessProbe, Startup: &startup}
+
+	deployment, err := NewAPIcast(opts).Deployment(context.TODO(), fake.NewFakeClient())
+	if err != nil {
+		t.Fatalf("error getting deployment: %v", err)
+	}
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	for name, probe := range map[string]*v1.Probe{"liveness": container.LivenessProbe, "readiness": container.ReadinessProbe, "startup": container.StartupProbe} {
+		if probe == nil || probe.HTTPGet == nil {
+			t.Fatalf("%s probe not set", name)
+		}
+		if probe.HTTPGet.Port.StrVal != "management" {
+			t.Errorf("%s probe does not use the management port: %v", name, probe.HTTPGet.Port)
+		}
+	}
+	if container.StartupProbe.FailureThreshold != 60 {
+		t.Errorf("unexpected startup probe failure threshold: %d", container.StartupProbe.FailureThreshold)
+	}
+	if container.ReadinessProbe.HTTPGet.Path != "/status/ready" || container.ReadinessProbe.PeriodSeconds != DefaultReadinessProbe.PeriodSeconds {
+		t.Errorf("unexpected readiness probe: %v", container.ReadinessProbe)
+	}
+
+	// the startup probe is opt-in
+	opts.Probes.Startup = nil
+	deployment, err = NewAPIcast(opts).Deployment(context.TODO(), fake.NewFakeClient())
+	if err != nil {
+		t.Fatalf("error getting deployment: %v", err)
+	}
+	if deployment.Spec.Template.Spec.Containers[0].StartupProbe != nil {
+		t.Errorf("unexpected startup probe: %v", deployment.Spec.Template.Spec.Containers[0].StartupProbe)
+	}
+}
+
+func TestAPIcastDeploymentRollout(t *testing.T) {
+	opts := testDefaultOpts()
+	opts.Rollout = RolloutOptions{
+		Strategy:                appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+		MinReadySeconds:         10,
+		ProgressDeadlineSeconds: 300,
+		RevisionHistoryLimit:    3,
+	}
+
+	deployment, err := NewAPIcast(opts).Deployment(context.TODO(), fake.NewFakeClient())
+	if err != nil {
+		t.Fatalf("error getting deployment: %v", err)
+	}
+
+	if !reflect.DeepEqual(deployment.Spec.Strategy, opts.Rollout.Strategy) {
+		t.Errorf("unexpected strategy: %s", cmp.Diff(opts.Rollout.Strategy, deployment.Spec.Strategy))
+	}
+	if deployment.Spec.MinReadySeconds != 10 {
+		t.Errorf("unexpected minReadySeconds: %d", deployment.Spec.MinReadySeconds)
+	}
+	if deployment.Spec.ProgressDeadlineSeconds == nil || *deployment.Spec.ProgressDeadlineSeconds != 300 {
+		t.Errorf("unexpected progressDeadlineSeconds: %v", deployment.Spec.ProgressDeadlineSeconds)
+	}
+	if deployment.Spec.RevisionHistoryLimit == nil || *deployment.Spec.RevisionHistoryLimit != 3 {
+		t.Errorf("unexpected revisionHistoryLimit: %v", deployment.Spec.RevisionHistoryLimit)
+	}
+}
+
+func TestAPIcastDeploymentGracefulShutdown(t *testing.T) {
+	opts := testDefaultOpts()
+	opts.GracefulShutdown = GracefulShutdownOptions{Enabled: true, PreStopDelaySeconds: 20, TerminationGracePeriodSeconds: 90}
+
+	deployment, err := NewAPIcast(opts).Deployment(context.TODO(), fake.NewFakeClient())
+	if err != nil {
+		t.Fatalf("error getting deployment: %v", err)
+	}
+
+	podSpec := deployment.Spec.Template.Spec
+	if podSpec.TerminationGracePeriodSeconds == nil || *podSpec.TerminationGracePeriodSeconds != 90 {
+		t.Errorf("unexpected terminationGracePeriodSeconds: %v", podSpec.TerminationGracePeriodSeconds)
+	}
+	lifecycle := podSpec.Containers[0].Lifecycle
+	if lifecycle == nil || lifecycle.PreStop == nil || lifecycle.PreStop.Exec == nil {
+		t.Fatalf("preStop hook not set: %v", lifecycle)
+	}
+	if command := strings.Join(lifecycle.PreStop.Exec.Command, " "); !strings.Contains(command, "sleep 20;") {
+		t.Errorf("unexpected preStop command: %s", command)
+	}
+
+	opts.GracefulShutdown = GracefulShutdownOptions{TerminationGracePeriodSeconds: DefaultPodTerminationGracePeriodSeconds}
+	deployment, err = NewAPIcast(opts).Deployment(context.TODO(), fake.NewFakeClient())
+	if err != nil {
+		t.Fatalf("error getting deployment: %v", err)
+	}
+	if deployment.Spec.Template.Spec.Containers[0].Lifecycle != nil {
+		t.Errorf("unexpected preStop hook when graceful shutdown is disabled")
+	}
+}
+
+func TestAPIcastMonitoring(t *testing.T) {
+	podLabelSelector := map[string]string{"deployment": "apicast-apicast1"}
+
+	opts := testDefaultOpts()
+	opts.PodLabelSelector = podLabelSelector
+	opts.CommonLabels = map[string]string{"app": "apicast"}
+	opts.Monitoring = MonitoringOptions{
+		Enabled:  true,
+		Kind:     "ServiceMonitor",
+		Interval: "30s",
+		Labels:   map[string]string{"release": "prometheus", "app": "other"},
+	}
+	apicastFactory := NewAPIcast(opts)
+
+	service := apicastFactory.Service()
+	hasMetricsPort := false
+	for _, port := range service.Spec.Ports {
+		if port.Name == "metrics" && port.Port == DefaultMetricsPort {
+			hasMetricsPort = true
+		}
+	}
+	if !hasMetricsPort {
+		t.Error("service does not expose the metrics port")
+	}
+	if service.Labels["deployment"] != "apicast-apicast1" {
+		t.Errorf("service labels do not include the pod label selector: %v", service.Labels)
+	}
+
+	serviceMonitor := apicastFactory.ServiceMonitor()
+	if !reflect.DeepEqual(podLabelSelector, serviceMonitor.Spec.Selector.MatchLabels) {
+		t.Error("service monitor selector does not match podlabelselector")
+	}
+	if serviceMonitor.Spec.Endpoints[0].Interval != "30s" {
+		t.Errorf("unexpected service monitor interval: %s", serviceMonitor.Spec.Endpoints[0].Interval)
+	}
+	expectedLabels := map[string]string{"release": "prometheus", "app": "apicast"}
+	if !reflect.DeepEqual(expectedLabels, serviceMonitor.Labels) {
+		t.Errorf("unexpected service monitor labels: %v", serviceMonitor.Labels)
+	}
+
+	opts.Monitoring.Enabled = false
+	for _, port := range NewAPIcast(opts).Service().Spec.Ports {
+		if port.Name == "metrics" {
+			t.Error("service exposes the metrics port with monitoring disabled")
+		}
+	}
+}
+
+func TestAPIcastPrometheusRule(t *testing.T) {
+	opts := testDefaultOpts()
+	opts.CommonLabels = map[string]string{"app": "apicast"}
+	opts.Monitoring = MonitoringOptions{
+		Enabled: true,
+		Labels:  map[string]string{"release": "prometheus"},
+		Alerts: AlertsOptions{
+			Enabled:                 true,
+			PodsNotReadyFor:         "10m",
+			HTTP5xxRatioThreshold:   20,
+			WorkerRestartsThreshold: DefaultAlertWorkerRestartsThreshold,
+			ErrorLogThreshold:       DefaultAlertErrorLogThreshold,
+			Severity:                "critical",
+		},
+	}
+
+	prometheusRule := NewAPIcast(opts).PrometheusRule()
+
+	expectedLabels := map[string]string{"release": "prometheus", "app": "apicast"}
+	if !reflect.DeepEqual(expectedLabels, prometheusRule.Labels) {
+		t.Errorf("unexpected prometheus rule labels: %v", prometheusRule.Labels)
+	}
+
+	if len(prometheusRule.Spec.Groups) != 1 {
+		t.Fatalf("expected one rule group, got %d", len(prometheusRule.Spec.Groups))
+	}
+
+	rules := map[string]string{}
+	for _, rule := range prometheusRule.Spec.Groups[0].Rules {
+		if rule.Labels["severity"] != "critical" {
+			t.Errorf("rule %s: unexpected severity %s", rule.Alert, rule.Labels["severity"])
+		}
+		if rule.Alert == "APIcastPodsNotReady" && (rule.For == nil || *rule.For != "10m") {
+			t.Errorf("rule %s: unexpected for duration %v", rule.Alert, rule.For)
+		}
+		rules[rule.Alert] = rule.Expr.String()
+	}
+
+	expectedExpr := `sum(rate(apicast_status{namespace="my-namespace",pod=~"apicast-apicast1-.*",pod!~"apicast-apicast1-canary-.*",status=~"5.."}[5m])) / sum(rate(apicast_status{namespace="my-namespace",pod=~"apicast-apicast1-.*",pod!~"apicast-apicast1-canary-.*"}[5m])) * 100 > 20`
+	if rules["APIcastHigh5xxRatio"] != expectedExpr {
+		t.Errorf("unexpected 5xx ratio expression: %s", rules["APIcastHigh5xxRatio"])
+	}
+
+	expectedExpr = `kube_deployment_status_replicas_ready{namespace="my-namespace",deployment=~"apicast-apicast1|apicast-apicast1-blue|apicast-apicast1-green"} < kube_deployment_spec_replicas{namespace="my-namespace",deployment=~"apicast-apicast1|apicast-apicast1-blue|apicast-apicast1-green"}`
+	if rules["APIcastPodsNotReady"] != expectedExpr {
+		t.Errorf("unexpected pods not ready expression: %s", rules["APIcastPodsNotReady"])
+	}
+
+	for _, alert := range []string{"APIcastPodsNotReady", "APIcastHigh5xxRatio", "APIcastWorkerRestarts", "APIcastErrorLogs"} {
+		if _, ok := rules[alert]; !ok {
+			t.Errorf("missing alert %s", alert)
+		}
+	}
+}
+
+func TestAPIcastGrafanaDashboard(t *testing.T) {
+	opts := testDefaultOpts()
+	opts.CommonLabels = map[string]string{"app": "apicast"}
+	opts.Monitoring = MonitoringOptions{
+		Enabled: true,
+		Dashboard: DashboardOptions{
+			Enabled:          true,
+			Labels:           map[string]string{GrafanaDashboardSidecarLabel: "1"},
+			InstanceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"dashboards": "grafana"}},
+		},
+	}
+	apicastFactory := NewAPIcast(opts)
+
+	configMap, err := apicastFactory.GrafanaDashboardConfigMap()
+	if err != nil {
+		t.Fatalf("error getting dashboard configmap: %v", err)
+	}
+	expectedLabels := map[string]string{GrafanaDashboardSidecarLabel: "1", "app": "apicast"}
+	if !reflect.DeepEqual(expectedLabels, configMap.Labels) {
+		t.Errorf("unexpected configmap labels: %v", configMap.Labels)
+	}
+
+	dashboardJSON, ok := configMap.Data["apicast-apicast1.json"]
+	if !ok {
+		t.Fatalf("dashboard key not found in configmap data: %v", configMap.Data)
+	}
+	dashboardModel := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(dashboardJSON), &dashboardModel); err != nil {
+		t.Fatalf("dashboard is not valid json: %v", err)
+	}
+	if !strings.Contains(dashboardJSON, `pod=~\"apicast-apicast1-.*\",pod!~\"apicast-apicast1-canary-.*\"`) {
+		t.Error("dashboard queries are not scoped to the apicast pods")
+	}
+
+	// The dashboard must be stable across reconciliations
+	configMap2, err := apicastFactory.GrafanaDashboardConfigMap()
+	if err != nil {
+		t.Fatalf("error getting dashboard configmap: %v", err)
+	}
+	if !reflect.DeepEqual(configMap.Data, configMap2.Data) {
+		t.Error("dashboard json is not deterministic")
+	}
+
+	dashboard, err := apicastFactory.GrafanaDashboard()
+	if err != nil {
+		t.Fatalf("error getting grafana dashboard: %v", err)
+	}
+	if dashboard.GroupVersionKind() != GrafanaDashboardGVK {
+		t.Errorf("unexpected grafana dashboard kind: %s", dashboard.GroupVersionKind())
+	}
+	instanceSelectorLabels, _, _ := unstructured.NestedStringMap(dashboard.Object, "spec", "instanceSelector", "matchLabels")
+	if !reflect.DeepEqual(map[string]string{"dashboards": "grafana"}, instanceSelectorLabels) {
+		t.Errorf("unexpected instance selector: %v", instanceSelectorLabels)
+	}
+	if specJSON, _, _ := unstructured.NestedString(dashboard.Object, "spec", "json"); specJSON != dashboardJSON {
+		t.Error("grafana dashboard json does not match the configmap json")
+	}
+}
+
+func TestSecretSourceCopy(t *testing.T) {
+	cr := &appsv1alpha1.APIcast{
+		ObjectMeta: metav1.ObjectMeta{Name: "apicast1", Namespace: testNamespace, UID: "apicast1-uid"},
+	}
+	source := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "shared-credentials",
+			Namespace:   "shared",
+			Labels:      map[string]string{"team": "platform"},
+			Annotations: map[string]string{"apicast.apps.3scale.net/allowed-namespaces": testNamespace},
+		},
+		Type: v1.SecretTypeOpaque,
+		Data: map[string][]byte{"AdminPortalURL": []byte("https://token@3scale-admin.example.com")},
+	}
+	secretSource := appsv1alpha1.SecretSourceSpec{
+		Name:      "admin-portal-credentials",
+		SourceRef: appsv1alpha1.SecretSourceRef{Namespace: "shared", Name: "shared-credentials"},
+	}
+
+	secret := SecretSourceCopy(cr, secretSource, source)
+
+	if secret.Name != "admin-portal-credentials" || secret.Namespace != testNamespace {
+		t.Errorf("unexpected copy key %s/%s", secret.Namespace, secret.Name)
+	}
+	if !reflect.DeepEqual(secret.Data, source.Data) {
+		t.Errorf("copy data %v does not match source data %v", secret.Data, source.Data)
+	}
+	if secret.Type != v1.SecretTypeOpaque {
+		t.Errorf("unexpected copy type %s", secret.Type)
+	}
+	if _, ok := secret.Labels["team"]; ok {
+		t.Error("source labels must not be copied")
+	}
+	if _, ok := secret.Annotations["apicast.apps.3scale.net/allowed-namespaces"]; ok {
+		t.Error("allowed namespaces annotation must not be copied")
+	}
+	if secret.Labels[SecretSourceCopyLabel] != "apicast1" {
+		t.Errorf("unexpected %s label: %v", SecretSourceCopyLabel, secret.Labels)
+	}
+	if secret.Labels["apicast.apps.3scale.net/watched-by"] != "apicast" {
+		t.Errorf("copy must be watched by apicast: %v", secret.Labels)
+	}
+	if secret.Annotations[SecretSourceAnnotation] != "shared/shared-credentials" {
+		t.Errorf("unexpected %s annotation: %v", SecretSourceAnnotation, secret.Annotations)
+	}
+	if !metav1.IsControlledBy(secret, cr) {
+		t.Error("copy must be controlled by the APIcast")
+	}
+
+	// mutating the copy must not affect the source
+	secret.Data["AdminPortalURL"][0] = 'x'
+	if string(source.Data["AdminPortalURL"]) != "https://token@3scale-admin.example.com" {
+		t.Error("copy data shares memory with the source data")
+	}
+}
@@ -0,0 +1,136 @@
+package apicast
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+	"github.com/3scale/apicast-operator/pkg/helper"
+	"github.com/3scale/apicast-operator/version"
+)
+
+// UpgradeOptions is the upgrade of the default image decided by the upgrade policy
+type UpgradeOptions struct {
+	// Status is the upgrade state, nil when the image is set in the spec
+	Status *appsv1alpha1.UpgradeStatus
+	// Upgraded is true when the default image is upgraded by the reconciliation
+	Upgraded bool
+	// RequeueAfter is the time until the start of the next maintenance window,
+	// when the upgrade is waiting for it
+	RequeueAfter time.Duration
+}
+
+// Upgrade returns the upgrade of the default image
+func (a *APIcast) Upgrade() UpgradeOptions {
+	return a.options.Upgrade
+}
+
+// upgradeOptions returns the default image run by the APIcast pods. When the
+// operator default image changed, the upgrade policy decides whether the pods
+// keep running the previous default image.
+func (a *APIcastOptionsProvider) upgradeOptions(defaultImage string, now time.Time) UpgradeOptions {
+	upgrade := a.APIcastCR.Status.Upgrade.DeepCopy()
+	if upgrade == nil {
+		upgrade = &appsv1alpha1.UpgradeStatus{Image: a.runningImage(defaultImage)}
+		if upgrade.Image == defaultImage {
+			upgrade.Version = version.ThreescaleVersionMajorMinorPatch()
+		}
+	}
+
+	upgrade.PendingImage = ""
+	upgrade.PendingVersion = ""
+	upgrade.Message = ""
+
+	if upgrade.Image == defaultImage {
+		return UpgradeOptions{Status: upgrade}
+	}
+
+	upgrade.PendingImage = defaultImage
+	upgrade.PendingVersion = version.ThreescaleVersionMajorMinorPatch()
+
+	message, requeueAfter := a.upgradeBlocked(upgrade.PendingVersion, now)
+	if message != "" {
+		upgrade.Message = message
+		return UpgradeOptions{Status: upgrade, RequeueAfter: requeueAfter}
+	}
+
+	record := appsv1alpha1.UpgradeRecord{
+		FromImage: upgrade.Image,
+		ToImage:   defaultImage,
+		Version:   upgrade.PendingVersion,
+		Time:      metav1.NewTime(now),
+	}
+	upgrade.History = append([]appsv1alpha1.UpgradeRecord{record}, upgrade.History...)
+	if len(upgrade.History) > appsv1alpha1.MaxUpgradeHistory {
+		upgrade.History = upgrade.History[:appsv1alpha1.MaxUpgradeHistory]
+	}
+
+	upgrade.Image = defaultImage
+	upgrade.Version = upgrade.PendingVersion
+	upgrade.PendingImage = ""
+	upgrade.PendingVersion = ""
+
+	return UpgradeOptions{Status: upgrade, Upgraded: true}
+}
+
+// runningImage returns the image run by the APIcast pods deployed before
+// upgrades were tracked, the default image for new APIcasts
+func (a *APIcastOptionsProvider) runningImage(defaultImage string) string {
+	status := a.APIcastCR.Status
+	if status.Image == "" {
+		return defaultImage
+	}
+
+	// the pods run the digest of the resolved image
+	if status.ResolvedImage != nil && status.ResolvedImage.Digest == status.Image {
+		return status.ResolvedImage.Image
+	}
+
+	return status.Image
+}
+
+// upgradeBlocked returns why the upgrade is blocked by the upgrade policy,
+// empty when the upgrade is allowed
+func (a *APIcastOptionsProvider) upgradeBlocked(pendingVersion string, now time.Time) (string, time.Duration) {
+	if !a.APIcastCR.Status.IsReady() {
+		return "Upgrade blocked until the APIcast is ready", 0
+	}
+
+	upgradePolicy := a.APIcastCR.Spec.UpgradePolicy
+
+	switch upgradePolicy.PolicyType() {
+	case appsv1alpha1.UpgradePolicyManual:
+		if upgradePolicy.ApprovedVersion == nil || *upgradePolicy.ApprovedVersion != pendingVersion {
+			return fmt.Sprintf("Waiting for approval, set upgradePolicy.approvedVersion to %s", pendingVersion), 0
+		}
+	case appsv1alpha1.UpgradePolicyMaintenanceWindow:
+		if upgradePolicy.MaintenanceWindow == nil {
+			return "Waiting for a maintenance window, none is configured", 0
+		}
+
+		schedule, err := helper.ParseCronSchedule(upgradePolicy.MaintenanceWindow.Schedule)
+		if err != nil {
+			return err.Error(), 0
+		}
+
+		now = now.UTC()
+		duration := time.Duration(appsv1alpha1.DefaultMaintenanceWindowDurationMinutes) * time.Minute
+		if upgradePolicy.MaintenanceWindow.DurationMinutes != nil {
+			duration = time.Duration(*upgradePolicy.MaintenanceWindow.DurationMinutes) * time.Minute
+		}
+
+		if start, ok := schedule.Last(now, duration); ok && now.Before(start.Add(duration)) {
+			return "", 0
+		}
+
+		next, ok := schedule.Next(now)
+		if !ok {
+			return "Waiting for a maintenance window, none is scheduled within a year", 0
+		}
+		return fmt.Sprintf("Waiting for the maintenance window starting at %s", next.Format(time.RFC3339)), next.Sub(now)
+	}
+
+	return "", 0
+}
@@ -0,0 +1,120 @@
+//go:build unit
+
+package apicast
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+	"github.com/3scale/apicast-operator/version"
+)
+
+func TestUpgradeOptions(t *testing.T) {
+	const (
+		previousImage = "quay.io/3scale/apicast:2.15"
+		defaultImage  = "quay.io/3scale/apicast:2.16"
+	)
+	// Saturday
+	now := time.Date(2026, time.October, 17, 2, 30, 0, 0, time.UTC)
+	readyConditions := []metav1.Condition{{Type: appsv1alpha1.ReadyConditionType, Status: metav1.ConditionTrue}}
+	notReadyConditions := []metav1.Condition{{Type: appsv1alpha1.ReadyConditionType, Status: metav1.ConditionFalse}}
+	manual := appsv1alpha1.UpgradePolicyManual
+	maintenanceWindow := appsv1alpha1.UpgradePolicyMaintenanceWindow
+
+	cases := []struct {
+		testName        string
+		upgradePolicy   *appsv1alpha1.UpgradePolicySpec
+		status          appsv1alpha1.APIcastStatus
+		expectedImage   string
+		expectedUpgrade bool
+		expectedMessage string
+		expectedRequeue time.Duration
+	}{
+		{"NewAPIcast", nil, appsv1alpha1.APIcastStatus{}, defaultImage, false, "", 0},
+		{"UpToDate", nil, appsv1alpha1.APIcastStatus{
+			Image: defaultImage, Conditions: readyConditions, Upgrade: &appsv1alpha1.UpgradeStatus{Image: defaultImage},
+		}, defaultImage, false, "", 0},
+		{"Automatic", nil, appsv1alpha1.APIcastStatus{
+			Image: previousImage, Conditions: readyConditions, Upgrade: &appsv1alpha1.UpgradeStatus{Image: previousImage},
+		}, defaultImage, true, "", 0},
+		{"PreviousOperatorVersion", nil, appsv1alpha1.APIcastStatus{
+			Image: previousImage, Conditions: readyConditions,
+		}, defaultImage, true, "", 0},
+		{"PinnedDigest", nil, appsv1alpha1.APIcastStatus{
+			Image: "quay.io/3scale/apicast@sha256:0123", Conditions: readyConditions,
+			ResolvedImage: &appsv1alpha1.ResolvedImageStatus{Image: defaultImage, Digest: "quay.io/3scale/apicast@sha256:0123"},
+		}, defaultImage, false, "", 0},
+		{"NotReady", nil, appsv1alpha1.APIcastStatus{
+			Image: previousImage, Conditions: notReadyConditions, Upgrade: &appsv1alpha1.UpgradeStatus{Image: previousImage},
+		}, previousImage, false, "until the APIcast is ready", 0},
+		{"ManualNotApproved", &appsv1alpha1.UpgradePolicySpec{Type: &manual}, appsv1alpha1.APIcastStatus{
+			Image: previousImage, Conditions: readyConditions, Upgrade: &appsv1alpha1.UpgradeStatus{Image: previousImage},
+		}, previousImage, false, "approval", 0},
+		{"ManualApproved", &appsv1alpha1.UpgradePolicySpec{Type: &manual, ApprovedVersion: ptr.To(version.ThreescaleVersionMajorMinorPatch())}, appsv1alpha1.APIcastStatus{
+			Image: previousImage, Conditions: readyConditions, Upgrade: &appsv1alpha1.UpgradeStatus{Image: previousImage},
+		}, defaultImage, true, "", 0},
+		{"InMaintenanceWindow", &appsv1alpha1.UpgradePolicySpec{Type: &maintenanceWindow, MaintenanceWindow: &appsv1alpha1.MaintenanceWindowSpec{Schedule: "0 2 * * 6"}}, appsv1alpha1.APIcastStatus{
+			Image: previousImage, Conditions: readyConditions, Upgrade: &appsv1alpha1.UpgradeStatus{Image: previousImage},
+		}, defaultImage, true, "", 0},
+		{"OutsideMaintenanceWindow", &appsv1alpha1.UpgradePolicySpec{Type: &maintenanceWindow, MaintenanceWindow: &appsv1alpha1.MaintenanceWindowSpec{Schedule: "0 2 * * 6", DurationMinutes: ptr.To(int32(15))}}, appsv1alpha1.APIcastStatus{
+			Image: previousImage, Conditions: readyConditions, Upgrade: &appsv1alpha1.UpgradeStatus{Image: previousImage},
+		}, previousImage, false, "maintenance window", 7*24*time.Hour - 30*time.Minute},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			cr := &appsv1alpha1.APIcast{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance1", Namespace: "my-ns"},
+				Spec:       appsv1alpha1.APIcastSpec{UpgradePolicy: tc.upgradePolicy},
+				Status:     tc.status,
+			}
+			optsProvider := NewApicastOptionsProvider(cr, nil)
+
+			upgrade := optsProvider.upgradeOptions(defaultImage, now)
+			if upgrade.Status.Image != tc.expectedImage {
+				subT.Errorf("expected image %s, got %s", tc.expectedImage, upgrade.Status.Image)
+			}
+			if upgrade.Upgraded != tc.expectedUpgrade {
+				subT.Errorf("expected upgraded %t, got %t", tc.expectedUpgrade, upgrade.Upgraded)
+			}
+			if !strings.Contains(upgrade.Status.Message, tc.expectedMessage) {
+				subT.Errorf("expected message containing %q, got %q", tc.expectedMessage, upgrade.Status.Message)
+			}
+			if upgrade.RequeueAfter != tc.expectedRequeue {
+				subT.Errorf("expected requeue after %s, got %s", tc.expectedRequeue, upgrade.RequeueAfter)
+			}
+
+			if tc.expectedUpgrade {
+				if len(upgrade.Status.History) != 1 || upgrade.Status.History[0].ToImage != defaultImage || upgrade.Status.PendingImage != "" {
+					subT.Errorf("unexpected upgrade status %+v", upgrade.Status)
+				}
+			} else if tc.expectedImage == previousImage && upgrade.Status.PendingImage != defaultImage {
+				subT.Errorf("expected pending image %s, got %s", defaultImage, upgrade.Status.PendingImage)
+			}
+		})
+	}
+}
+
+func TestUpgradeHistoryLimit(t *testing.T) {
+	history := make([]appsv1alpha1.UpgradeRecord, appsv1alpha1.MaxUpgradeHistory)
+	cr := &appsv1alpha1.APIcast{
+		Status: appsv1alpha1.APIcastStatus{
+			Image:      "quay.io/3scale/apicast:2.15",
+			Conditions: []metav1.Condition{{Type: appsv1alpha1.ReadyConditionType, Status: metav1.ConditionTrue}},
+			Upgrade:    &appsv1alpha1.UpgradeStatus{Image: "quay.io/3scale/apicast:2.15", History: history},
+		},
+	}
+
+	upgrade := NewApicastOptionsProvider(cr, nil).upgradeOptions("quay.io/3scale/apicast:2.16", time.Now())
+	if len(upgrade.Status.History) != appsv1alpha1.MaxUpgradeHistory {
+		t.Errorf("expected %d records, got %d", appsv1alpha1.MaxUpgradeHistory, len(upgrade.Status.History))
+	}
+	if upgrade.Status.History[0].FromImage != "quay.io/3scale/apicast:2.15" {
+		t.Errorf("expected the upgrade to be the first record, got %+v", upgrade.Status.History[0])
+	}
+}
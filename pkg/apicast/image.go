@@ -1,9 +1,40 @@
 package apicast
 
-import "github.com/3scale/apicast-operator/pkg/helper"
+import (
+	"strings"
+
+	"github.com/3scale/apicast-operator/pkg/helper"
+)
 
 const defaultImageVersion = "quay.io/3scale/apicast:latest"
 
 func GetDefaultImageVersion() string {
 	return helper.GetEnvVar("RELATED_IMAGE_APICAST", defaultImageVersion)
 }
+
+// ImageWithRegistry replaces the registry of the image. Images without
+// registry, pulled from Docker Hub, are prefixed with the registry, official
+// images with their library/ path.
+func ImageWithRegistry(image, registry string) string {
+	_, repository := splitImageRegistry(image)
+	return strings.TrimSuffix(registry, "/") + "/" + repository
+}
+
+// splitImageRegistry returns the registry and the repository of the image,
+// the tag or digest being kept in the repository. Images without registry are
+// pulled from Docker Hub, where official images are in the library/ path.
+func splitImageRegistry(image string) (string, string) {
+	registry, repository := dockerHubRegistry, image
+
+	parts := strings.SplitN(image, "/", 2)
+	// the first component is a registry when it is a host name
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		registry, repository = parts[0], parts[1]
+	}
+
+	if registry == dockerHubRegistry && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+
+	return registry, repository
+}
@@ -0,0 +1,309 @@
+package apicast
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	dockerHubRegistry    = "docker.io"
+	dockerHubAPIRegistry = "registry-1.docker.io"
+	imageResolveTimeout  = 30 * time.Second
+)
+
+// manifestMediaTypes are the manifests accepted when resolving a tag. Multi
+// architecture indexes come first, so the digest is valid for every node.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+var authChallengeParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// ImageResolver resolves image tags to digests
+type ImageResolver interface {
+	// Resolve returns the image reference pinned to the digest of the image.
+	// The pull secrets are used to authenticate against the registry.
+	Resolve(ctx context.Context, image string, pullSecrets []v1.Secret) (string, error)
+}
+
+// RegistryImageResolver resolves image tags with the registry HTTP API
+type RegistryImageResolver struct {
+	HTTPClient *http.Client
+}
+
+var _ ImageResolver = &RegistryImageResolver{}
+
+// NewRegistryImageResolver returns a resolver reaching the registries through
+// the proxies of the operator environment
+func NewRegistryImageResolver() *RegistryImageResolver {
+	return &RegistryImageResolver{
+		HTTPClient: &http.Client{Timeout: imageResolveTimeout},
+	}
+}
+
+// imageReference is a parsed image reference
+type imageReference struct {
+	// Name is the image reference without tag nor digest
+	Name       string
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+func parseImageReference(image string) (imageReference, error) {
+	ref := imageReference{Name: image}
+
+	if name, digest, found := strings.Cut(ref.Name, "@"); found {
+		ref.Name, ref.Digest = name, digest
+	}
+
+	// the tag separator is after the last path component separator, the
+	// registry may have a port
+	if idx := strings.LastIndex(ref.Name, ":"); idx > strings.LastIndex(ref.Name, "/") {
+		ref.Name, ref.Tag = ref.Name[:idx], ref.Name[idx+1:]
+	}
+
+	if ref.Name == "" {
+		return ref, fmt.Errorf("invalid image reference %q", image)
+	}
+
+	ref.Registry, ref.Repository = splitImageRegistry(ref.Name)
+
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+
+	return ref, nil
+}
+
+// apiRegistry returns the host serving the registry API
+func (r imageReference) apiRegistry() string {
+	if r.Registry == dockerHubRegistry {
+		return dockerHubAPIRegistry
+	}
+	return r.Registry
+}
+
+// Resolve requests the manifest of the image tag and returns the image
+// reference pinned to the digest reported by the registry. Images already
+// pinned to a digest are returned as is.
+func (r *RegistryImageResolver) Resolve(ctx context.Context, image string, pullSecrets []v1.Secret) (string, error) {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return "", err
+	}
+
+	if ref.Digest != "" {
+		return image, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, imageResolveTimeout)
+	defer cancel()
+
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", ref.apiRegistry(), ref.Repository, ref.Tag)
+	username, password := registryCredentials(pullSecrets, ref.Registry)
+
+	resp, err := r.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return "", fmt.Errorf("resolving image %s: %w", image, err)
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		authorization, err := r.authorization(ctx, resp.Header.Get("WWW-Authenticate"), ref, username, password)
+		if err != nil {
+			return "", fmt.Errorf("resolving image %s: %w", image, err)
+		}
+
+		resp, err = r.headManifest(ctx, manifestURL, authorization)
+		if err != nil {
+			return "", fmt.Errorf("resolving image %s: %w", image, err)
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("resolving image %s: registry returned %s", image, resp.Status)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if !strings.HasPrefix(digest, "sha256:") {
+		return "", fmt.Errorf("resolving image %s: registry returned invalid digest %q", image, digest)
+	}
+
+	return ref.Name + "@" + digest, nil
+}
+
+func (r *RegistryImageResolver) headManifest(ctx context.Context, manifestURL, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return resp, nil
+}
+
+// authorization answers the authentication challenge of the registry, either
+// with the basic credentials or with a bearer token requested to the token
+// service of the registry
+func (r *RegistryImageResolver) authorization(ctx context.Context, challenge string, ref imageReference, username, password string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if username == "" {
+			return "", fmt.Errorf("registry %s requires credentials", ref.Registry)
+		}
+		return "Basic " + basicAuth(username, password), nil
+	case "bearer":
+		return r.bearerToken(ctx, params, ref, username, password)
+	default:
+		return "", fmt.Errorf("unsupported registry authentication challenge %q", challenge)
+	}
+}
+
+func (r *RegistryImageResolver) bearerToken(ctx context.Context, challengeParams string, ref imageReference, username, password string) (string, error) {
+	params := map[string]string{}
+	for _, match := range authChallengeParamRegexp.FindAllStringSubmatch(challengeParams, -1) {
+		params[match[1]] = match[2]
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid registry token realm %q", params["realm"])
+	}
+
+	query := realm.Query()
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", ref.Repository)
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry token service returned %s", resp.Status)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("invalid registry token response: %w", err)
+	}
+
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return "", fmt.Errorf("registry token service returned no token")
+	}
+
+	return "Bearer " + token.Token, nil
+}
+
+// dockerConfigEntry is the entry of a registry in a docker config pull secret
+type dockerConfigEntry struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+// registryCredentials returns the credentials of the registry found in the
+// kubernetes.io/dockerconfigjson or kubernetes.io/dockercfg pull secrets
+func registryCredentials(pullSecrets []v1.Secret, registry string) (string, string) {
+	for idx := range pullSecrets {
+		entries := map[string]dockerConfigEntry{}
+
+		switch pullSecrets[idx].Type {
+		case v1.SecretTypeDockerConfigJson:
+			config := struct {
+				Auths map[string]dockerConfigEntry `json:"auths"`
+			}{}
+			if err := json.Unmarshal(pullSecrets[idx].Data[v1.DockerConfigJsonKey], &config); err != nil {
+				continue
+			}
+			entries = config.Auths
+		case v1.SecretTypeDockercfg:
+			if err := json.Unmarshal(pullSecrets[idx].Data[v1.DockerConfigKey], &entries); err != nil {
+				continue
+			}
+		}
+
+		for key, entry := range entries {
+			if normalizeRegistryHost(key) != registry {
+				continue
+			}
+
+			if entry.Username == "" && entry.Auth != "" {
+				decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+				if err != nil {
+					continue
+				}
+				entry.Username, entry.Password, _ = strings.Cut(string(decoded), ":")
+			}
+
+			return entry.Username, entry.Password
+		}
+	}
+
+	return "", ""
+}
+
+// normalizeRegistryHost returns the registry host of a docker config key,
+// which may be a URL such as https://index.docker.io/v1/
+func normalizeRegistryHost(key string) string {
+	key = strings.TrimPrefix(key, "https://")
+	key = strings.TrimPrefix(key, "http://")
+	key, _, _ = strings.Cut(key, "/")
+
+	switch key {
+	case "index.docker.io", dockerHubAPIRegistry:
+		return dockerHubRegistry
+	}
+
+	return key
+}
+
+func basicAuth(username, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
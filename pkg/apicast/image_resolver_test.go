@@ -0,0 +1,159 @@
+//go:build unit
+
+package apicast
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testImageDigest = "sha256:4f2c8ab3b0b9bd2b4c1e1a39e3c1f2d05d8c9a5e0e4b7e6f0e3b1d2c4a5f6e7d"
+
+// newTestRegistry returns a registry serving the manifest of
+// 3scale/apicast:3.15, with bearer tokens granted to the given credentials
+func newTestRegistry(t *testing.T, username, password string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			if user, pass, _ := r.BasicAuth(); user != username || pass != password {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Query().Get("scope") != "repository:3scale/apicast:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprint(w, `{"token": "secret-token"}`)
+		case r.URL.Path == "/v2/3scale/apicast/manifests/3.15":
+			if r.Header.Get("Authorization") != "Bearer secret-token" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test-registry"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if !strings.Contains(r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json") {
+				w.WriteHeader(http.StatusNotAcceptable)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", testImageDigest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRegistryImageResolver(t *testing.T) {
+	server := newTestRegistry(t, "robot", "s3cr3t")
+	registry := strings.TrimPrefix(server.URL, "https://")
+	resolver := &RegistryImageResolver{HTTPClient: server.Client()}
+
+	pullSecret := v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry"},
+		Type:       v1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			v1.DockerConfigJsonKey: []byte(fmt.Sprintf(`{"auths": {"https://%s/v1/": {"auth": "cm9ib3Q6czNjcjN0"}}}`, registry)),
+		},
+	}
+
+	cases := []struct {
+		testName      string
+		image         string
+		pullSecrets   []v1.Secret
+		expectedImage string
+		expectedError bool
+	}{
+		{"Resolved", registry + "/3scale/apicast:3.15", []v1.Secret{pullSecret}, registry + "/3scale/apicast@" + testImageDigest, false},
+		{"AlreadyPinned", registry + "/3scale/apicast@" + testImageDigest, nil, registry + "/3scale/apicast@" + testImageDigest, false},
+		{"NoCredentials", registry + "/3scale/apicast:3.15", nil, "", true},
+		{"UnknownTag", registry + "/3scale/apicast:0.1", []v1.Secret{pullSecret}, "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			image, err := resolver.Resolve(context.TODO(), tc.image, tc.pullSecrets)
+			if tc.expectedError {
+				if err == nil {
+					subT.Fatalf("expected an error, got image %s", image)
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if image != tc.expectedImage {
+				subT.Errorf("expected %s, got %s", tc.expectedImage, image)
+			}
+		})
+	}
+}
+
+func TestParseImageReference(t *testing.T) {
+	cases := []struct {
+		image    string
+		expected imageReference
+	}{
+		{"quay.io/3scale/apicast:3.15", imageReference{Name: "quay.io/3scale/apicast", Registry: "quay.io", Repository: "3scale/apicast", Tag: "3.15"}},
+		{"localhost:5000/apicast", imageReference{Name: "localhost:5000/apicast", Registry: "localhost:5000", Repository: "apicast", Tag: "latest"}},
+		{"nginx", imageReference{Name: "nginx", Registry: "docker.io", Repository: "library/nginx", Tag: "latest"}},
+		{"nginx:1.25", imageReference{Name: "nginx", Registry: "docker.io", Repository: "library/nginx", Tag: "1.25"}},
+		{"3scale/apicast@sha256:abc", imageReference{Name: "3scale/apicast", Registry: "docker.io", Repository: "3scale/apicast", Digest: "sha256:abc"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.image, func(subT *testing.T) {
+			ref, err := parseImageReference(tc.image)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if ref != tc.expected {
+				subT.Errorf("expected %+v, got %+v", tc.expected, ref)
+			}
+		})
+	}
+}
+
+func TestRegistryCredentials(t *testing.T) {
+	pullSecrets := []v1.Secret{
+		{
+			Type: v1.SecretTypeDockercfg,
+			Data: map[string][]byte{
+				v1.DockerConfigKey: []byte(`{"https://index.docker.io/v1/": {"username": "hub", "password": "hub-pass"}}`),
+			},
+		},
+		{
+			Type: v1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				v1.DockerConfigJsonKey: []byte(`{"auths": {"quay.io": {"auth": "cXVheTpxdWF5LXBhc3M="}}}`),
+			},
+		},
+	}
+
+	cases := []struct {
+		registry         string
+		expectedUsername string
+		expectedPassword string
+	}{
+		{"docker.io", "hub", "hub-pass"},
+		{"quay.io", "quay", "quay-pass"},
+		{"registry.example.com", "", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.registry, func(subT *testing.T) {
+			username, password := registryCredentials(pullSecrets, tc.registry)
+			if username != tc.expectedUsername || password != tc.expectedPassword {
+				subT.Errorf("expected %s:%s, got %s:%s", tc.expectedUsername, tc.expectedPassword, username, password)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package apicast
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+)
+
+var validLogLevels = []string{"debug", "info", "notice", "warn", "error", "crit", "alert", "emerg"}
+
+// OperatorConfig are the organization-wide defaults of the APIcast
+// resources, read from the operator configuration file. The values of the
+// APIcast resources take precedence.
+type OperatorConfig struct {
+	// ImageRegistry replaces the registry of the default APIcast image,
+	// e.g. to pull it from a mirror. Images set in the APIcast resources are
+	// not changed.
+	ImageRegistry string `json:"imageRegistry,omitempty"`
+
+	// Resources of the APIcast container
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Tolerations of the APIcast pods
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+
+	AllProxy   *string `json:"allProxy,omitempty"`
+	HTTPProxy  *string `json:"httpProxy,omitempty"`
+	HTTPSProxy *string `json:"httpsProxy,omitempty"`
+	NoProxy    *string `json:"noProxy,omitempty"`
+
+	// Labels added to the APIcast pods
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations added to the APIcast pods
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// LogLevel of APIcast's OpenResty logs
+	LogLevel *string `json:"logLevel,omitempty"`
+}
+
+// LoadOperatorConfig reads the operator configuration file. Unknown fields
+// are rejected, so typos are not silently ignored.
+func LoadOperatorConfig(path string) (*OperatorConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &OperatorConfig{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("invalid operator config %s: %w", path, err)
+	}
+
+	if err := config.Validate().ToAggregate(); err != nil {
+		return nil, fmt.Errorf("invalid operator config %s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+func (c *OperatorConfig) Validate() field.ErrorList {
+	errors := field.ErrorList{}
+
+	if c.LogLevel != nil {
+		valid := false
+		for _, logLevel := range validLogLevels {
+			valid = valid || *c.LogLevel == logLevel
+		}
+		if !valid {
+			errors = append(errors, field.NotSupported(field.NewPath("logLevel"), *c.LogLevel, validLogLevels))
+		}
+	}
+
+	if strings.Contains(c.ImageRegistry, "://") {
+		errors = append(errors, field.Invalid(field.NewPath("imageRegistry"), c.ImageRegistry, "must be a registry host, without scheme"))
+	}
+
+	return errors
+}
+
+// Image returns the default image pulled from the configured registry
+func (c *OperatorConfig) Image(image string) string {
+	if c == nil || c.ImageRegistry == "" {
+		return image
+	}
+
+	return ImageWithRegistry(image, c.ImageRegistry)
+}
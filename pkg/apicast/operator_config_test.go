@@ -0,0 +1,71 @@
+//go:build unit
+
+package apicast
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/utils/ptr"
+)
+
+func TestLoadOperatorConfig(t *testing.T) {
+	cases := []struct {
+		testName    string
+		content     string
+		expectError bool
+	}{
+		{"Valid", "imageRegistry: mirror.example.com\nlogLevel: warn\nlabels:\n  cost-center: api\n", false},
+		{"UnknownField", "imageRegistri: mirror.example.com\n", true},
+		{"InvalidLogLevel", "logLevel: verbose\n", true},
+		{"RegistryWithScheme", "imageRegistry: https://mirror.example.com\n", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			path := filepath.Join(subT.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+				subT.Fatal(err)
+			}
+
+			config, err := LoadOperatorConfig(path)
+			if tc.expectError {
+				if err == nil {
+					subT.Fatalf("expected an error, got %+v", config)
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if config.ImageRegistry != "mirror.example.com" || ptr.Deref(config.LogLevel, "") != "warn" || config.Labels["cost-center"] != "api" {
+				subT.Errorf("unexpected config %+v", config)
+			}
+		})
+	}
+}
+
+func TestImageWithRegistry(t *testing.T) {
+	cases := []struct {
+		image    string
+		registry string
+		expected string
+	}{
+		{"quay.io/3scale/apicast:latest", "mirror.example.com", "mirror.example.com/3scale/apicast:latest"},
+		{"quay.io/3scale/apicast:latest", "mirror.example.com:5000/quay/", "mirror.example.com:5000/quay/3scale/apicast:latest"},
+		{"localhost/apicast@sha256:abc", "mirror.example.com", "mirror.example.com/apicast@sha256:abc"},
+		{"3scale/apicast:latest", "mirror.example.com", "mirror.example.com/3scale/apicast:latest"},
+		{"apicast", "mirror.example.com", "mirror.example.com/library/apicast"},
+		{"nginx:1.25", "mirror.example.com", "mirror.example.com/library/nginx:1.25"},
+		{"docker.io/nginx@sha256:abc", "mirror.example.com", "mirror.example.com/library/nginx@sha256:abc"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.image, func(subT *testing.T) {
+			if image := ImageWithRegistry(tc.image, tc.registry); image != tc.expected {
+				subT.Errorf("expected %s, got %s", tc.expected, image)
+			}
+		})
+	}
+}
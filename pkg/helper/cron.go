@@ -0,0 +1,180 @@
+package helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCronSearch bounds the search of the next time matching a cron schedule
+const maxCronSearch = 366 * 24 * time.Hour
+
+// CronSchedule is a standard 5 fields cron schedule: minute, hour, day of
+// month, month and day of week. Fields support *, lists, ranges and steps.
+// Sunday is either 0 or 7.
+type CronSchedule struct {
+	minutes, hours, daysOfMonth, months, daysOfWeek map[int]bool
+	// day of month and day of week match either when both are restricted
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseCronSchedule parses a 5 fields cron schedule, e.g. "0 2 * * 6"
+func ParseCronSchedule(schedule string) (*CronSchedule, error) {
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron schedule %q: expected %d fields, got %d", schedule, len(cronFields), len(fields))
+	}
+
+	values := make([]map[int]bool, len(cronFields))
+	for idx, field := range fields {
+		parsed, err := parseCronField(field, cronFields[idx])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron schedule %q: %w", schedule, err)
+		}
+		values[idx] = parsed
+	}
+
+	if values[4][7] {
+		delete(values[4], 7)
+		values[4][0] = true
+	}
+
+	return &CronSchedule{
+		minutes:       values[0],
+		hours:         values[1],
+		daysOfMonth:   values[2],
+		months:        values[3],
+		daysOfWeek:    values[4],
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(value string, field cronField) (map[int]bool, error) {
+	result := map[int]bool{}
+
+	for _, part := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid %s step %q", field.name, stepPart)
+			}
+		}
+
+		start, end := field.min, field.max
+		if rangePart != "*" {
+			startPart, endPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			start, err = strconv.Atoi(startPart)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q", field.name, part)
+			}
+			end = start
+			if isRange {
+				end, err = strconv.Atoi(endPart)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s %q", field.name, part)
+				}
+			} else if hasStep {
+				end = field.max
+			}
+		}
+
+		if start < field.min || end > field.max || start > end {
+			return nil, fmt.Errorf("%s %q out of range %d-%d", field.name, part, field.min, field.max)
+		}
+
+		for v := start; v <= end; v += step {
+			result[v] = true
+		}
+	}
+
+	return result, nil
+}
+
+// Matches returns true when the minute of t matches the schedule
+func (c *CronSchedule) Matches(t time.Time) bool {
+	return c.minutes[t.Minute()] && c.hours[t.Hour()] && c.months[int(t.Month())] && c.matchesDay(t)
+}
+
+// matchesDay returns true when the day of t matches the day of month and the
+// day of week of the schedule
+func (c *CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := c.daysOfMonth[t.Day()]
+	dayOfWeek := c.daysOfWeek[int(t.Weekday())]
+	switch {
+	case c.anyDayOfMonth && c.anyDayOfWeek:
+		return true
+	case c.anyDayOfMonth:
+		return dayOfWeek
+	case c.anyDayOfWeek:
+		return dayOfMonth
+	default:
+		return dayOfMonth || dayOfWeek
+	}
+}
+
+// Next returns the first minute after t matching the schedule, false when
+// none matches within a year. Months, days and hours not matching the
+// schedule are skipped as a whole.
+func (c *CronSchedule) Next(t time.Time) (time.Time, bool) {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	for limit := t.Add(maxCronSearch); next.Before(limit); {
+		year, month, day := next.Date()
+
+		switch {
+		case !c.months[int(month)]:
+			next = nextCronStart(next, time.Date(year, month+1, 1, 0, 0, 0, 0, next.Location()))
+		case !c.matchesDay(next):
+			next = nextCronStart(next, time.Date(year, month, day+1, 0, 0, 0, 0, next.Location()))
+		case !c.hours[next.Hour()]:
+			next = next.Truncate(time.Hour).Add(time.Hour)
+		case !c.minutes[next.Minute()]:
+			next = next.Add(time.Minute)
+		default:
+			return next, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// nextCronStart returns the start of the next month or day, at least one
+// minute after t when time zone transitions move it back
+func nextCronStart(t, start time.Time) time.Time {
+	if !start.After(t) {
+		return t.Add(time.Minute)
+	}
+	return start
+}
+
+// Last returns the last minute at or before t matching the schedule, searching
+// back at most the given duration
+func (c *CronSchedule) Last(t time.Time, within time.Duration) (time.Time, bool) {
+	last := t.Truncate(time.Minute)
+	for limit := t.Add(-within); !last.Before(limit); last = last.Add(-time.Minute) {
+		if c.Matches(last) {
+			return last, true
+		}
+	}
+
+	return time.Time{}, false
+}
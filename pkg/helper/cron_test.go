@@ -0,0 +1,122 @@
+//go:build unit
+
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	cases := []struct {
+		schedule    string
+		expectedErr bool
+	}{
+		{"0 2 * * 6", false},
+		{"*/15 1-5 1,15 * 1-5", false},
+		{"30 22 * 1-12/3 *", false},
+		{"0 2 * *", true},
+		{"60 2 * * *", true},
+		{"0 2 * * 7", false},
+		{"0 2 * * 5-7", false},
+		{"0 2 * * 8", true},
+		{"0 5-2 * * *", true},
+		{"*/0 * * * *", true},
+		{"a * * * *", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.schedule, func(subT *testing.T) {
+			_, err := ParseCronSchedule(tc.schedule)
+			if (err != nil) != tc.expectedErr {
+				subT.Errorf("expected error %t, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestCronSchedule(t *testing.T) {
+	// Saturdays at 02:00
+	schedule, err := ParseCronSchedule("0 2 * * 6")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	friday := time.Date(2026, time.October, 16, 10, 30, 0, 0, time.UTC)
+	saturday := time.Date(2026, time.October, 17, 2, 0, 0, 0, time.UTC)
+
+	if schedule.Matches(friday) || !schedule.Matches(saturday) {
+		t.Errorf("unexpected matches")
+	}
+
+	if next, ok := schedule.Next(friday); !ok || !next.Equal(saturday) {
+		t.Errorf("expected next %s, got %s", saturday, next)
+	}
+
+	if last, ok := schedule.Last(saturday.Add(45*time.Minute), time.Hour); !ok || !last.Equal(saturday) {
+		t.Errorf("expected last %s, got %s", saturday, last)
+	}
+
+	if last, ok := schedule.Last(saturday.Add(2*time.Hour), time.Hour); ok {
+		t.Errorf("unexpected last %s", last)
+	}
+
+	// day of month and day of week match either
+	schedule, err = ParseCronSchedule("0 0 1 * 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sunday := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	firstOfMonth := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	if !schedule.Matches(sunday) || !schedule.Matches(firstOfMonth) || schedule.Matches(friday.Truncate(24*time.Hour)) {
+		t.Errorf("unexpected day matches")
+	}
+}
+
+func TestCronScheduleSunday(t *testing.T) {
+	sunday := time.Date(2026, time.October, 18, 2, 0, 0, 0, time.UTC)
+	monday := sunday.Add(24 * time.Hour)
+
+	for _, value := range []string{"0 2 * * 0", "0 2 * * 7", "0 2 * * 6-7", "0 2 * * */7"} {
+		schedule, err := ParseCronSchedule(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !schedule.Matches(sunday) || schedule.Matches(monday) {
+			t.Errorf("%s: unexpected matches", value)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	from := time.Date(2026, time.October, 17, 2, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		schedule   string
+		from       time.Time
+		expected   time.Time
+		expectedOk bool
+	}{
+		{"*/15 * * * *", from, time.Date(2026, time.October, 17, 2, 45, 0, 0, time.UTC), true},
+		{"0 2 * * 6", from, time.Date(2026, time.October, 24, 2, 0, 0, 0, time.UTC), true},
+		{"30 4 1 1 *", from, time.Date(2027, time.January, 1, 4, 30, 0, 0, time.UTC), true},
+		{"0 22 13 * 5", from, time.Date(2026, time.October, 23, 22, 0, 0, 0, time.UTC), true},
+		{"0 0 31 4 *", from, time.Time{}, false},
+		{"0 0 29 2 *", from, time.Time{}, false},
+		{"0 0 29 2 *", time.Date(2027, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.schedule, func(subT *testing.T) {
+			schedule, err := ParseCronSchedule(tc.schedule)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			next, ok := schedule.Next(tc.from)
+			if ok != tc.expectedOk || !next.Equal(tc.expected) {
+				subT.Errorf("expected %s %t, got %s %t", tc.expected, tc.expectedOk, next, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type prometheusQueryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// PrometheusInstantQuery runs an instant query against the Prometheus HTTP API
+// and returns the value of the first sample of the resulting vector. found is
+// false when the query returns no sample or the value is not a number, for
+// instance, when there is no traffic to compute a ratio.
+func PrometheusInstantQuery(ctx context.Context, httpClient *http.Client, prometheusURL, query string) (value float64, found bool, err error) {
+	queryURL := fmt.Sprintf("%s/api/v1/query?%s", strings.TrimSuffix(prometheusURL, "/"), url.Values{"query": {query}}.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
+	if err != nil {
+		return 0, false, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+
+	queryResponse := &prometheusQueryResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(queryResponse); err != nil {
+		return 0, false, fmt.Errorf("decoding prometheus response (HTTP %d): %w", resp.StatusCode, err)
+	}
+
+	if queryResponse.Status != "success" {
+		return 0, false, fmt.Errorf("prometheus query failed: %s: %s", queryResponse.ErrorType, queryResponse.Error)
+	}
+
+	if queryResponse.Data.ResultType != "vector" {
+		return 0, false, fmt.Errorf("unexpected prometheus result type %q", queryResponse.Data.ResultType)
+	}
+
+	if len(queryResponse.Data.Result) == 0 || len(queryResponse.Data.Result[0].Value) != 2 {
+		return 0, false, nil
+	}
+
+	// sample values are [<unix time>, "<value>"]
+	rawValue, ok := queryResponse.Data.Result[0].Value[1].(string)
+	if !ok {
+		return 0, false, fmt.Errorf("unexpected prometheus sample value %v", queryResponse.Data.Result[0].Value[1])
+	}
+
+	value, err = strconv.ParseFloat(rawValue, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("parsing prometheus sample value: %w", err)
+	}
+
+	if math.IsNaN(value) {
+		return 0, false, nil
+	}
+
+	return value, true, nil
+}
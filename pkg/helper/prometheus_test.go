@@ -0,0 +1,47 @@
+//go:build unit
+
+package helper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPrometheusInstantQuery(t *testing.T) {
+	cases := []struct {
+		testName      string
+		response      string
+		expectedValue float64
+		expectedFound bool
+		expectedErr   bool
+	}{
+		{"Sample", `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000.1,"2.5"]}]}}`, 2.5, true, false},
+		{"NoSample", `{"status":"success","data":{"resultType":"vector","result":[]}}`, 0, false, false},
+		{"NaN", `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000.1,"NaN"]}]}}`, 0, false, false},
+		{"QueryError", `{"status":"error","errorType":"bad_data","error":"parse error"}`, 0, false, true},
+		{"NotJSON", `not found`, 0, false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/query" || r.URL.Query().Get("query") != "up" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			value, found, err := PrometheusInstantQuery(context.TODO(), server.Client(), server.URL+"/", "up")
+			if (err != nil) != tc.expectedErr {
+				subT.Fatalf("unexpected error: %v", err)
+			}
+			if value != tc.expectedValue || found != tc.expectedFound {
+				subT.Fatalf("expected (%v, %t), got (%v, %t)", tc.expectedValue, tc.expectedFound, value, found)
+			}
+		})
+	}
+}
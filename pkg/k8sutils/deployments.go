@@ -5,6 +5,14 @@ import (
 	v1 "k8s.io/api/core/v1"
 )
 
+const (
+	// PodTemplateOverrideHashAnnotation holds the hash of the pod template override applied to the pod template
+	PodTemplateOverrideHashAnnotation = "apicast.apps.3scale.net/pod-template-override-hash"
+	// DeploymentProgressDeadlineExceededReason is the Progressing condition reason set by the
+	// deployment controller when the rollout does not progress within progressDeadlineSeconds
+	DeploymentProgressDeadlineExceededReason = "ProgressDeadlineExceeded"
+)
+
 func FindDeploymentStatusCondition(conditions []appsv1.DeploymentCondition, condType appsv1.DeploymentConditionType) *appsv1.DeploymentCondition {
 	for i := range conditions {
 		if conditions[i].Type == condType {
@@ -23,3 +31,14 @@ func IsStatusConditionTrue(conditions []appsv1.DeploymentCondition, condType app
 
 	return cond.Status == v1.ConditionTrue
 }
+
+// FindProgressDeadlineExceededCondition returns the Progressing condition when the
+// deployment rollout exceeded its progress deadline, nil otherwise
+func FindProgressDeadlineExceededCondition(conditions []appsv1.DeploymentCondition) *appsv1.DeploymentCondition {
+	cond := FindDeploymentStatusCondition(conditions, appsv1.DeploymentProgressing)
+	if cond == nil || cond.Status != v1.ConditionFalse || cond.Reason != DeploymentProgressDeadlineExceededReason {
+		return nil
+	}
+
+	return cond
+}
@@ -0,0 +1,36 @@
+//go:build unit
+
+package k8sutils
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestFindProgressDeadlineExceededCondition(t *testing.T) {
+	cases := []struct {
+		testName   string
+		conditions []appsv1.DeploymentCondition
+		expected   bool
+	}{
+		{"NoConditions", nil, false},
+		{"Progressing", []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentProgressing, Status: v1.ConditionTrue, Reason: "NewReplicaSetAvailable"},
+		}, false},
+		{"DeadlineExceeded", []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue, Reason: "MinimumReplicasAvailable"},
+			{Type: appsv1.DeploymentProgressing, Status: v1.ConditionFalse, Reason: DeploymentProgressDeadlineExceededReason},
+		}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			cond := FindProgressDeadlineExceededCondition(tc.conditions)
+			if (cond != nil) != tc.expected {
+				subT.Fatalf("expected condition found %t, got %v", tc.expected, cond)
+			}
+		})
+	}
+}
@@ -25,9 +25,10 @@ func TagObjectToDelete(obj KubernetesObject) {
 	annotations := obj.GetAnnotations()
 	if annotations == nil {
 		annotations = make(map[string]string)
-		obj.SetAnnotations(annotations)
 	}
 	annotations[DeleteTagAnnotation] = "true"
+	// Unstructured objects return a copy of the annotations
+	obj.SetAnnotations(annotations)
 }
 
 func IsObjectTaggedToDelete(obj KubernetesObject) bool {
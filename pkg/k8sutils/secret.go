@@ -1,12 +1,19 @@
 package k8sutils
 
 import (
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
 )
 
 const (
 	// ApicastSecretLabel is the label that secrets need to have in order to reconcile changes
 	ApicastSecretLabel = "apicast.apps.3scale.net/watched-by=apicast"
+
+	// SecretAllowedNamespacesAnnotation is the annotation a secret needs to have
+	// in order to be copied into other namespaces. The value is a comma separated
+	// list of namespaces, or "*" to allow any namespace.
+	SecretAllowedNamespacesAnnotation = "apicast.apps.3scale.net/allowed-namespaces"
 )
 
 func SecretStringDataFromData(secret *v1.Secret) map[string]string {
@@ -33,3 +40,25 @@ func IsSecretWatchedByApicast(secret *v1.Secret) bool {
 
 	return false
 }
+
+// IsSecretSharedWithNamespace returns true when the secret allows being copied
+// into the given namespace via the allowed-namespaces annotation
+func IsSecretSharedWithNamespace(secret *v1.Secret, namespace string) bool {
+	if secret == nil {
+		return false
+	}
+
+	allowed, ok := secret.GetAnnotations()[SecretAllowedNamespacesAnnotation]
+	if !ok {
+		return false
+	}
+
+	for _, ns := range strings.Split(allowed, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "*" || ns == namespace {
+			return true
+		}
+	}
+
+	return false
+}
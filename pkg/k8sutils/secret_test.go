@@ -64,3 +64,36 @@ func TestIsSecretWatchedByApicast(t *testing.T) {
 		})
 	}
 }
+
+func TestIsSecretSharedWithNamespace(t *testing.T) {
+	secretWithAnnotation := func(value string) *v1.Secret {
+		return &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "shared-secret",
+				Namespace:   "shared",
+				Annotations: map[string]string{SecretAllowedNamespacesAnnotation: value},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		secret    *v1.Secret
+		namespace string
+		want      bool
+	}{
+		{"Secret doesn't exist", nil, "gateway", false},
+		{"Secret without annotation", &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "s", Namespace: "shared"}}, "gateway", false},
+		{"Namespace listed", secretWithAnnotation("other, gateway"), "gateway", true},
+		{"Namespace not listed", secretWithAnnotation("other,gateway-2"), "gateway", false},
+		{"Wildcard", secretWithAnnotation("*"), "gateway", true},
+		{"Empty annotation", secretWithAnnotation(""), "gateway", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSecretSharedWithNamespace(tt.secret, tt.namespace); got != tt.want {
+				t.Errorf("IsSecretSharedWithNamespace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
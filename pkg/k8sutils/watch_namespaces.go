@@ -0,0 +1,87 @@
+package k8sutils
+
+import (
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// WatchNamespaceEnvVar is the environment variable with the comma
+	// separated list of namespaces watched by the operator.
+	// An empty value means the operator is running with cluster scope.
+	WatchNamespaceEnvVar = "WATCH_NAMESPACE"
+)
+
+// WatchedNamespaces are the namespaces watched by the operator.
+// No namespaces means all the namespaces are watched.
+type WatchedNamespaces []string
+
+// ParseWatchNamespaces returns the namespaces of a comma separated list
+func ParseWatchNamespaces(value string) WatchedNamespaces {
+	unique := map[string]bool{}
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			unique[ns] = true
+		}
+	}
+
+	namespaces := WatchedNamespaces{}
+	for ns := range unique {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	return namespaces
+}
+
+// AllNamespaces returns true when the operator watches all the namespaces
+func (w WatchedNamespaces) AllNamespaces() bool {
+	return len(w) == 0
+}
+
+// Contains returns true when the namespace is watched
+func (w WatchedNamespaces) Contains(namespace string) bool {
+	if w.AllNamespaces() {
+		return true
+	}
+
+	for _, ns := range w {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// CacheNamespaces returns the namespaces of the manager cache. It is nil
+// when all the namespaces are watched.
+func (w WatchedNamespaces) CacheNamespaces() map[string]cache.Config {
+	if w.AllNamespaces() {
+		return nil
+	}
+
+	namespaces := map[string]cache.Config{}
+	for _, ns := range w {
+		namespaces[ns] = cache.Config{}
+	}
+	return namespaces
+}
+
+// ListOptions scopes a list to the watched namespaces. Lists across several
+// namespaces are scoped by the manager cache.
+func (w WatchedNamespaces) ListOptions() []client.ListOption {
+	if len(w) == 1 {
+		return []client.ListOption{client.InNamespace(w[0])}
+	}
+	return nil
+}
+
+func (w WatchedNamespaces) String() string {
+	if w.AllNamespaces() {
+		return "all namespaces"
+	}
+	return strings.Join(w, ",")
+}
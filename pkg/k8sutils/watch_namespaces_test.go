@@ -0,0 +1,52 @@
+//go:build unit
+
+package k8sutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseWatchNamespaces(t *testing.T) {
+	cases := []struct {
+		testName string
+		value    string
+		expected WatchedNamespaces
+	}{
+		{"AllNamespaces", "", WatchedNamespaces{}},
+		{"SingleNamespace", "gateway-a", WatchedNamespaces{"gateway-a"}},
+		{"MultipleNamespaces", "gateway-b, gateway-a,,gateway-b", WatchedNamespaces{"gateway-a", "gateway-b"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			namespaces := ParseWatchNamespaces(tc.value)
+			if !reflect.DeepEqual(namespaces, tc.expected) {
+				subT.Errorf("expected %v, got %v", tc.expected, namespaces)
+			}
+		})
+	}
+}
+
+func TestWatchedNamespaces(t *testing.T) {
+	all := WatchedNamespaces{}
+	if !all.Contains("any") || all.CacheNamespaces() != nil || all.ListOptions() != nil {
+		t.Errorf("expected all the namespaces to be watched")
+	}
+
+	single := WatchedNamespaces{"gateway-a"}
+	if !single.Contains("gateway-a") || single.Contains("gateway-b") {
+		t.Errorf("unexpected watched namespaces %v", single)
+	}
+	if len(single.ListOptions()) != 1 {
+		t.Errorf("expected the lists to be scoped to the namespace")
+	}
+
+	multiple := WatchedNamespaces{"gateway-a", "gateway-b"}
+	if !multiple.Contains("gateway-b") || multiple.Contains("other") {
+		t.Errorf("unexpected watched namespaces %v", multiple)
+	}
+	if cacheNamespaces := multiple.CacheNamespaces(); len(cacheNamespaces) != 2 {
+		t.Errorf("unexpected cache namespaces %v", cacheNamespaces)
+	}
+}
@@ -0,0 +1,32 @@
+package reconcilers
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
+)
+
+// APIcastFleetMemberMutator ensures the spec of an APIcast member of a fleet.
+// Labels and annotations are merged, as the operator annotates the APIcasts too.
+func APIcastFleetMemberMutator(existingObj, desiredObj k8sutils.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*appsv1alpha1.APIcast)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *appsv1alpha1.APIcast", existingObj)
+	}
+	desired, ok := desiredObj.(*appsv1alpha1.APIcast)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *appsv1alpha1.APIcast", desiredObj)
+	}
+
+	update := k8sutils.EnsureObjectMeta(&existing.ObjectMeta, &desired.ObjectMeta)
+
+	if !equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
+		existing.Spec = desired.Spec
+		update = true
+	}
+
+	return update, nil
+}
@@ -0,0 +1,79 @@
+package reconcilers
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+)
+
+func TestAPIcastFleetMemberMutator(t *testing.T) {
+	logLevel := "info"
+	debugLogLevel := "debug"
+
+	tests := []struct {
+		name     string
+		existing *appsv1alpha1.APIcast
+		desired  *appsv1alpha1.APIcast
+		expected bool
+	}{
+		{
+			"test false when desired and existing are the same",
+			&appsv1alpha1.APIcast{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"a": "1"}, Annotations: map[string]string{"operator": "annotation"}},
+				Spec:       appsv1alpha1.APIcastSpec{LogLevel: &logLevel},
+			},
+			&appsv1alpha1.APIcast{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"a": "1"}},
+				Spec:       appsv1alpha1.APIcastSpec{LogLevel: &logLevel},
+			},
+			false,
+		},
+		{
+			"test true when spec changed",
+			&appsv1alpha1.APIcast{Spec: appsv1alpha1.APIcastSpec{LogLevel: &logLevel}},
+			&appsv1alpha1.APIcast{Spec: appsv1alpha1.APIcastSpec{LogLevel: &debugLogLevel}},
+			true,
+		},
+		{
+			"test true when labels changed",
+			&appsv1alpha1.APIcast{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"a": "1"}}},
+			&appsv1alpha1.APIcast{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"a": "2"}}},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			update, err := APIcastFleetMemberMutator(tt.existing, tt.desired)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if update != tt.expected {
+				subT.Fatalf("expected %t, got %t", tt.expected, update)
+			}
+			if !reflect.DeepEqual(tt.existing.Spec, tt.desired.Spec) {
+				subT.Errorf("the spec was not updated")
+			}
+			for key, value := range tt.desired.Labels {
+				if tt.existing.Labels[key] != value {
+					subT.Errorf("the label %s was not updated", key)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIcastFleetMemberMutatorKeepsAnnotations(t *testing.T) {
+	existing := &appsv1alpha1.APIcast{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"operator": "annotation"}}}
+	desired := &appsv1alpha1.APIcast{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"owner": "platform"}}}
+
+	if _, err := APIcastFleetMemberMutator(existing, desired); err != nil {
+		t.Fatal(err)
+	}
+	if existing.Annotations["operator"] != "annotation" || existing.Annotations["owner"] != "platform" {
+		t.Errorf("unexpected annotations %v", existing.Annotations)
+	}
+}
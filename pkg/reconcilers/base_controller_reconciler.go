@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -17,13 +18,16 @@ type BaseControllerReconciler struct {
 	apiClientReader client.Reader
 	//
 	scheme *runtime.Scheme
+	// recorder records events on the reconciled objects
+	recorder record.EventRecorder
 }
 
-func NewBaseControllerReconciler(client client.Client, apiClientReader client.Reader, scheme *runtime.Scheme) BaseControllerReconciler {
+func NewBaseControllerReconciler(client client.Client, apiClientReader client.Reader, scheme *runtime.Scheme, recorder record.EventRecorder) BaseControllerReconciler {
 	return BaseControllerReconciler{
 		client:          client,
 		apiClientReader: apiClientReader,
 		scheme:          scheme,
+		recorder:        recorder,
 	}
 }
 
@@ -45,3 +49,7 @@ func (r *BaseControllerReconciler) APIClientReader() client.Reader {
 func (r *BaseControllerReconciler) Scheme() *runtime.Scheme {
 	return r.scheme
 }
+
+func (r *BaseControllerReconciler) EventRecorder() record.EventRecorder {
+	return r.recorder
+}
@@ -5,9 +5,12 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
 	"github.com/3scale/apicast-operator/pkg/k8sutils"
 )
@@ -15,6 +18,16 @@ import (
 // MutateFn is a function which mutates the existing object into it's desired state.
 type MutateFn func(existing, desired k8sutils.KubernetesObject) (bool, error)
 
+// Reasons of the events recorded for the reconciled resources
+const (
+	EventReasonCreated      = "Created"
+	EventReasonCreateFailed = "CreateFailed"
+	EventReasonUpdated      = "Updated"
+	EventReasonUpdateFailed = "UpdateFailed"
+	EventReasonDeleted      = "Deleted"
+	EventReasonDeleteFailed = "DeleteFailed"
+)
+
 func CreateOnlyMutator(existing, desired k8sutils.KubernetesObject) (bool, error) {
 	return false, nil
 }
@@ -28,14 +41,19 @@ type BaseReconciler struct {
 	apiClientReader client.Reader
 	scheme          *runtime.Scheme
 	logger          logr.Logger
+	// recorder records the events of the reconciliation on eventObject
+	recorder    record.EventRecorder
+	eventObject runtime.Object
 }
 
-func NewBaseReconciler(client client.Client, apiClientReader client.Reader, scheme *runtime.Scheme, logger logr.Logger) BaseReconciler {
+func NewBaseReconciler(client client.Client, apiClientReader client.Reader, scheme *runtime.Scheme, logger logr.Logger, recorder record.EventRecorder, eventObject runtime.Object) BaseReconciler {
 	return BaseReconciler{
 		client:          client,
 		apiClientReader: apiClientReader,
 		scheme:          scheme,
 		logger:          logger,
+		recorder:        recorder,
+		eventObject:     eventObject,
 	}
 }
 
@@ -55,6 +73,15 @@ func (b *BaseReconciler) Logger() logr.Logger {
 	return b.logger
 }
 
+// RecordEventf records an event on the reconciled object. It is a no-op when
+// the reconciler has no event recorder.
+func (b *BaseReconciler) RecordEventf(eventType, reason, messageFmt string, args ...interface{}) {
+	if b.recorder == nil || b.eventObject == nil {
+		return
+	}
+	b.recorder.Eventf(b.eventObject, eventType, reason, messageFmt, args...)
+}
+
 // ReconcileResource attempts to mutate the existing state
 // in order to match the desired state. The object's desired state must be reconciled
 // with the existing state inside the passed in callback MutateFn.
@@ -103,15 +130,50 @@ func (b *BaseReconciler) ReconcileResource(ctx context.Context, obj, desired k8s
 
 func (b *BaseReconciler) createResource(ctx context.Context, obj k8sutils.KubernetesObject) error {
 	b.Logger().Info(fmt.Sprintf("Created object %s", k8sutils.ObjectInfo(obj)))
-	return b.Client().Create(ctx, obj)
+	err := b.Client().Create(ctx, obj)
+	if err != nil {
+		b.RecordEventf(v1.EventTypeWarning, EventReasonCreateFailed, "Failed to create %s %s: %v", b.objectKind(obj), obj.GetName(), err)
+		return err
+	}
+	b.RecordEventf(v1.EventTypeNormal, EventReasonCreated, "Created %s %s", b.objectKind(obj), obj.GetName())
+	return nil
 }
 
 func (b *BaseReconciler) updateResource(ctx context.Context, obj k8sutils.KubernetesObject) error {
 	b.Logger().Info(fmt.Sprintf("Updated object %s", k8sutils.ObjectInfo(obj)))
-	return b.Client().Update(ctx, obj)
+	err := b.Client().Update(ctx, obj)
+	if err != nil {
+		// conflicts are retried, the object might just be outdated
+		if !errors.IsConflict(err) {
+			b.RecordEventf(v1.EventTypeWarning, EventReasonUpdateFailed, "Failed to update %s %s: %v", b.objectKind(obj), obj.GetName(), err)
+		}
+		return err
+	}
+	b.RecordEventf(v1.EventTypeNormal, EventReasonUpdated, "Updated %s %s", b.objectKind(obj), obj.GetName())
+	return nil
 }
 
 func (b *BaseReconciler) deleteResource(ctx context.Context, obj k8sutils.KubernetesObject) error {
 	b.Logger().Info(fmt.Sprintf("Delete object %s", k8sutils.ObjectInfo(obj)))
-	return b.Client().Delete(ctx, obj)
+	err := b.Client().Delete(ctx, obj)
+	if err != nil {
+		b.RecordEventf(v1.EventTypeWarning, EventReasonDeleteFailed, "Failed to delete %s %s: %v", b.objectKind(obj), obj.GetName(), err)
+		return err
+	}
+	b.RecordEventf(v1.EventTypeNormal, EventReasonDeleted, "Deleted %s %s", b.objectKind(obj), obj.GetName())
+	return nil
+}
+
+// objectKind returns the kind of the object. Objects read from the cache do
+// not have the type meta set, so the kind is looked up in the scheme.
+func (b *BaseReconciler) objectKind(obj k8sutils.KubernetesObject) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	if b.scheme != nil {
+		if gvk, err := apiutil.GVKForObject(obj, b.scheme); err == nil {
+			return gvk.Kind
+		}
+	}
+	return "object"
 }
@@ -0,0 +1,82 @@
+package reconcilers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
+)
+
+func TestBaseReconcilerEvents(t *testing.T) {
+	owner := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "ns"}}
+	recorder := record.NewFakeRecorder(10)
+	cl := fake.NewClientBuilder().Build()
+	b := NewBaseReconciler(cl, cl, scheme.Scheme, logr.Discard(), recorder, owner)
+
+	desired := func() *v1.Secret {
+		return &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "ns"},
+			Data:       map[string][]byte{"a": []byte("1")},
+		}
+	}
+
+	steps := []struct {
+		name     string
+		desired  func() *v1.Secret
+		expected string
+	}{
+		{"create", desired, "Normal Created Created Secret my-secret"},
+		{"update", func() *v1.Secret {
+			secret := desired()
+			secret.Data["a"] = []byte("2")
+			return secret
+		}, "Normal Updated Updated Secret my-secret"},
+		{"delete", func() *v1.Secret {
+			secret := desired()
+			k8sutils.TagObjectToDelete(secret)
+			return secret
+		}, "Normal Deleted Deleted Secret my-secret"},
+	}
+
+	for _, step := range steps {
+		err := b.ReconcileResource(context.TODO(), &v1.Secret{}, step.desired(), SecretMutator(SecretDataMutator))
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+
+		select {
+		case event := <-recorder.Events:
+			if event != step.expected {
+				t.Errorf("%s: expected event %q, got %q", step.name, step.expected, event)
+			}
+		default:
+			t.Errorf("%s: expected event %q, got none", step.name, step.expected)
+		}
+	}
+
+	// nothing to do, no events
+	err := b.ReconcileResource(context.TODO(), &v1.Secret{}, steps[2].desired(), SecretMutator(SecretDataMutator))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("unexpected event: %s", <-recorder.Events)
+	}
+}
+
+func TestBaseReconcilerWithoutRecorder(t *testing.T) {
+	cl := fake.NewClientBuilder().Build()
+	b := NewBaseReconciler(cl, cl, scheme.Scheme, logr.Discard(), nil, nil)
+
+	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "ns"}}
+	if err := b.ReconcileResource(context.TODO(), &v1.Secret{}, secret, CreateOnlyMutator); err != nil {
+		t.Fatal(err)
+	}
+}
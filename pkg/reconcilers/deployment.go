@@ -7,6 +7,7 @@ import (
 	"github.com/3scale/apicast-operator/pkg/k8sutils"
 
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 )
 
 // DeploymentMutateFn is a function which mutates the existing Deployment into it's desired state.
@@ -67,6 +68,27 @@ func DeploymentImageMutator(desired, existing *appsv1.Deployment) bool {
 	return update
 }
 
+// DeploymentImagePullMutator ensures the APIcast container image pull policy
+// and the pod image pull secrets are reconciled
+func DeploymentImagePullMutator(desired, existing *appsv1.Deployment) bool {
+	updated := false
+
+	desiredContainer := &desired.Spec.Template.Spec.Containers[0]
+	existingContainer := &existing.Spec.Template.Spec.Containers[0]
+
+	if existingContainer.ImagePullPolicy != desiredContainer.ImagePullPolicy {
+		existingContainer.ImagePullPolicy = desiredContainer.ImagePullPolicy
+		updated = true
+	}
+
+	if !reflect.DeepEqual(existing.Spec.Template.Spec.ImagePullSecrets, desired.Spec.Template.Spec.ImagePullSecrets) {
+		existing.Spec.Template.Spec.ImagePullSecrets = desired.Spec.Template.Spec.ImagePullSecrets
+		updated = true
+	}
+
+	return updated
+}
+
 func DeploymentServiceAccountNameMutator(desired, existing *appsv1.Deployment) bool {
 	update := false
 
@@ -88,12 +110,13 @@ func DeploymentResourceMutator(desired, existing *appsv1.Deployment) bool {
 
 	//
 	// Check container resource requirements
+	// The APIcast container is the first one, sidecars are reconciled by DeploymentSidecarsMutator
 	//
-	if len(desired.Spec.Template.Spec.Containers) != 1 {
-		panic(fmt.Sprintf("%s desired spec.template.spec.containers length changed to '%d', should be 1", desiredName, len(desired.Spec.Template.Spec.Containers)))
+	if len(desired.Spec.Template.Spec.Containers) == 0 {
+		panic(fmt.Sprintf("%s desired spec.template.spec.containers is empty, should be at least 1", desiredName))
 	}
 
-	if len(existing.Spec.Template.Spec.Containers) != 1 {
+	if len(existing.Spec.Template.Spec.Containers) == 0 {
 		existing.Spec.Template.Spec.Containers = desired.Spec.Template.Spec.Containers
 		update = true
 	}
@@ -120,7 +143,7 @@ func DeploymentPodTemplateAnnotationsMutator(desired, existing *appsv1.Deploymen
 func DeploymentVolumesMutator(desired, existing *appsv1.Deployment) bool {
 	update := false
 
-	if !reflect.DeepEqual(existing.Spec.Template.Spec.Volumes, desired.Spec.Template.Spec.Volumes) {
+	if !equalDerivative(desired.Spec.Template.Spec.Volumes, existing.Spec.Template.Spec.Volumes) {
 		update = true
 		existing.Spec.Template.Spec.Volumes = desired.Spec.Template.Spec.Volumes
 	}
@@ -201,3 +224,159 @@ func DeploymentPriorityClassNameMutator(desired, existing *appsv1.Deployment) bo
 
 	return updated
 }
+
+// DeploymentSidecarsMutator ensures the containers next to the APIcast container are reconciled
+func DeploymentSidecarsMutator(desired, existing *appsv1.Deployment) bool {
+	updated := false
+
+	desiredSidecars := desired.Spec.Template.Spec.Containers[1:]
+	existingSidecars := existing.Spec.Template.Spec.Containers[1:]
+
+	if !equalDerivative(desiredSidecars, existingSidecars) {
+		existing.Spec.Template.Spec.Containers = append(existing.Spec.Template.Spec.Containers[:1], desiredSidecars...)
+		updated = true
+	}
+
+	return updated
+}
+
+// DeploymentInitContainersMutator ensures the init containers are reconciled
+func DeploymentInitContainersMutator(desired, existing *appsv1.Deployment) bool {
+	updated := false
+
+	if !equalDerivative(desired.Spec.Template.Spec.InitContainers, existing.Spec.Template.Spec.InitContainers) {
+		existing.Spec.Template.Spec.InitContainers = desired.Spec.Template.Spec.InitContainers
+		updated = true
+	}
+
+	return updated
+}
+
+// DeploymentPodTemplateOverrideMutator ensures the pod template override is reconciled.
+// Fields set by the override may not be reconciled by any other mutator, thus the
+// whole pod spec is replaced when the hash of the override changes or when a field
+// set in the desired pod spec, patched with the override, has drifted. Fields
+// not set in the desired pod spec are defaulted by the API server and ignored.
+func DeploymentPodTemplateOverrideMutator(desired, existing *appsv1.Deployment) bool {
+	desiredHash := desired.Spec.Template.Annotations[k8sutils.PodTemplateOverrideHashAnnotation]
+	existingHash := existing.Spec.Template.Annotations[k8sutils.PodTemplateOverrideHashAnnotation]
+
+	if desiredHash == existingHash &&
+		(desiredHash == "" || equality.Semantic.DeepDerivative(desired.Spec.Template.Spec, existing.Spec.Template.Spec)) {
+		return false
+	}
+
+	updated := true
+	existing.Spec.Template.Spec = desired.Spec.Template.Spec
+	k8sutils.MergeMapStringString(&updated, &existing.Spec.Template.Labels, desired.Spec.Template.Labels)
+	k8sutils.MergeMapStringString(&updated, &existing.Spec.Template.Annotations, desired.Spec.Template.Annotations)
+
+	// the override has been removed
+	if desiredHash == "" {
+		delete(existing.Spec.Template.Annotations, k8sutils.PodTemplateOverrideHashAnnotation)
+	}
+
+	return updated
+}
+
+// DeploymentProbesMutator ensures the APIcast container probes are reconciled
+func DeploymentProbesMutator(desired, existing *appsv1.Deployment) bool {
+	updated := false
+
+	desiredContainer := &desired.Spec.Template.Spec.Containers[0]
+	existingContainer := &existing.Spec.Template.Spec.Containers[0]
+
+	if !reflect.DeepEqual(existingContainer.LivenessProbe, desiredContainer.LivenessProbe) {
+		existingContainer.LivenessProbe = desiredContainer.LivenessProbe
+		updated = true
+	}
+
+	if !reflect.DeepEqual(existingContainer.ReadinessProbe, desiredContainer.ReadinessProbe) {
+		existingContainer.ReadinessProbe = desiredContainer.ReadinessProbe
+		updated = true
+	}
+
+	if !reflect.DeepEqual(existingContainer.StartupProbe, desiredContainer.StartupProbe) {
+		existingContainer.StartupProbe = desiredContainer.StartupProbe
+		updated = true
+	}
+
+	return updated
+}
+
+// DeploymentStrategyMutator ensures the deployment strategy is reconciled
+func DeploymentStrategyMutator(desired, existing *appsv1.Deployment) bool {
+	updated := false
+
+	if !reflect.DeepEqual(existing.Spec.Strategy, desired.Spec.Strategy) {
+		existing.Spec.Strategy = desired.Spec.Strategy
+		updated = true
+	}
+
+	return updated
+}
+
+// DeploymentMinReadySecondsMutator ensures the minReadySeconds is reconciled
+func DeploymentMinReadySecondsMutator(desired, existing *appsv1.Deployment) bool {
+	updated := false
+
+	if existing.Spec.MinReadySeconds != desired.Spec.MinReadySeconds {
+		existing.Spec.MinReadySeconds = desired.Spec.MinReadySeconds
+		updated = true
+	}
+
+	return updated
+}
+
+// DeploymentProgressDeadlineSecondsMutator ensures the progressDeadlineSeconds is reconciled
+func DeploymentProgressDeadlineSecondsMutator(desired, existing *appsv1.Deployment) bool {
+	updated := false
+
+	if !reflect.DeepEqual(existing.Spec.ProgressDeadlineSeconds, desired.Spec.ProgressDeadlineSeconds) {
+		existing.Spec.ProgressDeadlineSeconds = desired.Spec.ProgressDeadlineSeconds
+		updated = true
+	}
+
+	return updated
+}
+
+// DeploymentRevisionHistoryLimitMutator ensures the revisionHistoryLimit is reconciled
+func DeploymentRevisionHistoryLimitMutator(desired, existing *appsv1.Deployment) bool {
+	updated := false
+
+	if !reflect.DeepEqual(existing.Spec.RevisionHistoryLimit, desired.Spec.RevisionHistoryLimit) {
+		existing.Spec.RevisionHistoryLimit = desired.Spec.RevisionHistoryLimit
+		updated = true
+	}
+
+	return updated
+}
+
+// DeploymentGracefulShutdownMutator ensures the APIcast container preStop hook and
+// the pod termination grace period are reconciled
+func DeploymentGracefulShutdownMutator(desired, existing *appsv1.Deployment) bool {
+	updated := false
+
+	desiredContainer := &desired.Spec.Template.Spec.Containers[0]
+	existingContainer := &existing.Spec.Template.Spec.Containers[0]
+
+	if !reflect.DeepEqual(existingContainer.Lifecycle, desiredContainer.Lifecycle) {
+		existingContainer.Lifecycle = desiredContainer.Lifecycle
+		updated = true
+	}
+
+	if !reflect.DeepEqual(existing.Spec.Template.Spec.TerminationGracePeriodSeconds, desired.Spec.Template.Spec.TerminationGracePeriodSeconds) {
+		existing.Spec.Template.Spec.TerminationGracePeriodSeconds = desired.Spec.Template.Spec.TerminationGracePeriodSeconds
+		updated = true
+	}
+
+	return updated
+}
+
+// equalDerivative returns true when the existing items are the desired items,
+// ignoring the fields not set in the desired items and defaulted by the API
+// server, e.g. the termination message path of a container or the default
+// mode of a secret volume.
+func equalDerivative[T any](desired, existing []T) bool {
+	return len(desired) == len(existing) && equality.Semantic.DeepDerivative(desired, existing)
+}
@@ -4,10 +4,13 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
 	"github.com/google/go-cmp/cmp"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 )
 
 func TestAffinityMutator(t *testing.T) {
@@ -328,3 +331,444 @@ func TestDeploymentPodTemplateAnnotationsMutator(t *testing.T) {
 		})
 	}
 }
+
+func TestDeploymentSidecarsMutator(t *testing.T) {
+	apicastContainer := v1.Container{Name: "apicast-example", Image: "apicast:latest"}
+	deploymentFactory := func(sidecars ...v1.Container) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						Containers: append([]v1.Container{apicastContainer}, sidecars...),
+					},
+				},
+			},
+		}
+	}
+
+	logShipper := v1.Container{Name: "log-shipper", Image: "fluent-bit:1", Ports: []v1.ContainerPort{{Name: "metrics", ContainerPort: 2020}}}
+	logShipperUpgraded := v1.Container{Name: "log-shipper", Image: "fluent-bit:2", Ports: []v1.ContainerPort{{Name: "metrics", ContainerPort: 2020}}}
+	tokenRefresher := v1.Container{Name: "token-refresher", Image: "refresher"}
+	defaulted := func(container v1.Container) v1.Container {
+		container = *container.DeepCopy()
+		container.TerminationMessagePath = v1.TerminationMessagePathDefault
+		container.TerminationMessagePolicy = v1.TerminationMessageReadFile
+		container.ImagePullPolicy = v1.PullIfNotPresent
+		for idx := range container.Ports {
+			container.Ports[idx].Protocol = v1.ProtocolTCP
+		}
+		return container
+	}
+
+	cases := []struct {
+		testName       string
+		existing       *appsv1.Deployment
+		desired        *appsv1.Deployment
+		expectedResult bool
+	}{
+		{"NothingToReconcile", deploymentFactory(), deploymentFactory(), false},
+		{"EqualSidecars", deploymentFactory(logShipper), deploymentFactory(logShipper), false},
+		{"SidecarAdded", deploymentFactory(), deploymentFactory(logShipper, tokenRefresher), true},
+		{"SidecarRemoved", deploymentFactory(logShipper, tokenRefresher), deploymentFactory(logShipper), true},
+		{"SidecarChanged", deploymentFactory(logShipper), deploymentFactory(logShipperUpgraded), true},
+		{"ServerDefaults", deploymentFactory(defaulted(logShipper), defaulted(tokenRefresher)), deploymentFactory(logShipper, tokenRefresher), false},
+		{"DefaultedSidecarChanged", deploymentFactory(defaulted(logShipper)), deploymentFactory(logShipperUpgraded), true},
+		{"DefaultedSidecarRemoved", deploymentFactory(defaulted(logShipper), defaulted(tokenRefresher)), deploymentFactory(logShipper), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			update := DeploymentSidecarsMutator(tc.desired, tc.existing)
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+
+			if update && !reflect.DeepEqual(tc.existing.Spec.Template.Spec.Containers, tc.desired.Spec.Template.Spec.Containers) {
+				subT.Fatal(cmp.Diff(tc.existing.Spec.Template.Spec.Containers, tc.desired.Spec.Template.Spec.Containers))
+			}
+		})
+	}
+}
+
+func TestDeploymentInitContainersMutator(t *testing.T) {
+	deploymentFactory := func(initContainers []v1.Container) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						InitContainers: initContainers,
+					},
+				},
+			},
+		}
+	}
+
+	downloader := []v1.Container{{Name: "policy-downloader", Image: "curl"}}
+	defaultedDownloader := []v1.Container{{
+		Name: "policy-downloader", Image: "curl", ImagePullPolicy: v1.PullAlways,
+		TerminationMessagePath: v1.TerminationMessagePathDefault, TerminationMessagePolicy: v1.TerminationMessageReadFile,
+	}}
+
+	cases := []struct {
+		testName       string
+		existing       *appsv1.Deployment
+		desired        *appsv1.Deployment
+		expectedResult bool
+	}{
+		{"NothingToReconcile", deploymentFactory(nil), deploymentFactory(nil), false},
+		{"EqualInitContainers", deploymentFactory(downloader), deploymentFactory(downloader), false},
+		{"InitContainerAdded", deploymentFactory(nil), deploymentFactory(downloader), true},
+		{"InitContainerRemoved", deploymentFactory(downloader), deploymentFactory(nil), true},
+		{"ServerDefaults", deploymentFactory(defaultedDownloader), deploymentFactory(downloader), false},
+		{"DefaultedInitContainerRemoved", deploymentFactory(defaultedDownloader), deploymentFactory(nil), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			update := DeploymentInitContainersMutator(tc.desired, tc.existing)
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+
+			if update && !reflect.DeepEqual(tc.existing.Spec.Template.Spec.InitContainers, tc.desired.Spec.Template.Spec.InitContainers) {
+				subT.Fatalf("mismatch values: expected: %v, got %v", tc.desired.Spec.Template.Spec.InitContainers, tc.existing.Spec.Template.Spec.InitContainers)
+			}
+		})
+	}
+}
+
+func TestDeploymentVolumesMutator(t *testing.T) {
+	deploymentFactory := func(volumes ...v1.Volume) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						Volumes: volumes,
+					},
+				},
+			},
+		}
+	}
+
+	secretVolume := func(secretName string, defaultMode *int32) v1.Volume {
+		return v1.Volume{
+			Name: "policies",
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{SecretName: secretName, DefaultMode: defaultMode},
+			},
+		}
+	}
+	cache := v1.Volume{Name: "cache", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}
+
+	cases := []struct {
+		testName       string
+		existing       *appsv1.Deployment
+		desired        *appsv1.Deployment
+		expectedResult bool
+	}{
+		{"NothingToReconcile", deploymentFactory(), deploymentFactory(), false},
+		{"ServerDefaults", deploymentFactory(secretVolume("policies", ptr.To(v1.SecretVolumeSourceDefaultMode))), deploymentFactory(secretVolume("policies", nil)), false},
+		{"VolumeAdded", deploymentFactory(secretVolume("policies", ptr.To(v1.SecretVolumeSourceDefaultMode))), deploymentFactory(secretVolume("policies", nil), cache), true},
+		{"VolumeRemoved", deploymentFactory(secretVolume("policies", ptr.To(v1.SecretVolumeSourceDefaultMode)), cache), deploymentFactory(secretVolume("policies", nil)), true},
+		{"VolumeChanged", deploymentFactory(secretVolume("policies", ptr.To(v1.SecretVolumeSourceDefaultMode))), deploymentFactory(secretVolume("policies-v2", nil)), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			update := DeploymentVolumesMutator(tc.desired, tc.existing)
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+
+			if update && !reflect.DeepEqual(tc.existing.Spec.Template.Spec.Volumes, tc.desired.Spec.Template.Spec.Volumes) {
+				subT.Fatal(cmp.Diff(tc.existing.Spec.Template.Spec.Volumes, tc.desired.Spec.Template.Spec.Volumes))
+			}
+		})
+	}
+}
+
+func TestDeploymentPodTemplateOverrideMutator(t *testing.T) {
+	deploymentFactory := func(hash string, runtimeClassName *string) *appsv1.Deployment {
+		annotations := map[string]string{"prometheus.io/scrape": "true"}
+		if hash != "" {
+			annotations[k8sutils.PodTemplateOverrideHashAnnotation] = hash
+		}
+		return &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+					Spec: v1.PodSpec{
+						RuntimeClassName: runtimeClassName,
+						Containers:       []v1.Container{{Name: "apicast-example"}},
+					},
+				},
+			},
+		}
+	}
+
+	gvisor := "gvisor"
+	kata := "kata"
+
+	cases := []struct {
+		testName       string
+		existing       *appsv1.Deployment
+		desired        *appsv1.Deployment
+		expectedResult bool
+	}{
+		{"NothingToReconcile", deploymentFactory("", nil), deploymentFactory("", nil), false},
+		{"SameOverride", deploymentFactory("a", &gvisor), deploymentFactory("a", &gvisor), false},
+		{"OverrideAdded", deploymentFactory("", nil), deploymentFactory("a", &gvisor), true},
+		{"OverrideChanged", deploymentFactory("a", &gvisor), deploymentFactory("b", &kata), true},
+		{"OverrideRemoved", deploymentFactory("a", &gvisor), deploymentFactory("", nil), true},
+		{"OverrideDrifted", deploymentFactory("a", &kata), deploymentFactory("a", &gvisor), true},
+		{"OverrideFieldRemoved", deploymentFactory("a", nil), deploymentFactory("a", &gvisor), true},
+	}
+
+	t.Run("ServerDefaultedFields", func(subT *testing.T) {
+		existing := deploymentFactory("a", &gvisor)
+		existing.Spec.Template.Spec.DNSPolicy = v1.DNSClusterFirst
+		existing.Spec.Template.Spec.Containers[0].TerminationMessagePath = v1.TerminationMessagePathDefault
+		if DeploymentPodTemplateOverrideMutator(deploymentFactory("a", &gvisor), existing) {
+			subT.Fatal("expected no update")
+		}
+	})
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			update := DeploymentPodTemplateOverrideMutator(tc.desired, tc.existing)
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+			if !reflect.DeepEqual(tc.existing.Spec.Template.Spec, tc.desired.Spec.Template.Spec) {
+				subT.Fatal(cmp.Diff(tc.existing.Spec.Template.Spec, tc.desired.Spec.Template.Spec))
+			}
+			if !reflect.DeepEqual(tc.existing.Spec.Template.Annotations, tc.desired.Spec.Template.Annotations) {
+				subT.Fatal(cmp.Diff(tc.existing.Spec.Template.Annotations, tc.desired.Spec.Template.Annotations))
+			}
+		})
+	}
+}
+
+func TestDeploymentProbesMutator(t *testing.T) {
+	probeFactory := func(path string, failureThreshold int32) *v1.Probe {
+		return &v1.Probe{
+			ProbeHandler:     v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{Path: path}},
+			FailureThreshold: failureThreshold,
+		}
+	}
+	deploymentFactory := func(liveness, readiness, startup *v1.Probe) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						Containers: []v1.Container{{
+							Name:           "apicast-example",
+							LivenessProbe:  liveness,
+							ReadinessProbe: readiness,
+							StartupProbe:   startup,
+						}},
+					},
+				},
+			},
+		}
+	}
+
+	live := probeFactory("/status/live", 3)
+	ready := probeFactory("/status/ready", 3)
+	startup := probeFactory("/status/live", 30)
+	slowStartup := probeFactory("/status/live", 60)
+
+	cases := []struct {
+		testName       string
+		existing       *appsv1.Deployment
+		desired        *appsv1.Deployment
+		expectedResult bool
+	}{
+		{"NothingToReconcile", deploymentFactory(live, ready, startup), deploymentFactory(live, ready, startup), false},
+		{"StartupProbeAdded", deploymentFactory(live, ready, nil), deploymentFactory(live, ready, startup), true},
+		{"StartupProbeChanged", deploymentFactory(live, ready, startup), deploymentFactory(live, ready, slowStartup), true},
+		{"ReadinessProbeChanged", deploymentFactory(live, live, startup), deploymentFactory(live, ready, startup), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			update := DeploymentProbesMutator(tc.desired, tc.existing)
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+			if !reflect.DeepEqual(tc.existing.Spec.Template.Spec.Containers, tc.desired.Spec.Template.Spec.Containers) {
+				subT.Fatal(cmp.Diff(tc.existing.Spec.Template.Spec.Containers, tc.desired.Spec.Template.Spec.Containers))
+			}
+		})
+	}
+}
+
+func TestDeploymentStrategyMutator(t *testing.T) {
+	deploymentFactory := func(strategy appsv1.DeploymentStrategy) *appsv1.Deployment {
+		return &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Strategy: strategy}}
+	}
+	rollingUpdate := func(maxSurge, maxUnavailable string) appsv1.DeploymentStrategy {
+		surge := intstr.FromString(maxSurge)
+		unavailable := intstr.FromString(maxUnavailable)
+		return appsv1.DeploymentStrategy{
+			Type:          appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &surge, MaxUnavailable: &unavailable},
+		}
+	}
+	recreate := appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+
+	cases := []struct {
+		testName       string
+		existing       *appsv1.Deployment
+		desired        *appsv1.Deployment
+		expectedResult bool
+	}{
+		{"NothingToReconcile", deploymentFactory(rollingUpdate("25%", "25%")), deploymentFactory(rollingUpdate("25%", "25%")), false},
+		{"MaxUnavailableChanged", deploymentFactory(rollingUpdate("25%", "25%")), deploymentFactory(rollingUpdate("25%", "0%")), true},
+		{"RollingUpdateToRecreate", deploymentFactory(rollingUpdate("25%", "25%")), deploymentFactory(recreate), true},
+		{"RecreateToRollingUpdate", deploymentFactory(recreate), deploymentFactory(rollingUpdate("1", "0")), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			update := DeploymentStrategyMutator(tc.desired, tc.existing)
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+			if !reflect.DeepEqual(tc.existing.Spec.Strategy, tc.desired.Spec.Strategy) {
+				subT.Fatal(cmp.Diff(tc.existing.Spec.Strategy, tc.desired.Spec.Strategy))
+			}
+		})
+	}
+}
+
+func TestDeploymentRolloutSettingsMutators(t *testing.T) {
+	deploymentFactory := func(minReadySeconds int32, progressDeadlineSeconds, revisionHistoryLimit *int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				MinReadySeconds:         minReadySeconds,
+				ProgressDeadlineSeconds: progressDeadlineSeconds,
+				RevisionHistoryLimit:    revisionHistoryLimit,
+			},
+		}
+	}
+	int32Ptr := func(i int32) *int32 { return &i }
+
+	cases := []struct {
+		testName       string
+		existing       *appsv1.Deployment
+		desired        *appsv1.Deployment
+		expectedResult bool
+	}{
+		{"NothingToReconcile", deploymentFactory(0, int32Ptr(600), int32Ptr(10)), deploymentFactory(0, int32Ptr(600), int32Ptr(10)), false},
+		{"MinReadySecondsChanged", deploymentFactory(0, int32Ptr(600), int32Ptr(10)), deploymentFactory(30, int32Ptr(600), int32Ptr(10)), true},
+		{"ProgressDeadlineChanged", deploymentFactory(0, int32Ptr(600), int32Ptr(10)), deploymentFactory(0, int32Ptr(300), int32Ptr(10)), true},
+		{"RevisionHistoryLimitChanged", deploymentFactory(0, int32Ptr(600), int32Ptr(10)), deploymentFactory(0, int32Ptr(600), int32Ptr(3)), true},
+		{"RevisionHistoryLimitAdded", deploymentFactory(0, int32Ptr(600), nil), deploymentFactory(0, int32Ptr(600), int32Ptr(10)), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			update := DeploymentMutator(
+				DeploymentMinReadySecondsMutator,
+				DeploymentProgressDeadlineSecondsMutator,
+				DeploymentRevisionHistoryLimitMutator,
+			)
+			updated, err := update(tc.existing, tc.desired)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if updated != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, updated)
+			}
+			if !reflect.DeepEqual(tc.existing.Spec, tc.desired.Spec) {
+				subT.Fatal(cmp.Diff(tc.existing.Spec, tc.desired.Spec))
+			}
+		})
+	}
+}
+
+func TestDeploymentGracefulShutdownMutator(t *testing.T) {
+	deploymentFactory := func(lifecycle *v1.Lifecycle, terminationGracePeriodSeconds int64) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						TerminationGracePeriodSeconds: &terminationGracePeriodSeconds,
+						Containers:                    []v1.Container{{Name: "apicast-example", Lifecycle: lifecycle}},
+					},
+				},
+			},
+		}
+	}
+	preStop := func(command string) *v1.Lifecycle {
+		return &v1.Lifecycle{PreStop: &v1.LifecycleHandler{Exec: &v1.ExecAction{Command: []string{"/bin/sh", "-c", command}}}}
+	}
+
+	cases := []struct {
+		testName       string
+		existing       *appsv1.Deployment
+		desired        *appsv1.Deployment
+		expectedResult bool
+	}{
+		{"NothingToReconcile", deploymentFactory(preStop("sleep 15"), 60), deploymentFactory(preStop("sleep 15"), 60), false},
+		{"Enabled", deploymentFactory(nil, 30), deploymentFactory(preStop("sleep 15"), 60), true},
+		{"DelayChanged", deploymentFactory(preStop("sleep 15"), 60), deploymentFactory(preStop("sleep 20"), 60), true},
+		{"Disabled", deploymentFactory(preStop("sleep 15"), 60), deploymentFactory(nil, 30), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			update := DeploymentGracefulShutdownMutator(tc.desired, tc.existing)
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+			if !reflect.DeepEqual(tc.existing.Spec.Template.Spec, tc.desired.Spec.Template.Spec) {
+				subT.Fatal(cmp.Diff(tc.existing.Spec.Template.Spec, tc.desired.Spec.Template.Spec))
+			}
+		})
+	}
+}
+
+func TestDeploymentImagePullMutator(t *testing.T) {
+	deploymentFactory := func(pullPolicy v1.PullPolicy, pullSecrets ...string) *appsv1.Deployment {
+		var imagePullSecrets []v1.LocalObjectReference
+		for _, name := range pullSecrets {
+			imagePullSecrets = append(imagePullSecrets, v1.LocalObjectReference{Name: name})
+		}
+		return &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						ImagePullSecrets: imagePullSecrets,
+						Containers:       []v1.Container{{Name: "apicast-example", ImagePullPolicy: pullPolicy}},
+					},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		testName       string
+		existing       *appsv1.Deployment
+		desired        *appsv1.Deployment
+		expectedResult bool
+	}{
+		{"NothingToReconcile", deploymentFactory(v1.PullAlways, "registry"), deploymentFactory(v1.PullAlways, "registry"), false},
+		{"PullPolicyChanged", deploymentFactory(v1.PullAlways), deploymentFactory(v1.PullIfNotPresent), true},
+		{"PullSecretsAdded", deploymentFactory(v1.PullAlways), deploymentFactory(v1.PullAlways, "registry"), true},
+		{"PullSecretsRemoved", deploymentFactory(v1.PullAlways, "registry"), deploymentFactory(v1.PullAlways), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			update := DeploymentImagePullMutator(tc.desired, tc.existing)
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+			if !reflect.DeepEqual(tc.existing.Spec.Template.Spec, tc.desired.Spec.Template.Spec) {
+				subT.Fatal(cmp.Diff(tc.existing.Spec.Template.Spec, tc.desired.Spec.Template.Spec))
+			}
+		})
+	}
+}
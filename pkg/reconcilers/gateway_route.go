@@ -0,0 +1,71 @@
+package reconcilers
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
+)
+
+func HTTPRouteMutator(existingObj, desiredObj k8sutils.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*gatewayapiv1.HTTPRoute)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *gatewayapiv1.HTTPRoute", existingObj)
+	}
+	desired, ok := desiredObj.(*gatewayapiv1.HTTPRoute)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *gatewayapiv1.HTTPRoute", desiredObj)
+	}
+
+	update := false
+
+	if !equality.Semantic.DeepEqual(existing.Spec.ParentRefs, desired.Spec.ParentRefs) {
+		existing.Spec.ParentRefs = desired.Spec.ParentRefs
+		update = true
+	}
+
+	if !equality.Semantic.DeepEqual(existing.Spec.Hostnames, desired.Spec.Hostnames) {
+		existing.Spec.Hostnames = desired.Spec.Hostnames
+		update = true
+	}
+
+	if !equality.Semantic.DeepEqual(existing.Spec.Rules, desired.Spec.Rules) {
+		existing.Spec.Rules = desired.Spec.Rules
+		update = true
+	}
+
+	return update, nil
+}
+
+func TLSRouteMutator(existingObj, desiredObj k8sutils.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*gatewayapiv1alpha2.TLSRoute)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *gatewayapiv1alpha2.TLSRoute", existingObj)
+	}
+	desired, ok := desiredObj.(*gatewayapiv1alpha2.TLSRoute)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *gatewayapiv1alpha2.TLSRoute", desiredObj)
+	}
+
+	update := false
+
+	if !equality.Semantic.DeepEqual(existing.Spec.ParentRefs, desired.Spec.ParentRefs) {
+		existing.Spec.ParentRefs = desired.Spec.ParentRefs
+		update = true
+	}
+
+	if !equality.Semantic.DeepEqual(existing.Spec.Hostnames, desired.Spec.Hostnames) {
+		existing.Spec.Hostnames = desired.Spec.Hostnames
+		update = true
+	}
+
+	if !equality.Semantic.DeepEqual(existing.Spec.Rules, desired.Spec.Rules) {
+		existing.Spec.Rules = desired.Spec.Rules
+		update = true
+	}
+
+	return update, nil
+}
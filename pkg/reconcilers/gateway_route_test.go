@@ -0,0 +1,121 @@
+package reconcilers
+
+import (
+	"testing"
+
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestHTTPRouteMutator(t *testing.T) {
+	httpRouteFactory := func(gateway string, hostname gatewayapiv1.Hostname, path string) *gatewayapiv1.HTTPRoute {
+		pathType := gatewayapiv1.PathMatchPathPrefix
+		return &gatewayapiv1.HTTPRoute{
+			Spec: gatewayapiv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayapiv1.CommonRouteSpec{
+					ParentRefs: []gatewayapiv1.ParentReference{{Name: gatewayapiv1.ObjectName(gateway)}},
+				},
+				Hostnames: []gatewayapiv1.Hostname{hostname},
+				Rules: []gatewayapiv1.HTTPRouteRule{
+					{
+						Matches: []gatewayapiv1.HTTPRouteMatch{
+							{Path: &gatewayapiv1.HTTPPathMatch{Type: &pathType, Value: &path}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		existing *gatewayapiv1.HTTPRoute
+		desired  *gatewayapiv1.HTTPRoute
+		expected bool
+	}{
+		{
+			"test false when desired and existing are the same",
+			httpRouteFactory("gw", "foo", "/"), httpRouteFactory("gw", "foo", "/"), false,
+		},
+		{
+			"test true when desired and existing parent refs do not match",
+			httpRouteFactory("gw", "foo", "/"), httpRouteFactory("other", "foo", "/"), true,
+		},
+		{
+			"test true when desired and existing hostnames do not match",
+			httpRouteFactory("gw", "foo", "/"), httpRouteFactory("gw", "bar", "/"), true,
+		},
+		{
+			"test true when desired and existing rules do not match",
+			httpRouteFactory("gw", "foo", "/"), httpRouteFactory("gw", "foo", "/api"), true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			changed, err := HTTPRouteMutator(tc.existing, tc.desired)
+			if err != nil {
+				t.Error("unexpected error: ", err)
+			}
+			if changed != tc.expected {
+				t.Error("expected mutator return ", tc.expected, " but got: ", changed)
+			}
+		})
+	}
+}
+
+func TestTLSRouteMutator(t *testing.T) {
+	tlsRouteFactory := func(gateway string, hostname gatewayapiv1.Hostname, port gatewayapiv1.PortNumber) *gatewayapiv1alpha2.TLSRoute {
+		return &gatewayapiv1alpha2.TLSRoute{
+			Spec: gatewayapiv1alpha2.TLSRouteSpec{
+				CommonRouteSpec: gatewayapiv1.CommonRouteSpec{
+					ParentRefs: []gatewayapiv1.ParentReference{{Name: gatewayapiv1.ObjectName(gateway)}},
+				},
+				Hostnames: []gatewayapiv1.Hostname{hostname},
+				Rules: []gatewayapiv1alpha2.TLSRouteRule{
+					{
+						BackendRefs: []gatewayapiv1.BackendRef{
+							{BackendObjectReference: gatewayapiv1.BackendObjectReference{Name: "apicast-example", Port: &port}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		existing *gatewayapiv1alpha2.TLSRoute
+		desired  *gatewayapiv1alpha2.TLSRoute
+		expected bool
+	}{
+		{
+			"test false when desired and existing are the same",
+			tlsRouteFactory("gw", "foo", 8443), tlsRouteFactory("gw", "foo", 8443), false,
+		},
+		{
+			"test true when desired and existing parent refs do not match",
+			tlsRouteFactory("gw", "foo", 8443), tlsRouteFactory("other", "foo", 8443), true,
+		},
+		{
+			"test true when desired and existing hostnames do not match",
+			tlsRouteFactory("gw", "foo", 8443), tlsRouteFactory("gw", "bar", 8443), true,
+		},
+		{
+			"test true when desired and existing backend ports do not match",
+			tlsRouteFactory("gw", "foo", 8443), tlsRouteFactory("gw", "foo", 9443), true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			changed, err := TLSRouteMutator(tc.existing, tc.desired)
+			if err != nil {
+				t.Error("unexpected error: ", err)
+			}
+			if changed != tc.expected {
+				t.Error("expected mutator return ", tc.expected, " but got: ", changed)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package reconcilers
+
+import (
+	"fmt"
+	"reflect"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
+)
+
+func GrafanaDashboardConfigMapMutator(existingObj, desiredObj k8sutils.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*v1.ConfigMap)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.ConfigMap", existingObj)
+	}
+	desired, ok := desiredObj.(*v1.ConfigMap)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.ConfigMap", desiredObj)
+	}
+
+	update := false
+
+	// Labels are used by the Grafana dashboards sidecar to select the dashboard
+	k8sutils.MergeMapStringString(&update, &existing.Labels, desired.Labels)
+
+	if !reflect.DeepEqual(existing.Data, desired.Data) {
+		existing.Data = desired.Data
+		update = true
+	}
+
+	return update, nil
+}
+
+func GrafanaDashboardMutator(existingObj, desiredObj k8sutils.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*unstructured.Unstructured)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *unstructured.Unstructured", existingObj)
+	}
+	desired, ok := desiredObj.(*unstructured.Unstructured)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *unstructured.Unstructured", desiredObj)
+	}
+
+	update := false
+
+	existingLabels := existing.GetLabels()
+	k8sutils.MergeMapStringString(&update, &existingLabels, desired.GetLabels())
+	if update {
+		existing.SetLabels(existingLabels)
+	}
+
+	for _, fieldName := range []string{"json", "instanceSelector"} {
+		existingField, _, err := unstructured.NestedFieldCopy(existing.Object, "spec", fieldName)
+		if err != nil {
+			return false, err
+		}
+		desiredField, _, err := unstructured.NestedFieldCopy(desired.Object, "spec", fieldName)
+		if err != nil {
+			return false, err
+		}
+
+		if !equality.Semantic.DeepEqual(existingField, desiredField) {
+			if err := unstructured.SetNestedField(existing.Object, desiredField, "spec", fieldName); err != nil {
+				return false, err
+			}
+			update = true
+		}
+	}
+
+	return update, nil
+}
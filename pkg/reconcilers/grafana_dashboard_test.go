@@ -0,0 +1,123 @@
+package reconcilers
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGrafanaDashboardConfigMapMutator(t *testing.T) {
+	configMapFactory := func(labels map[string]string, dashboard string) *v1.ConfigMap {
+		return &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Labels: labels},
+			Data:       map[string]string{"apicast-example.json": dashboard},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		existing *v1.ConfigMap
+		desired  *v1.ConfigMap
+		expected bool
+	}{
+		{
+			"test false when desired and existing are the same",
+			configMapFactory(map[string]string{"grafana_dashboard": "1"}, "{}"),
+			configMapFactory(map[string]string{"grafana_dashboard": "1"}, "{}"),
+			false,
+		},
+		{
+			"test true when desired label is missing",
+			configMapFactory(nil, "{}"),
+			configMapFactory(map[string]string{"grafana_dashboard": "1"}, "{}"),
+			true,
+		},
+		{
+			"test true when desired and existing data do not match",
+			configMapFactory(map[string]string{"grafana_dashboard": "1"}, "{}"),
+			configMapFactory(map[string]string{"grafana_dashboard": "1"}, `{"title":"apicast"}`),
+			true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			changed, err := GrafanaDashboardConfigMapMutator(tc.existing, tc.desired)
+			if err != nil {
+				t.Error("unexpected error: ", err)
+			}
+			if changed != tc.expected {
+				t.Error("expected mutator return ", tc.expected, " but got: ", changed)
+			}
+		})
+	}
+}
+
+func TestGrafanaDashboardMutator(t *testing.T) {
+	dashboardFactory := func(labels map[string]string, dashboard string, instanceSelector map[string]interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"json":             dashboard,
+				"instanceSelector": instanceSelector,
+			},
+		}}
+		obj.SetLabels(labels)
+		return obj
+	}
+
+	grafanaSelector := map[string]interface{}{"matchLabels": map[string]interface{}{"dashboards": "grafana"}}
+
+	tests := []struct {
+		name     string
+		existing *unstructured.Unstructured
+		desired  *unstructured.Unstructured
+		expected bool
+	}{
+		{
+			"test false when desired and existing are the same",
+			dashboardFactory(map[string]string{"app": "apicast"}, "{}", map[string]interface{}{}),
+			dashboardFactory(map[string]string{"app": "apicast"}, "{}", map[string]interface{}{}),
+			false,
+		},
+		{
+			"test true when desired label is missing",
+			dashboardFactory(nil, "{}", map[string]interface{}{}),
+			dashboardFactory(map[string]string{"app": "apicast"}, "{}", map[string]interface{}{}),
+			true,
+		},
+		{
+			"test true when desired and existing json do not match",
+			dashboardFactory(nil, "{}", map[string]interface{}{}),
+			dashboardFactory(nil, `{"title":"apicast"}`, map[string]interface{}{}),
+			true,
+		},
+		{
+			"test true when desired and existing instance selector do not match",
+			dashboardFactory(nil, "{}", map[string]interface{}{}),
+			dashboardFactory(nil, "{}", grafanaSelector),
+			true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			changed, err := GrafanaDashboardMutator(tc.existing, tc.desired)
+			if err != nil {
+				t.Error("unexpected error: ", err)
+			}
+			if changed != tc.expected {
+				t.Error("expected mutator return ", tc.expected, " but got: ", changed)
+			}
+		})
+	}
+
+	existing := dashboardFactory(nil, "{}", map[string]interface{}{})
+	if _, err := GrafanaDashboardMutator(existing, dashboardFactory(map[string]string{"app": "apicast"}, "{}", grafanaSelector)); err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+	if existing.GetLabels()["app"] != "apicast" {
+		t.Error("expected labels to be updated, got: ", existing.GetLabels())
+	}
+}
@@ -2,39 +2,257 @@ package reconcilers
 
 import (
 	"fmt"
+	"reflect"
 
 	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
 	helper "github.com/3scale/apicast-operator/pkg/helper"
 	"github.com/3scale/apicast-operator/pkg/k8sutils"
 	hpa "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	DefaultHpaMinReplicas             int32 = 1
+	DefaultHpaMaxReplicas             int32 = 5
+	DefaultHpaCPUTargetUtilization    int32 = 85
+	DefaultHpaMemoryTargetUtilization int32 = 85
+)
+
 // HpaMutateFn is a function which mutates the existing Hpa into it's desired state.
 type HpaMutateFn func(desired, existing *hpa.HorizontalPodAutoscaler) bool
 
-func HpaCreateOnlyMutator() MutateFn {
+func HpaMutator(opts ...HpaMutateFn) MutateFn {
 	return func(existingObj, desiredObj k8sutils.KubernetesObject) (bool, error) {
-		return false, nil
+		existing, ok := existingObj.(*hpa.HorizontalPodAutoscaler)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *hpa.HorizontalPodAutoscaler", existingObj)
+		}
+		desired, ok := desiredObj.(*hpa.HorizontalPodAutoscaler)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *hpa.HorizontalPodAutoscaler", desiredObj)
+		}
+
+		update := false
+
+		// Loop through each option
+		for _, opt := range opts {
+			tmpUpdate := opt(desired, existing)
+			update = update || tmpUpdate
+		}
+
+		return update, nil
 	}
 }
 
+// HpaCreateOnlyMutator does not revert the changes made to the HPA, it only
+// ensures the HPA is owned by the APIcast
+func HpaCreateOnlyMutator() MutateFn {
+	return HpaMutator(HpaOwnerReferencesMutator)
+}
+
 func HpaDeleteMutator() MutateFn {
 	return func(existingObj, desiredObj k8sutils.KubernetesObject) (bool, error) {
 		return false, nil
 	}
 }
 
+func HpaScaleTargetRefMutator(desired, existing *hpa.HorizontalPodAutoscaler) bool {
+	update := false
+
+	if !reflect.DeepEqual(existing.Spec.ScaleTargetRef, desired.Spec.ScaleTargetRef) {
+		existing.Spec.ScaleTargetRef = desired.Spec.ScaleTargetRef
+		update = true
+	}
+
+	return update
+}
+
+func HpaMinReplicasMutator(desired, existing *hpa.HorizontalPodAutoscaler) bool {
+	update := false
+
+	if !reflect.DeepEqual(existing.Spec.MinReplicas, desired.Spec.MinReplicas) {
+		existing.Spec.MinReplicas = desired.Spec.MinReplicas
+		update = true
+	}
+
+	return update
+}
+
+func HpaMaxReplicasMutator(desired, existing *hpa.HorizontalPodAutoscaler) bool {
+	update := false
+
+	if existing.Spec.MaxReplicas != desired.Spec.MaxReplicas {
+		existing.Spec.MaxReplicas = desired.Spec.MaxReplicas
+		update = true
+	}
+
+	return update
+}
+
+func HpaMetricsMutator(desired, existing *hpa.HorizontalPodAutoscaler) bool {
+	update := false
+
+	// Semantic comparison to avoid false positives on resource.Quantity values
+	if !equality.Semantic.DeepEqual(existing.Spec.Metrics, desired.Spec.Metrics) {
+		existing.Spec.Metrics = desired.Spec.Metrics
+		update = true
+	}
+
+	return update
+}
+
+// HpaOwnerReferencesMutator ensures the HPA is owned by the APIcast. HPAs
+// created by previous operator versions have no owner references.
+func HpaOwnerReferencesMutator(desired, existing *hpa.HorizontalPodAutoscaler) bool {
+	update := false
+
+	for _, ownerRef := range desired.GetOwnerReferences() {
+		found := false
+		for _, existingOwnerRef := range existing.GetOwnerReferences() {
+			if existingOwnerRef.UID == ownerRef.UID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing.OwnerReferences = append(existing.OwnerReferences, ownerRef)
+			update = true
+		}
+	}
+
+	return update
+}
+
+// HpaBehaviorMutator ensures the scaling behavior is reconciled. The API server
+// defaults the scaling rules that are not set, thus the existing rules are
+// compared with the desired rules once defaulted.
+func HpaBehaviorMutator(desired, existing *hpa.HorizontalPodAutoscaler) bool {
+	update := false
+
+	if desired.Spec.Behavior == nil {
+		if existing.Spec.Behavior != nil {
+			existing.Spec.Behavior = nil
+			update = true
+		}
+		return update
+	}
+
+	if existing.Spec.Behavior == nil {
+		existing.Spec.Behavior = &hpa.HorizontalPodAutoscalerBehavior{}
+		update = true
+	}
+
+	tmpUpdate := hpaScalingRulesMutator(defaultedHpaScalingRules(desired.Spec.Behavior.ScaleUp, hpaDefaultScaleUpRules()), &existing.Spec.Behavior.ScaleUp)
+	update = update || tmpUpdate
+
+	tmpUpdate = hpaScalingRulesMutator(defaultedHpaScalingRules(desired.Spec.Behavior.ScaleDown, hpaDefaultScaleDownRules()), &existing.Spec.Behavior.ScaleDown)
+	update = update || tmpUpdate
+
+	return update
+}
+
+func hpaScalingRulesMutator(desired *hpa.HPAScalingRules, existing **hpa.HPAScalingRules) bool {
+	if reflect.DeepEqual(*existing, desired) {
+		return false
+	}
+
+	*existing = desired
+	return true
+}
+
+// defaultedHpaScalingRules returns the scaling rules with the fields not set
+// defaulted the way the API server does
+func defaultedHpaScalingRules(rules, defaults *hpa.HPAScalingRules) *hpa.HPAScalingRules {
+	if rules == nil {
+		return defaults
+	}
+
+	defaulted := rules.DeepCopy()
+	if defaulted.StabilizationWindowSeconds == nil {
+		defaulted.StabilizationWindowSeconds = defaults.StabilizationWindowSeconds
+	}
+	if defaulted.SelectPolicy == nil {
+		defaulted.SelectPolicy = defaults.SelectPolicy
+	}
+	if defaulted.Policies == nil {
+		defaulted.Policies = defaults.Policies
+	}
+
+	return defaulted
+}
+
+// hpaDefaultScaleUpRules returns the scale up rules defaulted by the
+// autoscaling/v2 API when the behavior is set
+func hpaDefaultScaleUpRules() *hpa.HPAScalingRules {
+	selectMax := hpa.MaxChangePolicySelect
+	return &hpa.HPAScalingRules{
+		StabilizationWindowSeconds: helper.Int32Ptr(0),
+		SelectPolicy:               &selectMax,
+		Policies: []hpa.HPAScalingPolicy{
+			{Type: hpa.PodsScalingPolicy, Value: 4, PeriodSeconds: 15},
+			{Type: hpa.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		},
+	}
+}
+
+// hpaDefaultScaleDownRules returns the scale down rules defaulted by the
+// autoscaling/v2 API when the behavior is set. The stabilization window is
+// left to the controller manager default.
+func hpaDefaultScaleDownRules() *hpa.HPAScalingRules {
+	selectMax := hpa.MaxChangePolicySelect
+	return &hpa.HPAScalingRules{
+		SelectPolicy: &selectMax,
+		Policies: []hpa.HPAScalingPolicy{
+			{Type: hpa.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		},
+	}
+}
+
 func HpaCR(cr *appsv1alpha1.APIcast) *hpa.HorizontalPodAutoscaler {
-	minPods := helper.Int32Ptr(1)
-	maxPods := int32(5)
-	cpuPercent := helper.Int32Ptr(85)
-	memoryPercent := helper.Int32Ptr(85)
+	minPods := helper.Int32Ptr(DefaultHpaMinReplicas)
+	maxPods := DefaultHpaMaxReplicas
+	cpuPercent := DefaultHpaCPUTargetUtilization
+	memoryPercent := DefaultHpaMemoryTargetUtilization
+	var extraMetrics []hpa.MetricSpec
+	var behavior *hpa.HorizontalPodAutoscalerBehavior
+
+	if autoscaling := cr.Spec.Autoscaling; autoscaling != nil {
+		if autoscaling.MinReplicas != nil {
+			minPods = helper.Int32Ptr(*autoscaling.MinReplicas)
+		}
+		if autoscaling.MaxReplicas != nil {
+			maxPods = *autoscaling.MaxReplicas
+		}
+		if autoscaling.CPUTargetUtilization != nil {
+			cpuPercent = *autoscaling.CPUTargetUtilization
+		}
+		if autoscaling.MemoryTargetUtilization != nil {
+			memoryPercent = *autoscaling.MemoryTargetUtilization
+		}
+		extraMetrics = autoscaling.Metrics
+		behavior = autoscaling.Behavior
+	}
+
+	metrics := []hpa.MetricSpec{}
+	if memoryPercent > 0 {
+		metrics = append(metrics, hpaResourceMetric(v1.ResourceMemory, memoryPercent))
+	}
+	if cpuPercent > 0 {
+		metrics = append(metrics, hpaResourceMetric(v1.ResourceCPU, cpuPercent))
+	}
+	metrics = append(metrics, extraMetrics...)
 
 	return &hpa.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "autoscaling/v2",
+			Kind:       "HorizontalPodAutoscaler",
+		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      cr.Name,
-			Namespace: cr.Namespace,
+			Name:            cr.Name,
+			Namespace:       cr.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*cr.GetOwnerReference()},
 		},
 		Spec: hpa.HorizontalPodAutoscalerSpec{
 			ScaleTargetRef: hpa.CrossVersionObjectReference{
@@ -44,27 +262,20 @@ func HpaCR(cr *appsv1alpha1.APIcast) *hpa.HorizontalPodAutoscaler {
 			},
 			MinReplicas: minPods,
 			MaxReplicas: maxPods,
-			Metrics: []hpa.MetricSpec{
-				{
-					Type: hpa.ResourceMetricSourceType,
-					Resource: &hpa.ResourceMetricSource{
-						Name: "memory",
-						Target: hpa.MetricTarget{
-							Type:               hpa.UtilizationMetricType,
-							AverageUtilization: memoryPercent,
-						},
-					},
-				},
-				{
-					Type: hpa.ResourceMetricSourceType,
-					Resource: &hpa.ResourceMetricSource{
-						Name: "cpu",
-						Target: hpa.MetricTarget{
-							Type:               hpa.UtilizationMetricType,
-							AverageUtilization: cpuPercent,
-						},
-					},
-				},
+			Metrics:     metrics,
+			Behavior:    behavior,
+		},
+	}
+}
+
+func hpaResourceMetric(name v1.ResourceName, averageUtilization int32) hpa.MetricSpec {
+	return hpa.MetricSpec{
+		Type: hpa.ResourceMetricSourceType,
+		Resource: &hpa.ResourceMetricSource{
+			Name: name,
+			Target: hpa.MetricTarget{
+				Type:               hpa.UtilizationMetricType,
+				AverageUtilization: helper.Int32Ptr(averageUtilization),
 			},
 		},
 	}
@@ -0,0 +1,254 @@
+package reconcilers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hpa "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+	"github.com/3scale/apicast-operator/pkg/helper"
+)
+
+func TestHpaCR(t *testing.T) {
+	requestsPerSecond := hpa.MetricSpec{
+		Type: hpa.PodsMetricSourceType,
+		Pods: &hpa.PodsMetricSource{
+			Metric: hpa.MetricIdentifier{Name: "nginx_http_requests_per_second"},
+			Target: hpa.MetricTarget{
+				Type:         hpa.AverageValueMetricType,
+				AverageValue: resource.NewQuantity(100, resource.DecimalSI),
+			},
+		},
+	}
+
+	scaleDownWindow := int32(600)
+	behavior := &hpa.HorizontalPodAutoscalerBehavior{
+		ScaleDown: &hpa.HPAScalingRules{StabilizationWindowSeconds: &scaleDownWindow},
+	}
+
+	tests := []struct {
+		name            string
+		spec            appsv1alpha1.APIcastSpec
+		expectedMin     int32
+		expectedMax     int32
+		expectedMetrics []hpa.MetricSpec
+		expectedBehav   *hpa.HorizontalPodAutoscalerBehavior
+	}{
+		{
+			"hpa shorthand uses defaults",
+			appsv1alpha1.APIcastSpec{Hpa: true},
+			1, 5,
+			[]hpa.MetricSpec{
+				hpaResourceMetric(v1.ResourceMemory, 85),
+				hpaResourceMetric(v1.ResourceCPU, 85),
+			},
+			nil,
+		},
+		{
+			"autoscaling overrides defaults",
+			appsv1alpha1.APIcastSpec{
+				Autoscaling: &appsv1alpha1.AutoscalingSpec{
+					MinReplicas:             helper.Int32Ptr(2),
+					MaxReplicas:             helper.Int32Ptr(10),
+					CPUTargetUtilization:    helper.Int32Ptr(70),
+					MemoryTargetUtilization: helper.Int32Ptr(0),
+					Metrics:                 []hpa.MetricSpec{requestsPerSecond},
+					Behavior:                behavior,
+				},
+			},
+			2, 10,
+			[]hpa.MetricSpec{
+				hpaResourceMetric(v1.ResourceCPU, 70),
+				requestsPerSecond,
+			},
+			behavior,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(subT *testing.T) {
+			cr := &appsv1alpha1.APIcast{
+				ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "ns"},
+				Spec:       tc.spec,
+			}
+
+			desired := HpaCR(cr)
+			if desired.Spec.ScaleTargetRef.Name != "apicast-example" {
+				subT.Errorf("unexpected scale target ref: %s", desired.Spec.ScaleTargetRef.Name)
+			}
+			if *desired.Spec.MinReplicas != tc.expectedMin {
+				subT.Errorf("expected min replicas %d, got %d", tc.expectedMin, *desired.Spec.MinReplicas)
+			}
+			if desired.Spec.MaxReplicas != tc.expectedMax {
+				subT.Errorf("expected max replicas %d, got %d", tc.expectedMax, desired.Spec.MaxReplicas)
+			}
+			if diff := cmp.Diff(tc.expectedMetrics, desired.Spec.Metrics); diff != "" {
+				subT.Errorf("unexpected metrics (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedBehav, desired.Spec.Behavior); diff != "" {
+				subT.Errorf("unexpected behavior (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHpaMutator(t *testing.T) {
+	cr := &appsv1alpha1.APIcast{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "ns"},
+		Spec:       appsv1alpha1.APIcastSpec{Hpa: true},
+	}
+
+	mutator := HpaMutator(
+		HpaScaleTargetRefMutator,
+		HpaMinReplicasMutator,
+		HpaMaxReplicasMutator,
+		HpaMetricsMutator,
+		HpaBehaviorMutator,
+	)
+
+	t.Run("no update when existing matches desired", func(subT *testing.T) {
+		existing := HpaCR(cr)
+		update, err := mutator(existing, HpaCR(cr))
+		if err != nil {
+			subT.Fatal(err)
+		}
+		if update {
+			subT.Fatal("expected no update")
+		}
+	})
+
+	t.Run("changed spec is reconciled", func(subT *testing.T) {
+		existing := HpaCR(cr)
+
+		changedCR := cr.DeepCopy()
+		changedCR.Spec.Autoscaling = &appsv1alpha1.AutoscalingSpec{
+			MaxReplicas:          helper.Int32Ptr(8),
+			CPUTargetUtilization: helper.Int32Ptr(60),
+		}
+		desired := HpaCR(changedCR)
+
+		update, err := mutator(existing, desired)
+		if err != nil {
+			subT.Fatal(err)
+		}
+		if !update {
+			subT.Fatal("expected update")
+		}
+		if existing.Spec.MaxReplicas != 8 {
+			subT.Errorf("expected max replicas 8, got %d", existing.Spec.MaxReplicas)
+		}
+		if diff := cmp.Diff(desired.Spec.Metrics, existing.Spec.Metrics); diff != "" {
+			subT.Errorf("unexpected metrics (-want,+got):\n%s", diff)
+		}
+	})
+}
+
+func TestHpaOwnerReferencesMutator(t *testing.T) {
+	cr := &appsv1alpha1.APIcast{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "ns", UID: "apicast-uid"},
+		Spec:       appsv1alpha1.APIcastSpec{Hpa: true},
+	}
+
+	// HPA created by a previous operator version
+	existing := HpaCR(cr)
+	existing.OwnerReferences = nil
+
+	update, err := HpaCreateOnlyMutator()(existing, HpaCR(cr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update {
+		t.Fatal("expected update")
+	}
+	if len(existing.OwnerReferences) != 1 || existing.OwnerReferences[0].UID != cr.UID {
+		t.Errorf("unexpected owner references %v", existing.OwnerReferences)
+	}
+
+	// manual changes are not reverted
+	existing.Spec.MaxReplicas = 12
+	update, err = HpaCreateOnlyMutator()(existing, HpaCR(cr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update || existing.Spec.MaxReplicas != 12 {
+		t.Errorf("expected no update, got update %t and max replicas %d", update, existing.Spec.MaxReplicas)
+	}
+}
+
+func TestHpaBehaviorMutator(t *testing.T) {
+	window := int32(300)
+	otherWindow := int32(0)
+
+	hpaFactory := func(behavior *hpa.HorizontalPodAutoscalerBehavior) *hpa.HorizontalPodAutoscaler {
+		return &hpa.HorizontalPodAutoscaler{Spec: hpa.HorizontalPodAutoscalerSpec{Behavior: behavior}}
+	}
+	// rules as defaulted by the API server
+	scaleUpRules := func(window *int32) *hpa.HPAScalingRules {
+		rules := hpaDefaultScaleUpRules()
+		rules.StabilizationWindowSeconds = window
+		return rules
+	}
+
+	tests := []struct {
+		name     string
+		existing *hpa.HorizontalPodAutoscaler
+		desired  *hpa.HorizontalPodAutoscaler
+		expected bool
+	}{
+		{
+			"no update when both are nil",
+			hpaFactory(nil), hpaFactory(nil), false,
+		},
+		{
+			"update when desired behavior removed",
+			hpaFactory(&hpa.HorizontalPodAutoscalerBehavior{}), hpaFactory(nil), true,
+		},
+		{
+			"no update when existing has server defaulted fields",
+			hpaFactory(&hpa.HorizontalPodAutoscalerBehavior{
+				ScaleUp: scaleUpRules(&window), ScaleDown: hpaDefaultScaleDownRules(),
+			}),
+			hpaFactory(&hpa.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &hpa.HPAScalingRules{StabilizationWindowSeconds: &window},
+			}),
+			false,
+		},
+		{
+			"update when desired field differs",
+			hpaFactory(&hpa.HorizontalPodAutoscalerBehavior{
+				ScaleUp: scaleUpRules(&window), ScaleDown: hpaDefaultScaleDownRules(),
+			}),
+			hpaFactory(&hpa.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &hpa.HPAScalingRules{StabilizationWindowSeconds: &otherWindow},
+			}),
+			true,
+		},
+		{
+			"update when desired rules removed",
+			hpaFactory(&hpa.HorizontalPodAutoscalerBehavior{
+				ScaleUp: scaleUpRules(&window), ScaleDown: &hpa.HPAScalingRules{StabilizationWindowSeconds: &window, SelectPolicy: hpaDefaultScaleDownRules().SelectPolicy, Policies: hpaDefaultScaleDownRules().Policies},
+			}),
+			hpaFactory(&hpa.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &hpa.HPAScalingRules{StabilizationWindowSeconds: &window},
+			}),
+			true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(subT *testing.T) {
+			update := HpaBehaviorMutator(tc.desired, tc.existing)
+			if update != tc.expected {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expected, update)
+			}
+			if update && tc.desired.Spec.Behavior != nil && !reflect.DeepEqual(tc.existing.Spec.Behavior.ScaleDown, defaultedHpaScalingRules(tc.desired.Spec.Behavior.ScaleDown, hpaDefaultScaleDownRules())) {
+				subT.Errorf("unexpected scale down rules %v", tc.existing.Spec.Behavior.ScaleDown)
+			}
+		})
+	}
+}
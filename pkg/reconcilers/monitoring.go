@@ -0,0 +1,89 @@
+package reconcilers
+
+import (
+	"fmt"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
+)
+
+func ServiceMonitorMutator(existingObj, desiredObj k8sutils.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*monitoringv1.ServiceMonitor)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *monitoringv1.ServiceMonitor", existingObj)
+	}
+	desired, ok := desiredObj.(*monitoringv1.ServiceMonitor)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *monitoringv1.ServiceMonitor", desiredObj)
+	}
+
+	update := false
+
+	// Labels are used by the Prometheus instance to select the monitor
+	k8sutils.MergeMapStringString(&update, &existing.Labels, desired.Labels)
+
+	if !equality.Semantic.DeepEqual(existing.Spec.Endpoints, desired.Spec.Endpoints) {
+		existing.Spec.Endpoints = desired.Spec.Endpoints
+		update = true
+	}
+
+	if !equality.Semantic.DeepEqual(existing.Spec.Selector, desired.Spec.Selector) {
+		existing.Spec.Selector = desired.Spec.Selector
+		update = true
+	}
+
+	return update, nil
+}
+
+func PodMonitorMutator(existingObj, desiredObj k8sutils.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*monitoringv1.PodMonitor)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *monitoringv1.PodMonitor", existingObj)
+	}
+	desired, ok := desiredObj.(*monitoringv1.PodMonitor)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *monitoringv1.PodMonitor", desiredObj)
+	}
+
+	update := false
+
+	// Labels are used by the Prometheus instance to select the monitor
+	k8sutils.MergeMapStringString(&update, &existing.Labels, desired.Labels)
+
+	if !equality.Semantic.DeepEqual(existing.Spec.PodMetricsEndpoints, desired.Spec.PodMetricsEndpoints) {
+		existing.Spec.PodMetricsEndpoints = desired.Spec.PodMetricsEndpoints
+		update = true
+	}
+
+	if !equality.Semantic.DeepEqual(existing.Spec.Selector, desired.Spec.Selector) {
+		existing.Spec.Selector = desired.Spec.Selector
+		update = true
+	}
+
+	return update, nil
+}
+
+func PrometheusRuleMutator(existingObj, desiredObj k8sutils.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*monitoringv1.PrometheusRule)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *monitoringv1.PrometheusRule", existingObj)
+	}
+	desired, ok := desiredObj.(*monitoringv1.PrometheusRule)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *monitoringv1.PrometheusRule", desiredObj)
+	}
+
+	update := false
+
+	// Labels are used by the Prometheus instance to select the rule
+	k8sutils.MergeMapStringString(&update, &existing.Labels, desired.Labels)
+
+	if !equality.Semantic.DeepEqual(existing.Spec.Groups, desired.Spec.Groups) {
+		existing.Spec.Groups = desired.Spec.Groups
+		update = true
+	}
+
+	return update, nil
+}
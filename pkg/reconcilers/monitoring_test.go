@@ -0,0 +1,166 @@
+package reconcilers
+
+import (
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestServiceMonitorMutator(t *testing.T) {
+	serviceMonitorFactory := func(labels map[string]string, interval monitoringv1.Duration) *monitoringv1.ServiceMonitor {
+		return &monitoringv1.ServiceMonitor{
+			ObjectMeta: metav1.ObjectMeta{Labels: labels},
+			Spec: monitoringv1.ServiceMonitorSpec{
+				Endpoints: []monitoringv1.Endpoint{{Port: "metrics", Path: "/metrics", Interval: interval}},
+				Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"deployment": "apicast-example"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		existing *monitoringv1.ServiceMonitor
+		desired  *monitoringv1.ServiceMonitor
+		expected bool
+	}{
+		{
+			"test false when desired and existing are the same",
+			serviceMonitorFactory(map[string]string{"app": "apicast"}, "30s"),
+			serviceMonitorFactory(map[string]string{"app": "apicast"}, "30s"),
+			false,
+		},
+		{
+			"test false when existing has additional labels",
+			serviceMonitorFactory(map[string]string{"app": "apicast", "foo": "bar"}, "30s"),
+			serviceMonitorFactory(map[string]string{"app": "apicast"}, "30s"),
+			false,
+		},
+		{
+			"test true when desired label is missing",
+			serviceMonitorFactory(map[string]string{"app": "apicast"}, "30s"),
+			serviceMonitorFactory(map[string]string{"app": "apicast", "release": "prometheus"}, "30s"),
+			true,
+		},
+		{
+			"test true when desired and existing endpoints do not match",
+			serviceMonitorFactory(map[string]string{"app": "apicast"}, "30s"),
+			serviceMonitorFactory(map[string]string{"app": "apicast"}, "1m"),
+			true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			changed, err := ServiceMonitorMutator(tc.existing, tc.desired)
+			if err != nil {
+				t.Error("unexpected error: ", err)
+			}
+			if changed != tc.expected {
+				t.Error("expected mutator return ", tc.expected, " but got: ", changed)
+			}
+		})
+	}
+}
+
+func TestPodMonitorMutator(t *testing.T) {
+	podMonitorFactory := func(labels map[string]string, scrapeTimeout monitoringv1.Duration) *monitoringv1.PodMonitor {
+		return &monitoringv1.PodMonitor{
+			ObjectMeta: metav1.ObjectMeta{Labels: labels},
+			Spec: monitoringv1.PodMonitorSpec{
+				PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{{Port: "metrics", Path: "/metrics", ScrapeTimeout: scrapeTimeout}},
+				Selector:            metav1.LabelSelector{MatchLabels: map[string]string{"deployment": "apicast-example"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		existing *monitoringv1.PodMonitor
+		desired  *monitoringv1.PodMonitor
+		expected bool
+	}{
+		{
+			"test false when desired and existing are the same",
+			podMonitorFactory(map[string]string{"app": "apicast"}, "10s"),
+			podMonitorFactory(map[string]string{"app": "apicast"}, "10s"),
+			false,
+		},
+		{
+			"test true when desired label is missing",
+			podMonitorFactory(nil, "10s"),
+			podMonitorFactory(map[string]string{"release": "prometheus"}, "10s"),
+			true,
+		},
+		{
+			"test true when desired and existing endpoints do not match",
+			podMonitorFactory(map[string]string{"app": "apicast"}, "10s"),
+			podMonitorFactory(map[string]string{"app": "apicast"}, "5s"),
+			true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			changed, err := PodMonitorMutator(tc.existing, tc.desired)
+			if err != nil {
+				t.Error("unexpected error: ", err)
+			}
+			if changed != tc.expected {
+				t.Error("expected mutator return ", tc.expected, " but got: ", changed)
+			}
+		})
+	}
+}
+
+func TestPrometheusRuleMutator(t *testing.T) {
+	prometheusRuleFactory := func(labels map[string]string, expr string) *monitoringv1.PrometheusRule {
+		return &monitoringv1.PrometheusRule{
+			ObjectMeta: metav1.ObjectMeta{Labels: labels},
+			Spec: monitoringv1.PrometheusRuleSpec{
+				Groups: []monitoringv1.RuleGroup{
+					{Name: "apicast.rules", Rules: []monitoringv1.Rule{{Alert: "APIcastErrorLogs", Expr: intstr.FromString(expr)}}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		existing *monitoringv1.PrometheusRule
+		desired  *monitoringv1.PrometheusRule
+		expected bool
+	}{
+		{
+			"test false when desired and existing are the same",
+			prometheusRuleFactory(map[string]string{"app": "apicast"}, "up > 1"),
+			prometheusRuleFactory(map[string]string{"app": "apicast"}, "up > 1"),
+			false,
+		},
+		{
+			"test true when desired label is missing",
+			prometheusRuleFactory(nil, "up > 1"),
+			prometheusRuleFactory(map[string]string{"release": "prometheus"}, "up > 1"),
+			true,
+		},
+		{
+			"test true when desired and existing groups do not match",
+			prometheusRuleFactory(map[string]string{"app": "apicast"}, "up > 1"),
+			prometheusRuleFactory(map[string]string{"app": "apicast"}, "up > 2"),
+			true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			changed, err := PrometheusRuleMutator(tc.existing, tc.desired)
+			if err != nil {
+				t.Error("unexpected error: ", err)
+			}
+			if changed != tc.expected {
+				t.Error("expected mutator return ", tc.expected, " but got: ", changed)
+			}
+		})
+	}
+}
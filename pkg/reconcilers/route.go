@@ -0,0 +1,50 @@
+package reconcilers
+
+import (
+	"fmt"
+	"reflect"
+
+	routev1 "github.com/openshift/api/route/v1"
+
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
+)
+
+func RouteMutator(existingObj, desiredObj k8sutils.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*routev1.Route)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *routev1.Route", existingObj)
+	}
+	desired, ok := desiredObj.(*routev1.Route)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *routev1.Route", desiredObj)
+	}
+
+	update := false
+
+	if existing.Spec.Host != desired.Spec.Host {
+		existing.Spec.Host = desired.Spec.Host
+		update = true
+	}
+
+	if !reflect.DeepEqual(existing.Spec.To, desired.Spec.To) {
+		existing.Spec.To = desired.Spec.To
+		update = true
+	}
+
+	if !reflect.DeepEqual(existing.Spec.Port, desired.Spec.Port) {
+		existing.Spec.Port = desired.Spec.Port
+		update = true
+	}
+
+	if !reflect.DeepEqual(existing.Spec.TLS, desired.Spec.TLS) {
+		existing.Spec.TLS = desired.Spec.TLS
+		update = true
+	}
+
+	if existing.Spec.WildcardPolicy != desired.Spec.WildcardPolicy {
+		existing.Spec.WildcardPolicy = desired.Spec.WildcardPolicy
+		update = true
+	}
+
+	return update, nil
+}
@@ -0,0 +1,61 @@
+package reconcilers
+
+import (
+	"testing"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestRouteMutator(t *testing.T) {
+	routeFactory := func(host, targetPort string, tls *routev1.TLSConfig) *routev1.Route {
+		return &routev1.Route{
+			Spec: routev1.RouteSpec{
+				Host:           host,
+				To:             routev1.RouteTargetReference{Kind: "Service", Name: "apicast-example"},
+				Port:           &routev1.RoutePort{TargetPort: intstr.FromString(targetPort)},
+				TLS:            tls,
+				WildcardPolicy: routev1.WildcardPolicyNone,
+			},
+		}
+	}
+
+	edge := &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}
+	passthrough := &routev1.TLSConfig{Termination: routev1.TLSTerminationPassthrough}
+
+	tests := []struct {
+		name     string
+		existing *routev1.Route
+		desired  *routev1.Route
+		expected bool
+	}{
+		{
+			"test false when desired and existing are the same",
+			routeFactory("foo", "proxy", edge), routeFactory("foo", "proxy", edge), false,
+		},
+		{
+			"test true when desired and existing host do not match",
+			routeFactory("foo", "proxy", nil), routeFactory("bar", "proxy", nil), true,
+		},
+		{
+			"test true when desired and existing termination do not match",
+			routeFactory("foo", "proxy", edge), routeFactory("foo", "httpsproxy", passthrough), true,
+		},
+		{
+			"test true when desired tls is removed",
+			routeFactory("foo", "proxy", edge), routeFactory("foo", "proxy", nil), true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			changed, err := RouteMutator(tc.existing, tc.desired)
+			if err != nil {
+				t.Error("unexpected error: ", err)
+			}
+			if changed != tc.expected {
+				t.Error("expected mutator return ", tc.expected, " but got: ", changed)
+			}
+		})
+	}
+}
@@ -51,3 +51,32 @@ func SecretStringDataMutator(desired, existing *v1.Secret) bool {
 
 	return updated
 }
+
+// SecretDataMutator ensures the data of the secret is reconciled. The secret
+// type is immutable, it is only set on creation.
+func SecretDataMutator(desired, existing *v1.Secret) bool {
+	updated := false
+
+	if !reflect.DeepEqual(existing.Data, desired.Data) {
+		updated = true
+		existing.Data = desired.Data
+	}
+
+	return updated
+}
+
+func SecretLabelsMutator(desired, existing *v1.Secret) bool {
+	updated := false
+
+	k8sutils.MergeMapStringString(&updated, &existing.Labels, desired.Labels)
+
+	return updated
+}
+
+func SecretAnnotationsMutator(desired, existing *v1.Secret) bool {
+	updated := false
+
+	k8sutils.MergeMapStringString(&updated, &existing.Annotations, desired.Annotations)
+
+	return updated
+}
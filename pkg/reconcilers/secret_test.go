@@ -0,0 +1,76 @@
+package reconcilers
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSecretDataMutator(t *testing.T) {
+	secretFactory := func(secretType v1.SecretType, data map[string][]byte) *v1.Secret {
+		return &v1.Secret{Type: secretType, Data: data}
+	}
+
+	tests := []struct {
+		name     string
+		existing *v1.Secret
+		desired  *v1.Secret
+		expected bool
+	}{
+		{
+			"test false when desired and existing are the same",
+			secretFactory(v1.SecretTypeOpaque, map[string][]byte{"a": []byte("1")}),
+			secretFactory(v1.SecretTypeOpaque, map[string][]byte{"a": []byte("1")}),
+			false,
+		},
+		{
+			"test true when desired and existing data do not match",
+			secretFactory(v1.SecretTypeOpaque, map[string][]byte{"a": []byte("1")}),
+			secretFactory(v1.SecretTypeOpaque, map[string][]byte{"a": []byte("2")}),
+			true,
+		},
+		{
+			"test true when desired has a key removed",
+			secretFactory(v1.SecretTypeOpaque, map[string][]byte{"a": []byte("1"), "b": []byte("2")}),
+			secretFactory(v1.SecretTypeOpaque, map[string][]byte{"a": []byte("1")}),
+			true,
+		},
+		{
+			"test false when desired and existing type do not match",
+			secretFactory(v1.SecretTypeOpaque, map[string][]byte{"a": []byte("1")}),
+			secretFactory(v1.SecretTypeTLS, map[string][]byte{"a": []byte("1")}),
+			false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(subT *testing.T) {
+			update := SecretDataMutator(tc.desired, tc.existing)
+			if update != tc.expected {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expected, update)
+			}
+			if !reflect.DeepEqual(tc.existing.Data, tc.desired.Data) {
+				subT.Fatalf("secret not mutated, expected: %v, got: %v", tc.desired, tc.existing)
+			}
+			if tc.existing.Type != v1.SecretTypeOpaque {
+				subT.Fatalf("secret type mutated, got: %s", tc.existing.Type)
+			}
+		})
+	}
+}
+
+func TestSecretLabelsMutator(t *testing.T) {
+	existing := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"other": "value"}}}
+	desired := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"apicast.apps.3scale.net/watched-by": "apicast"}}}
+
+	if !SecretLabelsMutator(desired, existing) {
+		t.Fatal("expected update when desired label is missing")
+	}
+	if existing.Labels["other"] != "value" || existing.Labels["apicast.apps.3scale.net/watched-by"] != "apicast" {
+		t.Fatalf("labels not merged: %v", existing.Labels)
+	}
+	if SecretLabelsMutator(desired, existing) {
+		t.Fatal("expected no update when labels are already merged")
+	}
+}
@@ -55,3 +55,11 @@ func ServiceSelectorMutator(desired, existing *v1.Service) bool {
 
 	return updated
 }
+
+func ServiceLabelsMutator(desired, existing *v1.Service) bool {
+	updated := false
+
+	k8sutils.MergeMapStringString(&updated, &existing.Labels, desired.Labels)
+
+	return updated
+}
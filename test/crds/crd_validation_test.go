@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/3scale/apicast-operator/apis/apps/v1alpha1"
+	"github.com/3scale/apicast-operator/apis/apps/v1beta1"
 	"github.com/RHsyseng/operator-utils/pkg/validation"
 	"github.com/ghodss/yaml"
 
@@ -18,9 +19,38 @@ import (
 
 // Missing fields path omissions
 const (
-	lastTransitionTimePath = "/status/conditions/lastTransitionTime"
+	lastTransitionTimePath           = "/status/conditions/lastTransitionTime"
+	canaryAvailableSincePath         = "/status/canary/availableSince"
+	lastConfigurationRolloutTimePath = "/status/lastConfigurationRolloutTime"
+	upgradeHistoryTimePath           = "/status/upgrade/history/time"
+	lastAdminPortalCheckTimePath     = "/status/lastAdminPortalCheckTime"
+	// HPA metric targets are resource.Quantity values, defined as
+	// int-or-string in the CRD schema
+	autoscalingMetricsPath = "/spec/autoscaling/metrics"
+	// The relabeling modulus is an uint64, defined as integer in the CRD
+	// schema
+	monitoringModulusPath    = "/spec/monitoring/relabelings/modulus"
+	observabilityModulusPath = "/spec/observability/monitoring/relabelings/modulus"
+	// Core Kubernetes container and volume types embed int-or-string,
+	// resource.Quantity and ObjectMeta fields which the CRD schema defines
+	// differently than the Go structs
+	sidecarsPath       = "/spec/sidecars"
+	initContainersPath = "/spec/initContainers"
+	extraVolumesPath   = "/spec/extraVolumes"
+	// The pod template override is a schemaless strategic merge patch
+	podTemplateOverridePath = "/spec/podTemplateOverride"
+	// The rolling update parameters are int-or-string values
+	maxSurgePath       = "/spec/deploymentStrategy/maxSurge"
+	maxUnavailablePath = "/spec/deploymentStrategy/maxUnavailable"
+	// The APIcastFleet embeds the APIcast spec in its template
+	fleetTemplateSpecPath = "/spec/template"
+	// The APIcastFleet overlays are schemaless strategic merge patches
+	fleetNamespaceOverlaysPath = "/spec/namespaceOverlays/overlay"
+	fleetMemberOverlayPath     = "/spec/members/overlay"
 )
 
+var autoscalingMetricSources = []string{"containerResource", "external", "object", "pods", "resource"}
+
 type testCRInfo struct {
 	crPrefix   string
 	apiVersion string
@@ -32,15 +62,29 @@ func TestSampleCustomResources(t *testing.T) {
 		"../../config/samples",
 		"../../doc/cr_samples",
 	}
-	crdCrMap := map[string]testCRInfo{
+	crdCrMap := map[string][]testCRInfo{
 		"apps.3scale.net_apicasts.yaml": {
-			crPrefix:   "apps_v1alpha1_apicast",
-			apiVersion: v1alpha1.GroupVersion.Version,
+			{
+				crPrefix:   "apps_v1alpha1_apicast_",
+				apiVersion: v1alpha1.GroupVersion.Version,
+			},
+			{
+				crPrefix:   "apps_v1beta1_apicast_",
+				apiVersion: v1beta1.GroupVersion.Version,
+			},
+		},
+		"apps.3scale.net_apicastfleets.yaml": {
+			{
+				crPrefix:   "apps_v1alpha1_apicastfleet",
+				apiVersion: v1alpha1.GroupVersion.Version,
+			},
 		},
 	}
-	for crd, elem := range crdCrMap {
-		for _, samplesRoot := range samplesRootList {
-			validateCustomResources(t, schemaRoot, samplesRoot, crd, elem.crPrefix, elem.apiVersion)
+	for crd, elems := range crdCrMap {
+		for _, elem := range elems {
+			for _, samplesRoot := range samplesRootList {
+				validateCustomResources(t, schemaRoot, samplesRoot, crd, elem.crPrefix, elem.apiVersion)
+			}
 		}
 	}
 }
@@ -70,29 +114,84 @@ type testCRDInfo struct {
 func TestCompleteCRD(t *testing.T) {
 	root := "../../bundle/manifests"
 
-	crdStructMap := map[string]testCRDInfo{
+	crdStructMap := map[string][]testCRDInfo{
 		"apps.3scale.net_apicasts.yaml": {
-			obj:        &v1alpha1.APIcast{},
-			apiVersion: v1alpha1.GroupVersion.Version,
+			{
+				obj:        &v1alpha1.APIcast{},
+				apiVersion: v1alpha1.GroupVersion.Version,
+			},
+			{
+				obj:        &v1beta1.APIcast{},
+				apiVersion: v1beta1.GroupVersion.Version,
+			},
+		},
+		"apps.3scale.net_apicastfleets.yaml": {
+			{
+				obj:        &v1alpha1.APIcastFleet{},
+				apiVersion: v1alpha1.GroupVersion.Version,
+			},
 		},
 	}
 
 	pathOmissions := []string{
 		lastTransitionTimePath,
+		canaryAvailableSincePath,
+		lastConfigurationRolloutTimePath,
+		upgradeHistoryTimePath,
+		lastAdminPortalCheckTimePath,
+		monitoringModulusPath,
+		observabilityModulusPath,
+		sidecarsPath,
+		initContainersPath,
+		extraVolumesPath,
+		podTemplateOverridePath,
+		maxSurgePath,
+		maxUnavailablePath,
+	}
+	for _, source := range autoscalingMetricSources {
+		pathOmissions = append(pathOmissions,
+			fmt.Sprintf("%s/%s/target/averageValue", autoscalingMetricsPath, source),
+			fmt.Sprintf("%s/%s/target/value", autoscalingMetricsPath, source),
+		)
+	}
+	for _, omission := range pathOmissions {
+		if strings.HasPrefix(omission, "/spec/") {
+			pathOmissions = append(pathOmissions, fleetTemplateSpecPath+omission)
+		}
 	}
+	pathOmissions = append(pathOmissions, fleetNamespaceOverlaysPath, fleetMemberOverlayPath)
 
-	for crd, elem := range crdStructMap {
-		schema := getSchemaVersioned(t, fmt.Sprintf("%s/%s", root, crd), elem.apiVersion)
-		missingEntries := schema.GetMissingEntries(elem.obj)
-		for _, missing := range missingEntries {
-			if missingFieldPathInPathOmissions(missing.Path, pathOmissions) {
-				continue
+	for crd, elems := range crdStructMap {
+		for _, elem := range elems {
+			schema := getSchemaVersioned(t, fmt.Sprintf("%s/%s", root, crd), elem.apiVersion)
+			missingEntries := schema.GetMissingEntries(elem.obj)
+			for _, missing := range missingEntries {
+				if missingFieldPathInPathOmissions(missing.Path, pathOmissions) {
+					continue
+				}
+				assert.Fail(t, "Discrepancy between CRD and Struct", "CRD: %s (%s): Missing or incorrect schema validation at %s, expected type %s", crd, elem.apiVersion, missing.Path, missing.Type)
 			}
-			assert.Fail(t, "Discrepancy between CRD and Struct", "CRD: %s: Missing or incorrect schema validation at %s, expected type %s", crd, missing.Path, missing.Type)
 		}
 	}
 }
 
+// maxCRDSize keeps the CRDs well below the default 1.5 MiB request limit of
+// etcd, they are applied server side as they exceed the 256 KiB limit of the
+// last-applied-configuration annotation
+const maxCRDSize = 1024 * 1024
+
+func TestCRDSize(t *testing.T) {
+	root := "../../config/crd/bases"
+
+	for _, crd := range []string{"apps.3scale.net_apicasts.yaml", "apps.3scale.net_apicastfleets.yaml"} {
+		bytes, err := os.ReadFile(filepath.Join(root, crd))
+		assert.NoError(t, err, "Error reading CRD yaml from %v", crd)
+		compact, err := yaml.YAMLToJSON(bytes)
+		assert.NoError(t, err)
+		assert.Less(t, len(compact), maxCRDSize, "CRD %s is too large", crd)
+	}
+}
+
 func getSchemaVersioned(t *testing.T, crd string, version string) validation.Schema {
 	bytes, err := os.ReadFile(crd)
 	assert.NoError(t, err, "Error reading CRD yaml from %v", crd)